@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// requestTimeout bounds a single call to the API
+const requestTimeout = 30 * time.Second
+
+// client talks to the GraphQL API of a formify instance with an API key.
+type client struct {
+	endpoint string
+	apiKey   string
+	http     *http.Client
+}
+
+func newClient(endpoint, apiKey string) *client {
+	return &client{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		http:     &http.Client{Timeout: requestTimeout},
+	}
+}
+
+type graphQLError struct {
+	Message    string                 `json:"message"`
+	Extensions map[string]interface{} `json:"extensions"`
+}
+
+// do runs a GraphQL operation and decodes its data into result.
+func (c *client) do(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("api-key", c.apiKey)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []graphQLError  `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return fmt.Errorf("unexpected response from %s (%s): %w", c.endpoint, resp.Status, err)
+	}
+	if len(payload.Errors) > 0 {
+		return formatErrors(payload.Errors)
+	}
+	return json.Unmarshal(payload.Data, result)
+}
+
+// formatErrors lists the problems of an invalid document one per line.
+func formatErrors(errs []graphQLError) error {
+	lines := make([]string, 0, len(errs))
+	for _, e := range errs {
+		issues, _ := e.Extensions["issues"].([]interface{})
+		if len(issues) == 0 {
+			lines = append(lines, e.Message)
+			continue
+		}
+		for _, i := range issues {
+			issue, _ := i.(map[string]interface{})
+			if path, _ := issue["path"].(string); path != "" {
+				lines = append(lines, fmt.Sprintf("%s: %v", path, issue["message"]))
+			} else {
+				lines = append(lines, fmt.Sprint(issue["message"]))
+			}
+		}
+	}
+	return fmt.Errorf("%s", strings.Join(lines, "\n"))
+}
+
+type remoteForm struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// liveDocument returns the live form with the key as a YAML document, or nil when
+// there is none.
+func (c *client) liveDocument(ctx context.Context, key string, workspaceID *string) (*remoteForm, string, error) {
+	var data struct {
+		FormByKey *remoteForm `json:"formByKey"`
+	}
+	err := c.do(ctx, `query($key: String!, $workspaceId: ID) {
+		formByKey(key: $key, workspaceId: $workspaceId) { id title }
+	}`, map[string]interface{}{"key": key, "workspaceId": workspaceID}, &data)
+	if err != nil || data.FormByKey == nil {
+		return nil, "", err
+	}
+
+	var export struct {
+		ExportForm string `json:"exportForm"`
+	}
+	err = c.do(ctx, `query($id: ID!) { exportForm(id: $id, format: YAML) }`,
+		map[string]interface{}{"id": data.FormByKey.ID}, &export)
+	if err != nil {
+		return nil, "", err
+	}
+	return data.FormByKey, export.ExportForm, nil
+}
+
+// apply creates or updates the form described by the document and returns what was done.
+func (c *client) apply(ctx context.Context, document string, workspaceID *string) (*remoteForm, string, error) {
+	var data struct {
+		ApplyForm struct {
+			Form   remoteForm `json:"form"`
+			Action string     `json:"action"`
+		} `json:"applyForm"`
+	}
+	err := c.do(ctx, `mutation($document: String!, $workspaceId: ID) {
+		applyForm(document: $document, format: YAML, workspaceId: $workspaceId) {
+			form { id title }
+			action
+		}
+	}`, map[string]interface{}{"document": document, "workspaceId": workspaceID}, &data)
+	if err != nil {
+		return nil, "", err
+	}
+	return &data.ApplyForm.Form, data.ApplyForm.Action, nil
+}
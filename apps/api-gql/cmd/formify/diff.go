@@ -0,0 +1,49 @@
+package main
+
+import "strings"
+
+// diffLines returns a line diff of a and b: unchanged lines start with two spaces,
+// removed ones with "- " and added ones with "+ ".
+func diffLines(a, b string) []string {
+	x := strings.Split(strings.TrimRight(a, "\n"), "\n")
+	y := strings.Split(strings.TrimRight(b, "\n"), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var result []string
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			result = append(result, "  "+x[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, "- "+x[i])
+			i++
+		default:
+			result = append(result, "+ "+y[j])
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		result = append(result, "- "+x[i])
+	}
+	for ; j < len(y); j++ {
+		result = append(result, "+ "+y[j])
+	}
+	return result
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []string
+	}{
+		{
+			name: "same",
+			a:    "title: A\nkey: a\n",
+			b:    "title: A\nkey: a",
+			want: []string{"  title: A", "  key: a"},
+		},
+		{
+			name: "changed line",
+			a:    "title: A\nkey: a\naccess: PRIVATE\n",
+			b:    "title: B\nkey: a\naccess: PRIVATE\n",
+			want: []string{"- title: A", "+ title: B", "  key: a", "  access: PRIVATE"},
+		},
+		{
+			name: "added and removed",
+			a:    "a\nb\nc\n",
+			b:    "b\nc\nd\n",
+			want: []string{"- a", "  b", "  c", "+ d"},
+		},
+		{
+			name: "inserted in the middle",
+			a:    "a\nc\n",
+			b:    "a\nb\nc\n",
+			want: []string{"  a", "+ b", "  c"},
+		},
+		{
+			name: "nothing in common",
+			a:    "a\n",
+			b:    "b\nc\n",
+			want: []string{"- a", "+ b", "+ c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffLines(tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
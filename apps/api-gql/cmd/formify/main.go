@@ -0,0 +1,206 @@
+// Command formify keeps forms in sync with YAML form documents kept in a directory,
+// so changes to surveys can be reviewed in pull requests like code.
+//
+//	FORMIFY_API_KEY=... formify -dir forms plan
+//	FORMIFY_API_KEY=... formify -dir forms apply
+//
+// Every document needs a key; it is stored on the form and matches documents to live
+// forms. plan shows what apply would change; apply creates missing forms and updates
+// changed ones, so running it twice changes nothing. Forms whose documents were removed
+// are left alone.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/formdoc"
+)
+
+type localForm struct {
+	path     string
+	raw      string
+	document *formdoc.Document
+}
+
+func main() {
+	flags := flag.NewFlagSet("formify", flag.ExitOnError)
+	dir := flags.String("dir", ".", "directory with the YAML form documents")
+	endpoint := flags.String("url", envOr("FORMIFY_URL", "http://localhost:8080/query"), "GraphQL endpoint of the formify instance")
+	workspace := flags.String("workspace", os.Getenv("FORMIFY_WORKSPACE"), "workspace ID the forms belong to; the personal workspace when empty")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: formify [flags] plan|apply")
+		fmt.Fprintln(flags.Output(), "The API key is read from FORMIFY_API_KEY; plan needs the forms:read scope, apply forms:write too.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(os.Args[1:])
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	apiKey := os.Getenv("FORMIFY_API_KEY")
+	if apiKey == "" {
+		fail(errors.New("FORMIFY_API_KEY is not set"))
+	}
+
+	var workspaceID *string
+	if *workspace != "" {
+		workspaceID = workspace
+	}
+
+	forms, err := loadForms(*dir)
+	if err != nil {
+		fail(err)
+	}
+
+	ctx := context.Background()
+	c := newClient(*endpoint, apiKey)
+	switch flags.Arg(0) {
+	case "plan":
+		err = plan(ctx, c, forms, workspaceID)
+	case "apply":
+		err = apply(ctx, c, forms, workspaceID)
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+// loadForms reads and validates every .yaml and .yml file under dir, by key.
+func loadForms(dir string) ([]localForm, error) {
+	var forms []localForm
+	var problems []string
+	keys := make(map[string]string)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || (filepath.Ext(path) != ".yaml" && filepath.Ext(path) != ".yml") {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		doc, err := formdoc.Parse(data, formdoc.FormatYAML)
+		var invalid *formdoc.ValidationError
+		switch {
+		case errors.As(err, &invalid):
+			for _, issue := range invalid.Issues {
+				problems = append(problems, fmt.Sprintf("%s: %s", path, describe(issue)))
+			}
+			return nil
+		case err != nil:
+			return err
+		case doc.Key == "":
+			problems = append(problems, fmt.Sprintf("%s: key: is required", path))
+			return nil
+		}
+
+		if other, ok := keys[doc.Key]; ok {
+			problems = append(problems, fmt.Sprintf("%s: key: %q is already used by %s", path, doc.Key, other))
+			return nil
+		}
+		keys[doc.Key] = path
+		forms = append(forms, localForm{path: path, raw: string(data), document: doc})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) > 0 {
+		return nil, errors.New(strings.Join(problems, "\n"))
+	}
+
+	sort.Slice(forms, func(i, j int) bool { return forms[i].document.Key < forms[j].document.Key })
+	return forms, nil
+}
+
+func plan(ctx context.Context, c *client, forms []localForm, workspaceID *string) error {
+	var created, updated, unchanged int
+	for _, f := range forms {
+		key := f.document.Key
+		live, raw, err := c.liveDocument(ctx, key, workspaceID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+
+		if live == nil {
+			created++
+			fmt.Printf("+ %s: create %q from %s\n", key, f.document.Title, f.path)
+			continue
+		}
+
+		current, err := formdoc.Parse([]byte(raw), formdoc.FormatYAML)
+		if err != nil {
+			return fmt.Errorf("%s: cannot read the live form: %w", key, err)
+		}
+		if formdoc.Equal(current, f.document) {
+			unchanged++
+			continue
+		}
+
+		updated++
+		fmt.Printf("~ %s: update form %s from %s\n", key, live.ID, f.path)
+		if !formdoc.SameQuestions(current, f.document) {
+			fmt.Println("  questions will be updated; removed questions and options lose their answers")
+		}
+		before, err := formdoc.Marshal(current.Normalized(), formdoc.FormatYAML)
+		if err != nil {
+			return err
+		}
+		after, err := formdoc.Marshal(f.document.Normalized(), formdoc.FormatYAML)
+		if err != nil {
+			return err
+		}
+		for _, line := range diffLines(string(before), string(after)) {
+			fmt.Println("    " + line)
+		}
+	}
+
+	fmt.Printf("\nPlan: %d to create, %d to update, %d unchanged.\n", created, updated, unchanged)
+	return nil
+}
+
+func apply(ctx context.Context, c *client, forms []localForm, workspaceID *string) error {
+	for _, f := range forms {
+		form, action, err := c.apply(ctx, f.raw, workspaceID)
+		if err != nil {
+			return fmt.Errorf("%s (%s): %w", f.document.Key, f.path, err)
+		}
+		fmt.Printf("%s: %s (form %s)\n", f.document.Key, strings.ToLower(action), form.ID)
+	}
+	return nil
+}
+
+func describe(issue formdoc.Issue) string {
+	if issue.Path == "" {
+		return issue.Message
+	}
+	return issue.Path + ": " + issue.Message
+}
+
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
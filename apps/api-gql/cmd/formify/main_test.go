@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func formFile(key string) string {
+	return "version: 1\nkey: " + key + "\ntitle: Form " + key + "\nquestions:\n  - text: Name?\n    type: SHORT_TEXT\n"
+}
+
+func TestLoadForms(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"survey.yaml":         formFile("survey"),
+		"team/feedback.yml":   formFile("feedback"),
+		"README.md":           "not a form",
+		"team/notes.txt":      "not a form either",
+		"archive/intake.yaml": formFile("intake"),
+	})
+
+	forms, err := loadForms(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, f := range forms {
+		keys = append(keys, f.document.Key)
+		if f.raw == "" || !strings.HasPrefix(f.path, dir) {
+			t.Errorf("form %s = %+v, want its file", f.document.Key, f)
+		}
+	}
+	if want := []string{"feedback", "intake", "survey"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+}
+
+func TestLoadFormsReportsEveryProblem(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.yaml":       formFile("survey"),
+		"b.yaml":       formFile("survey"),
+		"keyless.yaml": "version: 1\ntitle: No key\nquestions:\n  - text: Name?\n    type: SHORT_TEXT\n",
+		"bad.yaml":     "version: 1\nkey: bad\ntitle: Bad\nquestions:\n  - text: Name?\n    type: NO_SUCH_TYPE\n",
+	})
+
+	_, err := loadForms(dir)
+	if err == nil {
+		t.Fatal("loadForms() succeeded")
+	}
+	for _, want := range []string{
+		filepath.Join(dir, "b.yaml") + `: key: "survey" is already used by ` + filepath.Join(dir, "a.yaml"),
+		filepath.Join(dir, "keyless.yaml") + ": key: is required",
+		filepath.Join(dir, "bad.yaml") + ": ",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %q, want %q", err, want)
+		}
+	}
+	if lines := strings.Count(err.Error(), "\n") + 1; lines < 3 {
+		t.Errorf("error = %q, want a line per problem", err)
+	}
+}
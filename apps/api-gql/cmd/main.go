@@ -7,12 +7,28 @@ import (
 	"time"
 
 	"github.com/TrySquadDF/formify/api-gql/internal/server/middleware"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/analytics"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/apikeys"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/csvimport"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/folders"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/forms"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/identities"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/mailer"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/members"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/notifications"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/presence"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/pubsub"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/responses"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/review"
 	"github.com/TrySquadDF/formify/api-gql/internal/services/tokens"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/webhooks"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/workspaces"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/TrySquadDF/formify/api-gql/internal/services/users"
 	"github.com/TrySquadDF/formify/lib/config"
-	"github.com/TrySquadDF/formify/lib/google"
+	providers "github.com/TrySquadDF/formify/lib/oauth"
 
 	"github.com/TrySquadDF/formify/api-gql/internal/auth"
 	"github.com/TrySquadDF/formify/api-gql/internal/delivery/gql"
@@ -20,6 +36,8 @@ import (
 	"github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/resolvers"
 	oauth "github.com/TrySquadDF/formify/api-gql/internal/delivery/http/auth"
 	"github.com/TrySquadDF/formify/api-gql/internal/delivery/http/oauth2"
+	"github.com/TrySquadDF/formify/api-gql/internal/delivery/http/restapi"
+	"github.com/TrySquadDF/formify/api-gql/internal/delivery/http/submit"
 	"github.com/TrySquadDF/formify/api-gql/internal/server"
 
 	"github.com/redis/go-redis/extra/redisotel/v9"
@@ -107,13 +125,27 @@ func main() {
 		fx.Provide(
 			users.New,
 			tokens.New,
+			analytics.New,
+			responses.New,
+			forms.New,
+			review.New,
+			webhooks.New,
+			pubsub.New,
+			presence.New,
+			access.New,
+			members.New,
+			workspaces.New,
+			folders.New,
+			csvimport.New,
+			apikeys.New,
+			identities.New,
 		),
 		fx.Provide(
 			config.NewFx,
 			newGorm,
 			newRedis,
 			newPgxPool,
-			google.NewOAuth2Config,
+			providers.NewFx,
 			directives.New,
 			middleware.New,
 			auth.NewSessions,
@@ -124,6 +156,11 @@ func main() {
 			gql.New,
 			oauth2.New,
 			oauth.New,
+			submit.New,
+			restapi.New,
+			// Queue workers that nothing else depends on
+			mailer.New,
+			notifications.New,
 		),
 	)
 
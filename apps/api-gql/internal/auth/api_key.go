@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/TrySquadDF/formify/api-gql/internal/server/gincontext"
@@ -9,29 +10,107 @@ import (
 	model "github.com/TrySquadDF/formify/lib/gomodels"
 )
 
-func (s *Auth) GetAuthenticatedUserByApiKey(ctx context.Context) (*model.Users, error) {
-	var apiKey string
+// ApiKeyHeader carries the API key of an HTTP request; WebSocket clients send it as
+// "api-key" in the connection init payload instead.
+const ApiKeyHeader = "api-key"
+
+// apiKeyContextKey keeps the authenticated key in the gin context for the rest of the request
+const apiKeyContextKey = "auth.apiKey"
+
+var ErrNoApiKey = errors.New("api key is required")
+
+// ScopeError is returned when the API key of a request lacks the scope an operation needs.
+type ScopeError struct {
+	Scope model.ApiKeyScope
+}
+
+func (e *ScopeError) Error() string {
+	return fmt.Sprintf("api key lacks the %s scope", e.Scope)
+}
+
+// Extensions lets GraphQL clients tell a missing scope from other errors.
+func (e *ScopeError) Extensions() map[string]interface{} {
+	return map[string]interface{}{
+		"code":  "MISSING_SCOPE",
+		"scope": string(e.Scope),
+	}
+}
+
+// HasApiKey reports whether the request presents an API key, valid or not.
+func (s *Auth) HasApiKey(ctx context.Context) bool {
+	return s.requestApiKey(ctx) != ""
+}
 
-	wsApiKey, _ := s.getWsAuthenticatedApiKey(ctx)
-	if wsApiKey != "" {
-		apiKey = wsApiKey
-	} else {
-		ginCtx, err := gincontext.GetGinContext(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get gin context: %w", err)
-		}
+func (s *Auth) requestApiKey(ctx context.Context) string {
+	if key, _ := s.getWsAuthenticatedApiKey(ctx); key != "" {
+		return key
+	}
 
-		apiKey = ginCtx.GetHeader("api-key")
+	ginCtx, err := gincontext.GetGinContext(ctx)
+	if err != nil {
+		return ""
 	}
+	return ginCtx.GetHeader(ApiKeyHeader)
+}
 
-	if apiKey == "" {
-		return nil, fmt.Errorf("api key is required")
+// GetApiKey authenticates the API key of the request. Over HTTP the key is looked up
+// once per request; a WebSocket checks it for every operation it starts, so a revoked
+// key starts nothing new on an open connection. Subscriptions already running are not
+// stopped by the revocation.
+func (s *Auth) GetApiKey(ctx context.Context) (*model.ApiKey, error) {
+	if key, _ := s.getWsAuthenticatedApiKey(ctx); key != "" {
+		return s.apiKeys.Authenticate(ctx, key)
+	}
+
+	ginCtx, err := gincontext.GetGinContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gin context: %w", err)
+	}
+	if cached, ok := ginCtx.Get(apiKeyContextKey); ok {
+		return cached.(*model.ApiKey), nil
+	}
+
+	key := ginCtx.GetHeader(ApiKeyHeader)
+	if key == "" {
+		return nil, ErrNoApiKey
+	}
+
+	apiKey, err := s.apiKeys.Authenticate(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	ginCtx.Set(apiKeyContextKey, apiKey)
+	return apiKey, nil
+}
+
+func (s *Auth) GetAuthenticatedUserByApiKey(ctx context.Context) (*model.Users, error) {
+	apiKey, err := s.GetApiKey(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	user := model.Users{}
-	if err := s.gorm.Where(`"apiKey" = ?`, apiKey).First(&user).Error; err != nil {
+	if err := s.gorm.Where("id = ?", apiKey.UserID).First(&user).Error; err != nil {
 		return nil, fmt.Errorf("cannot get user from db: %w", err)
 	}
 
 	return &user, nil
 }
+
+// RequireScope fails with a *ScopeError when the request is authenticated with an API
+// key that lacks the scope. Requests without a key pass: a session may do anything its
+// user may, and anonymous access is up to the caller.
+func (s *Auth) RequireScope(ctx context.Context, scope model.ApiKeyScope) error {
+	if !s.HasApiKey(ctx) {
+		return nil
+	}
+
+	apiKey, err := s.GetApiKey(ctx)
+	if err != nil {
+		return err
+	}
+	if !apiKey.HasScope(scope) {
+		return &ScopeError{Scope: scope}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/server/gincontext"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/apikeys"
+	model "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+// requestContext is the context of an HTTP request with the API key header set, or none
+// for an empty key. An authenticated key is cached on the request the way GetApiKey does.
+func requestContext(key string, authenticated *model.ApiKey) context.Context {
+	ginCtx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ginCtx.Request = httptest.NewRequest("POST", "/query", nil)
+	if key != "" {
+		ginCtx.Request.Header.Set(ApiKeyHeader, key)
+	}
+	if authenticated != nil {
+		ginCtx.Set(apiKeyContextKey, authenticated)
+	}
+	return context.WithValue(context.Background(), gincontext.GinContextKey, ginCtx)
+}
+
+func TestRequireScope(t *testing.T) {
+	readOnly := &model.ApiKey{Scopes: pq.StringArray{string(model.ApiKeyScopeFormsRead)}}
+
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		scope model.ApiKeyScope
+		err   error
+	}{
+		{"session", requestContext("", nil), model.ApiKeyScopeResponsesWrite, nil},
+		{"granted", requestContext("fmf_key", readOnly), model.ApiKeyScopeFormsRead, nil},
+		{"missing", requestContext("fmf_key", readOnly), model.ApiKeyScopeResponsesRead, &ScopeError{}},
+		// A key that is presented is judged, even when it is no key at all
+		{"invalid header", requestContext("secret", nil), model.ApiKeyScopeFormsRead, apikeys.ErrInvalidKey},
+		{"invalid socket key", context.WithValue(context.Background(), WsApiKeyContextKey{}, "secret"), model.ApiKeyScopeFormsRead, apikeys.ErrInvalidKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Auth{apiKeys: &apikeys.Service{}}
+			err := s.RequireScope(tt.ctx, tt.scope)
+
+			var scopeErr *ScopeError
+			switch {
+			case tt.err == nil:
+				if err != nil {
+					t.Errorf("RequireScope() error = %v", err)
+				}
+			case errors.As(tt.err, &scopeErr):
+				if !errors.As(err, &scopeErr) || scopeErr.Scope != tt.scope {
+					t.Errorf("RequireScope() error = %v, want a ScopeError for %s", err, tt.scope)
+				}
+			case !errors.Is(err, tt.err):
+				t.Errorf("RequireScope() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
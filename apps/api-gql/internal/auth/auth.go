@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/TrySquadDF/formify/api-gql/internal/services/apikeys"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/identities"
 	"github.com/TrySquadDF/formify/api-gql/internal/services/users"
 	"github.com/TrySquadDF/formify/lib/config"
 	model "github.com/TrySquadDF/formify/lib/gomodels"
@@ -27,12 +29,16 @@ type Opts struct {
 	Gorm  *gorm.DB
 
 	UserService *users.Service
+	ApiKeys     *apikeys.Service
+	Identities  *identities.Service
 	Config config.Config
 }
 
 type Auth struct {
 
 	userService    *users.Service
+	apiKeys        *apikeys.Service
+	identities     *identities.Service
 	sessionManager *scs.SessionManager
 	gorm           *gorm.DB
 	config 		   config.Config
@@ -47,6 +53,8 @@ func NewSessions(opts Opts) *Auth {
 
 	return &Auth{
 		userService:    opts.UserService,
+		apiKeys:        opts.ApiKeys,
+		identities:     opts.Identities,
 		sessionManager: sessionManager,
 		gorm:           opts.Gorm,
 		config: 		opts.Config,
@@ -96,6 +104,13 @@ func (s *Auth) Put(ctx context.Context, key string, val interface{}) {
 	s.sessionManager.Commit(ctx)
 }
 
+// Pop returns the value of the key and removes it from the session, so that it is used once.
+func (s *Auth) Pop(ctx context.Context, key string) interface{} {
+	val := s.sessionManager.Pop(ctx, key)
+	s.sessionManager.Commit(ctx)
+	return val
+}
+
 func (s *Auth) AuthenticateWithEmailPassword(ctx context.Context, email, password string) (*model.Users, error) {
     user, err := s.userService.FindUserByEmail(ctx, email)
 	if err != nil {
@@ -21,9 +21,9 @@ func (s *Auth) GetUserIDFromContext(ctx context.Context) (string, error) {
 }
 
 func (s *Auth) GetAuthenticatedUser(ctx context.Context) (*model.Users, error) {
-	userByApyKey, err := s.GetAuthenticatedUserByApiKey(ctx)
-	if err == nil {
-		return userByApyKey, nil
+	// A request that presents a key is judged by the key alone
+	if s.HasApiKey(ctx) {
+		return s.GetAuthenticatedUserByApiKey(ctx)
 	}
 
 	user, ok := s.sessionManager.Get(ctx, "dbUser").(model.Users)
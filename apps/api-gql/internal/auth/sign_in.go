@@ -0,0 +1,23 @@
+package auth
+
+import (
+	"context"
+	"log"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/identities"
+	model "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// SignIn starts the session of the user. Signing in also confirms a login waiting in the
+// session to be linked to this user; a link meant for another account is dropped.
+func (s *Auth) SignIn(ctx context.Context, user model.Users) {
+	s.Put(ctx, "dbUser", user)
+
+	pending, ok := s.Pop(ctx, identities.PendingLinkSessionKey).(identities.PendingLink)
+	if !ok {
+		return
+	}
+	if _, err := s.identities.Confirm(ctx, user.ID, pending); err != nil {
+		log.Printf("Error linking %s login to user %s: %v", pending.Provider, user.ID, err)
+	}
+}
@@ -0,0 +1,117 @@
+// Package dbtest lets services be tested without Postgres. Open returns a database in
+// gorm's dry run mode: statements are built for the Postgres dialect and recorded, but
+// never sent, so a test checks the SQL a service would run. Queries find nothing.
+// Serve runs statements against canned replies, for services that act on what they read.
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// errNoDatabase is returned if a statement is ever sent; dry run mode never sends one
+var errNoDatabase = errors.New("dbtest: no database")
+
+// Recorder keeps the statements of a database opened with Open, with their arguments
+// written in.
+type Recorder struct {
+	mu         sync.Mutex
+	statements []string
+}
+
+// Statements returns the statements recorded so far, in order.
+func (r *Recorder) Statements() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.statements...)
+}
+
+// Reset forgets the recorded statements.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statements = nil
+}
+
+// Find returns the recorded statements that contain every one of the parts.
+func (r *Recorder) Find(parts ...string) []string {
+	var found []string
+	for _, statement := range r.Statements() {
+		matches := true
+		for _, part := range parts {
+			matches = matches && strings.Contains(statement, part)
+		}
+		if matches {
+			found = append(found, statement)
+		}
+	}
+	return found
+}
+
+// Open returns a dry run database and the recorder of its statements.
+func Open(t testing.TB) (*gorm.DB, *Recorder) {
+	t.Helper()
+
+	recorder := &Recorder{}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: pool{}}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               recorder,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, recorder
+}
+
+// The recorder is the logger of the database: gorm traces every statement it builds.
+
+func (r *Recorder) LogMode(logger.LogLevel) logger.Interface      { return r }
+func (r *Recorder) Info(context.Context, string, ...interface{})  {}
+func (r *Recorder) Warn(context.Context, string, ...interface{})  {}
+func (r *Recorder) Error(context.Context, string, ...interface{}) {}
+
+func (r *Recorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	statement, _ := fc()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statements = append(r.statements, statement)
+}
+
+// pool stands in for the connection pool so transactions can begin and commit.
+type pool struct{}
+
+func (pool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errNoDatabase
+}
+
+func (pool) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, errNoDatabase
+}
+
+func (pool) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, errNoDatabase
+}
+
+func (pool) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
+	return nil
+}
+
+func (pool) BeginTx(context.Context, *sql.TxOptions) (gorm.ConnPool, error) {
+	return &tx{}, nil
+}
+
+// tx is a pointer, since gorm checks the committer for nil
+type tx struct{ pool }
+
+func (*tx) Commit() error   { return nil }
+func (*tx) Rollback() error { return nil }
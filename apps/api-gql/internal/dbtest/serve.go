@@ -0,0 +1,113 @@
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Reply is the canned answer to the statements that contain Match. Statements are
+// matched as they are sent, with $1-style placeholders rather than their arguments.
+// A reply with Err fails the statement.
+type Reply struct {
+	Match    string
+	Columns  []string
+	Rows     [][]driver.Value
+	Affected int64
+	Err      error
+}
+
+// Serve returns a database that answers statements with the first matching reply,
+// and the recorder of its statements. Unlike with Open, statements run: a query that
+// matches no reply finds nothing, so First fails with gorm.ErrRecordNotFound, and
+// other statements affect no rows.
+func Serve(t testing.TB, replies ...Reply) (*gorm.DB, *Recorder) {
+	t.Helper()
+
+	conn := sql.OpenDB(&connector{replies: replies})
+	t.Cleanup(func() { conn.Close() })
+
+	recorder := &Recorder{}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               recorder,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, recorder
+}
+
+// connector hands out connections that answer with the replies.
+type connector struct {
+	replies []Reply
+}
+
+func (c *connector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{replies: c.replies}, nil
+}
+
+func (c *connector) Driver() driver.Driver { return stubDriver{} }
+
+type stubDriver struct{}
+
+func (stubDriver) Open(string) (driver.Conn, error) { return nil, errNoDatabase }
+
+type conn struct {
+	replies []Reply
+}
+
+func (c *conn) reply(query string) Reply {
+	for _, r := range c.replies {
+		if strings.Contains(query, r.Match) {
+			return r
+		}
+	}
+	return Reply{}
+}
+
+func (c *conn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	r := c.reply(query)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return driver.RowsAffected(r.Affected), nil
+}
+
+func (c *conn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	r := c.reply(query)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return &rows{columns: r.Columns, values: r.Rows}, nil
+}
+
+// CheckNamedValue takes every argument as it is: nothing is sent anywhere.
+func (c *conn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (c *conn) Prepare(string) (driver.Stmt, error) { return nil, errNoDatabase }
+func (c *conn) Close() error                        { return nil }
+func (c *conn) Begin() (driver.Tx, error)           { return &tx{}, nil }
+
+type rows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *rows) Columns() []string { return r.columns }
+func (r *rows) Close() error      { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
@@ -3,24 +3,36 @@ package directives
 import (
 	"context"
 	"fmt"
+	"strings"
+
+	gqlmodel "github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph/model"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
 
 	"github.com/99designs/gqlgen/graphql"
 )
 
+// IsAuthenticated lets through sessions and API keys holding the scope of the field.
+// Fields without a scope cannot be used with an API key at all.
 func (c *Directives) IsAuthenticated(
 	ctx context.Context,
 	obj interface{},
 	next graphql.Resolver,
+	scope *gqlmodel.APIKeyScope,
 ) (interface{}, error) {
-	_, apiKeyErr := c.sessions.GetAuthenticatedUserByApiKey(ctx)
-	_, sessionErr := c.sessions.GetAuthenticatedUser(ctx)
-
-	if apiKeyErr != nil && sessionErr != nil {
+	if _, err := c.sessions.GetAuthenticatedUser(ctx); err != nil {
 		return nil, fmt.Errorf("not authenticated")
 	}
 
+	if c.sessions.HasApiKey(ctx) {
+		if scope == nil {
+			return nil, fmt.Errorf("this operation is not available with an api key")
+		}
+		// FORMS_READ in the schema is forms:read on keys
+		required := gomodel.ApiKeyScope(strings.Replace(strings.ToLower(string(*scope)), "_", ":", 1))
+		if err := c.sessions.RequireScope(ctx, required); err != nil {
+			return nil, err
+		}
+	}
+
 	return next(ctx)
 }
-
-
-
@@ -1,15 +1,24 @@
 package gql
 
 import (
+	"context"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/TrySquadDF/formify/api-gql/internal/auth"
 	"github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/directives"
 	"github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph"
 	"github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/resolvers"
 	"github.com/TrySquadDF/formify/api-gql/internal/server"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/vektah/gqlparser/v2/ast"
 	"go.uber.org/fx"
 )
 
@@ -35,11 +44,29 @@ func New(opts Opts) *handler.Server {
 	srv.AddTransport(transport.GET{})
 	srv.AddTransport(transport.POST{})
 	srv.AddTransport(transport.MultipartForm{})
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+		InitFunc:              auth.WsGqlInitFunc,
+		Upgrader: websocket.Upgrader{
+			// The session cookie goes along with the upgrade, so only trusted origins may open a socket
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				return origin == "" || slices.Contains(server.AllowedOrigins, origin)
+			},
+		},
+	})
 
 	playgroundHandler := playground.Handler("GraphQL playground", "/query")
 
 	
 	srv.Use(extension.Introspection{})
+	// A subscription lives on, so only queries and mutations cache the stars of the user
+	srv.AroundOperations(func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		if op := graphql.GetOperationContext(ctx).Operation; op != nil && op.Operation != ast.Subscription {
+			ctx = resolvers.WithStars(ctx)
+		}
+		return next(ctx)
+	})
 
 	opts.Server.Any("/", func(context *gin.Context) {
 		playgroundHandler.ServeHTTP(context.Writer, context.Request)
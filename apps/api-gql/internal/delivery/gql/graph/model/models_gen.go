@@ -28,47 +28,272 @@ type AnswerInput struct {
 	OptionIds   []string `json:"optionIds,omitempty"`
 }
 
+type APIKey struct {
+	ID         string        `json:"id"`
+	Name       string        `json:"name"`
+	Prefix     string        `json:"prefix"`
+	Scopes     []APIKeyScope `json:"scopes"`
+	ExpiresAt  *string       `json:"expiresAt,omitempty"`
+	LastUsedAt *string       `json:"lastUsedAt,omitempty"`
+	CreatedAt  string        `json:"createdAt"`
+	Key        *string       `json:"key,omitempty"`
+}
+
+type APIKeyInput struct {
+	Name      string        `json:"name"`
+	Scopes    []APIKeyScope `json:"scopes"`
+	ExpiresAt *string       `json:"expiresAt,omitempty"`
+}
+
+type CrossTab struct {
+	FormID           string              `json:"formId"`
+	RowQuestion      *Question           `json:"rowQuestion"`
+	ColumnQuestion   *Question           `json:"columnQuestion"`
+	Rows             []*CrossTabCategory `json:"rows"`
+	Columns          []*CrossTabCategory `json:"columns"`
+	Cells            []*CrossTabCell     `json:"cells"`
+	Total            int32               `json:"total"`
+	ChiSquare        *float64            `json:"chiSquare,omitempty"`
+	DegreesOfFreedom int32               `json:"degreesOfFreedom"`
+	PValue           *float64            `json:"pValue,omitempty"`
+}
+
+type CrossTabCategory struct {
+	Key     string  `json:"key"`
+	Label   string  `json:"label"`
+	Total   int32   `json:"total"`
+	Percent float64 `json:"percent"`
+}
+
+type CrossTabCell struct {
+	RowKey        string  `json:"rowKey"`
+	ColumnKey     string  `json:"columnKey"`
+	Count         int32   `json:"count"`
+	Expected      float64 `json:"expected"`
+	RowPercent    float64 `json:"rowPercent"`
+	ColumnPercent float64 `json:"columnPercent"`
+	TotalPercent  float64 `json:"totalPercent"`
+}
+
+type CrossTabFilterInput struct {
+	QuestionID string   `json:"questionId"`
+	OptionIds  []string `json:"optionIds,omitempty"`
+	BoolValue  *bool    `json:"boolValue,omitempty"`
+	NumberMin  *float64 `json:"numberMin,omitempty"`
+	NumberMax  *float64 `json:"numberMax,omitempty"`
+	TextValue  *string  `json:"textValue,omitempty"`
+}
+
+type DraftAnswer struct {
+	QuestionID  string   `json:"questionId"`
+	TextValue   *string  `json:"textValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	NumberValue *float64 `json:"numberValue,omitempty"`
+	DateValue   *string  `json:"dateValue,omitempty"`
+	OptionIds   []string `json:"optionIds,omitempty"`
+}
+
+type ExternalFormImport struct {
+	Form      *Form                 `json:"form"`
+	Notes     []*ImportNote         `json:"notes"`
+	Responses *ResponseImportReport `json:"responses,omitempty"`
+}
+
+type Folder struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	ParentID    *string `json:"parentId,omitempty"`
+	WorkspaceID *string `json:"workspaceId,omitempty"`
+	CreatedAt   string  `json:"createdAt"`
+}
+
 type Form struct {
-	ID          string      `json:"id"`
-	OwnerID     string      `json:"ownerId"`
-	Title       string      `json:"title"`
-	Description string      `json:"description"`
-	Access      FormAccess  `json:"access"`
-	CreatedAt   string      `json:"createdAt"`
-	UpdatedAt   string      `json:"updatedAt"`
-	Questions   []*Question `json:"questions,omitempty"`
+	ID                    string                `json:"id"`
+	OwnerID               string                `json:"ownerId"`
+	WorkspaceID           *string               `json:"workspaceId,omitempty"`
+	FolderID              *string               `json:"folderId,omitempty"`
+	Tags                  []string              `json:"tags"`
+	IsTemplate            bool                  `json:"isTemplate"`
+	ExternalKey           *string               `json:"externalKey,omitempty"`
+	Title                 string                `json:"title"`
+	Description           string                `json:"description"`
+	Access                FormAccess            `json:"access"`
+	CreatedAt             string                `json:"createdAt"`
+	UpdatedAt             string                `json:"updatedAt"`
+	DeletedAt             *string               `json:"deletedAt,omitempty"`
+	AllowResponseEditing  bool                  `json:"allowResponseEditing"`
+	ResponseEditDeadline  *string               `json:"responseEditDeadline,omitempty"`
+	ClosedAt              *string               `json:"closedAt,omitempty"`
+	ResponseNotifications ResponseNotifications `json:"responseNotifications"`
+	Version               int32                 `json:"version"`
+	Questions             []*Question           `json:"questions,omitempty"`
+	Starred               bool                  `json:"starred"`
+	MyRole                *FormRole             `json:"myRole,omitempty"`
+	ResponseStatuses      []string              `json:"responseStatuses"`
+}
+
+type FormApply struct {
+	Form   *Form           `json:"form"`
+	Action FormApplyAction `json:"action"`
+}
+
+type FormChangeEvent struct {
+	FormID     string          `json:"formId"`
+	Kind       FormChangeKind  `json:"kind"`
+	Version    int32           `json:"version"`
+	ActorID    string          `json:"actorId"`
+	QuestionID *string         `json:"questionId,omitempty"`
+	OptionID   *string         `json:"optionId,omitempty"`
+	Form       *Form           `json:"form,omitempty"`
+	Question   *Question       `json:"question,omitempty"`
+	Option     *Option         `json:"option,omitempty"`
+	Presence   []*FormPresence `json:"presence,omitempty"`
+}
+
+type FormImport struct {
+	Form        *Form         `json:"form"`
+	QuestionIds []*ImportedID `json:"questionIds"`
+	OptionIds   []*ImportedID `json:"optionIds"`
 }
 
 type FormInput struct {
-	Title       string           `json:"title"`
-	Description *string          `json:"description,omitempty"`
-	Access      *FormAccess      `json:"access,omitempty"`
-	Questions   []*QuestionInput `json:"questions,omitempty"`
+	WorkspaceID           *string                `json:"workspaceId,omitempty"`
+	FolderID              *string                `json:"folderId,omitempty"`
+	Tags                  []string               `json:"tags,omitempty"`
+	Title                 string                 `json:"title"`
+	Description           *string                `json:"description,omitempty"`
+	Access                *FormAccess            `json:"access,omitempty"`
+	Questions             []*QuestionInput       `json:"questions,omitempty"`
+	AllowResponseEditing  *bool                  `json:"allowResponseEditing,omitempty"`
+	ResponseEditDeadline  *string                `json:"responseEditDeadline,omitempty"`
+	ResponseNotifications *ResponseNotifications `json:"responseNotifications,omitempty"`
+}
+
+type FormInvitation struct {
+	ID        string               `json:"id"`
+	FormID    string               `json:"formId"`
+	FormTitle *string              `json:"formTitle,omitempty"`
+	Email     string               `json:"email"`
+	Role      FormRole             `json:"role"`
+	Status    FormInvitationStatus `json:"status"`
+	InvitedBy *User                `json:"invitedBy,omitempty"`
+	CreatedAt string               `json:"createdAt"`
+	ExpiresAt string               `json:"expiresAt"`
+}
+
+type FormMember struct {
+	User      *User    `json:"user"`
+	Role      FormRole `json:"role"`
+	CreatedAt string   `json:"createdAt"`
+}
+
+type FormPresence struct {
+	UserID      string  `json:"userId"`
+	DisplayName string  `json:"displayName"`
+	Picture     string  `json:"picture"`
+	Editing     *string `json:"editing,omitempty"`
+	LastSeen    string  `json:"lastSeen"`
 }
 
 type FormResponse struct {
-	ID        string    `json:"id"`
-	FormID    string    `json:"formId"`
-	Form      *Form     `json:"form,omitempty"`
-	CreatedAt string    `json:"createdAt"`
-	Answers   []*Answer `json:"answers"`
+	ID         string              `json:"id"`
+	FormID     string              `json:"formId"`
+	Form       *Form               `json:"form,omitempty"`
+	CreatedAt  string              `json:"createdAt"`
+	UpdatedAt  *string             `json:"updatedAt,omitempty"`
+	DeletedAt  *string             `json:"deletedAt,omitempty"`
+	Answers    []*Answer           `json:"answers"`
+	EditToken  *string             `json:"editToken,omitempty"`
+	Status     string              `json:"status"`
+	AssigneeID *string             `json:"assigneeId,omitempty"`
+	Tags       []string            `json:"tags"`
+	Notes      []*ResponseNote     `json:"notes"`
+	Activity   []*ResponseActivity `json:"activity"`
+}
+
+type FormResponseBatchResult struct {
+	Index          int32         `json:"index"`
+	IdempotencyKey *string       `json:"idempotencyKey,omitempty"`
+	Response       *FormResponse `json:"response,omitempty"`
+	Duplicate      bool          `json:"duplicate"`
+	Error          *string       `json:"error,omitempty"`
+}
+
+type FormResponseFilter struct {
+	Statuses   []string `json:"statuses,omitempty"`
+	AssigneeID *string  `json:"assigneeId,omitempty"`
+	Unassigned *bool    `json:"unassigned,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
 }
 
 type FormResponseInput struct {
-	FormID  string         `json:"formId"`
+	FormID         string         `json:"formId"`
+	Answers        []*AnswerInput `json:"answers"`
+	DraftToken     *string        `json:"draftToken,omitempty"`
+	IdempotencyKey *string        `json:"idempotencyKey,omitempty"`
+	SubmittedAt    *string        `json:"submittedAt,omitempty"`
+}
+
+type FormResponseRevision struct {
+	ID         string    `json:"id"`
+	ResponseID string    `json:"responseId"`
+	Revision   int32     `json:"revision"`
+	CreatedAt  string    `json:"createdAt"`
+	Answers    []*Answer `json:"answers"`
+}
+
+type FormResponseUpdateInput struct {
 	Answers []*AnswerInput `json:"answers"`
 }
 
+type FormTemplate struct {
+	ID            string `json:"id"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	BuiltIn       bool   `json:"builtIn"`
+	QuestionCount int32  `json:"questionCount"`
+}
+
 type FormUpdateInput struct {
-	Title       *string          `json:"title,omitempty"`
-	Description *string          `json:"description,omitempty"`
-	Access      *FormAccess      `json:"access,omitempty"`
-	Questions   []*QuestionInput `json:"questions,omitempty"`
+	Title                 *string                `json:"title,omitempty"`
+	Description           *string                `json:"description,omitempty"`
+	Access                *FormAccess            `json:"access,omitempty"`
+	Questions             []*QuestionInput       `json:"questions,omitempty"`
+	ReviewStatuses        []string               `json:"reviewStatuses,omitempty"`
+	AllowResponseEditing  *bool                  `json:"allowResponseEditing,omitempty"`
+	ResponseEditDeadline  *string                `json:"responseEditDeadline,omitempty"`
+	ResponseNotifications *ResponseNotifications `json:"responseNotifications,omitempty"`
+	Version               int32                  `json:"version"`
+}
+
+type Identity struct {
+	ID        string `json:"id"`
+	Provider  string `json:"provider"`
+	Email     string `json:"email"`
+	CreatedAt string `json:"createdAt"`
+}
+
+type ImportNote struct {
+	Title   string `json:"title"`
+	Type    string `json:"type"`
+	Skipped bool   `json:"skipped"`
+	Message string `json:"message"`
+}
+
+type ImportedID struct {
+	SourceID string `json:"sourceId"`
+	ID       string `json:"id"`
 }
 
 type Mutation struct {
 }
 
+type NumberBucketsInput struct {
+	Min   *float64 `json:"min,omitempty"`
+	Max   *float64 `json:"max,omitempty"`
+	Count int32    `json:"count"`
+}
+
 type Option struct {
 	ID         string `json:"id"`
 	QuestionID string `json:"questionId"`
@@ -82,8 +307,9 @@ type OptionInput struct {
 }
 
 type OptionUpdateInput struct {
-	Text  *string `json:"text,omitempty"`
-	Order *int32  `json:"order,omitempty"`
+	Text    *string `json:"text,omitempty"`
+	Order   *int32  `json:"order,omitempty"`
+	Version int32   `json:"version"`
 }
 
 type Ping struct {
@@ -95,39 +321,233 @@ type Query struct {
 }
 
 type Question struct {
-	ID       string       `json:"id"`
-	FormID   string       `json:"formId"`
-	Text     string       `json:"text"`
-	Type     QuestionType `json:"type"`
-	Required bool         `json:"required"`
-	Order    int32        `json:"order"`
-	Options  []*Option    `json:"options,omitempty"`
+	ID              string       `json:"id"`
+	FormID          string       `json:"formId"`
+	Key             *string      `json:"key,omitempty"`
+	Text            string       `json:"text"`
+	Type            QuestionType `json:"type"`
+	Required        bool         `json:"required"`
+	Order           int32        `json:"order"`
+	RespondentEmail bool         `json:"respondentEmail"`
+	Options         []*Option    `json:"options,omitempty"`
 }
 
 type QuestionInput struct {
-	Text     string         `json:"text"`
-	Type     QuestionType   `json:"type"`
-	Required bool           `json:"required"`
-	Order    int32          `json:"order"`
-	Options  []*OptionInput `json:"options,omitempty"`
+	Key             *string        `json:"key,omitempty"`
+	Text            string         `json:"text"`
+	Type            QuestionType   `json:"type"`
+	Required        bool           `json:"required"`
+	Order           int32          `json:"order"`
+	RespondentEmail *bool          `json:"respondentEmail,omitempty"`
+	Options         []*OptionInput `json:"options,omitempty"`
 }
 
 type QuestionUpdateInput struct {
+	Key      *string        `json:"key,omitempty"`
 	Text     *string        `json:"text,omitempty"`
 	Type     *QuestionType  `json:"type,omitempty"`
 	Required *bool          `json:"required,omitempty"`
 	Order    *int32         `json:"order,omitempty"`
 	Options  []*OptionInput `json:"options,omitempty"`
+	Version  int32          `json:"version"`
+}
+
+type ResponseActivity struct {
+	ID         string                 `json:"id"`
+	ResponseID string                 `json:"responseId"`
+	ActorID    string                 `json:"actorId"`
+	ActorName  string                 `json:"actorName"`
+	Action     ResponseActivityAction `json:"action"`
+	OldValue   *string                `json:"oldValue,omitempty"`
+	NewValue   *string                `json:"newValue,omitempty"`
+	CreatedAt  string                 `json:"createdAt"`
+}
+
+type ResponseColumnInput struct {
+	Column     string `json:"column"`
+	QuestionID string `json:"questionId"`
+}
+
+type ResponseDraft struct {
+	FormID    string         `json:"formId"`
+	Token     string         `json:"token"`
+	Answers   []*DraftAnswer `json:"answers"`
+	UpdatedAt string         `json:"updatedAt"`
+	ExpiresAt string         `json:"expiresAt"`
+}
+
+type ResponseImportError struct {
+	Row     int32   `json:"row"`
+	Column  *string `json:"column,omitempty"`
+	Message string  `json:"message"`
+}
+
+type ResponseImportReport struct {
+	Imported         int32                  `json:"imported"`
+	Errors           []*ResponseImportError `json:"errors"`
+	UnmatchedColumns []string               `json:"unmatchedColumns"`
+}
+
+type ResponseNote struct {
+	ID         string  `json:"id"`
+	ResponseID string  `json:"responseId"`
+	ParentID   *string `json:"parentId,omitempty"`
+	AuthorID   string  `json:"authorId"`
+	AuthorName string  `json:"authorName"`
+	Body       string  `json:"body"`
+	CreatedAt  string  `json:"createdAt"`
+}
+
+type Subscription struct {
 }
 
 type User struct {
-	ID          string  `json:"id"`
-	Email       string  `json:"email"`
-	DisplayName string  `json:"displayName"`
-	Picture     string  `json:"picture"`
-	GoogleID    string  `json:"googleId"`
-	IsBanned    bool    `json:"isBanned"`
-	Forms       []*Form `json:"forms"`
+	ID               string       `json:"id"`
+	Email            string       `json:"email"`
+	DisplayName      string       `json:"displayName"`
+	Picture          string       `json:"picture"`
+	GoogleID         string       `json:"googleId"`
+	IsBanned         bool         `json:"isBanned"`
+	Forms            []*Form      `json:"forms"`
+	Workspaces       []*Workspace `json:"workspaces"`
+	CurrentWorkspace *Workspace   `json:"currentWorkspace,omitempty"`
+}
+
+type Webhook struct {
+	ID        string         `json:"id"`
+	FormID    string         `json:"formId"`
+	URL       string         `json:"url"`
+	Events    []WebhookEvent `json:"events"`
+	Enabled   bool           `json:"enabled"`
+	CreatedAt string         `json:"createdAt"`
+	Secret    *string        `json:"secret,omitempty"`
+}
+
+type WebhookDelivery struct {
+	ID             string                `json:"id"`
+	WebhookID      string                `json:"webhookId"`
+	Event          WebhookEvent          `json:"event"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int32                 `json:"attempts"`
+	RequestBody    string                `json:"requestBody"`
+	ResponseStatus *int32                `json:"responseStatus,omitempty"`
+	ResponseBody   *string               `json:"responseBody,omitempty"`
+	Error          *string               `json:"error,omitempty"`
+	CreatedAt      string                `json:"createdAt"`
+	NextAttemptAt  string                `json:"nextAttemptAt"`
+	DeliveredAt    *string               `json:"deliveredAt,omitempty"`
+}
+
+type WebhookInput struct {
+	URL    string         `json:"url"`
+	Events []WebhookEvent `json:"events"`
+	Secret *string        `json:"secret,omitempty"`
+}
+
+type WebhookUpdateInput struct {
+	URL     *string        `json:"url,omitempty"`
+	Events  []WebhookEvent `json:"events,omitempty"`
+	Enabled *bool          `json:"enabled,omitempty"`
+}
+
+type Workspace struct {
+	ID        string             `json:"id"`
+	Name      string             `json:"name"`
+	CreatedAt string             `json:"createdAt"`
+	MyRole    *WorkspaceRole     `json:"myRole,omitempty"`
+	Members   []*WorkspaceMember `json:"members"`
+}
+
+type WorkspaceMember struct {
+	User      *User         `json:"user"`
+	Role      WorkspaceRole `json:"role"`
+	CreatedAt string        `json:"createdAt"`
+}
+
+type APIKeyScope string
+
+const (
+	APIKeyScopeFormsRead      APIKeyScope = "FORMS_READ"
+	APIKeyScopeFormsWrite     APIKeyScope = "FORMS_WRITE"
+	APIKeyScopeResponsesRead  APIKeyScope = "RESPONSES_READ"
+	APIKeyScopeResponsesWrite APIKeyScope = "RESPONSES_WRITE"
+)
+
+var AllAPIKeyScope = []APIKeyScope{
+	APIKeyScopeFormsRead,
+	APIKeyScopeFormsWrite,
+	APIKeyScopeResponsesRead,
+	APIKeyScopeResponsesWrite,
+}
+
+func (e APIKeyScope) IsValid() bool {
+	switch e {
+	case APIKeyScopeFormsRead, APIKeyScopeFormsWrite, APIKeyScopeResponsesRead, APIKeyScopeResponsesWrite:
+		return true
+	}
+	return false
+}
+
+func (e APIKeyScope) String() string {
+	return string(e)
+}
+
+func (e *APIKeyScope) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = APIKeyScope(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ApiKeyScope", str)
+	}
+	return nil
+}
+
+func (e APIKeyScope) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ExternalFormSource string
+
+const (
+	ExternalFormSourceGoogleForms ExternalFormSource = "GOOGLE_FORMS"
+	ExternalFormSourceTypeform    ExternalFormSource = "TYPEFORM"
+)
+
+var AllExternalFormSource = []ExternalFormSource{
+	ExternalFormSourceGoogleForms,
+	ExternalFormSourceTypeform,
+}
+
+func (e ExternalFormSource) IsValid() bool {
+	switch e {
+	case ExternalFormSourceGoogleForms, ExternalFormSourceTypeform:
+		return true
+	}
+	return false
+}
+
+func (e ExternalFormSource) String() string {
+	return string(e)
+}
+
+func (e *ExternalFormSource) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ExternalFormSource(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ExternalFormSource", str)
+	}
+	return nil
+}
+
+func (e ExternalFormSource) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
 type FormAccess string
@@ -173,6 +593,278 @@ func (e FormAccess) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type FormApplyAction string
+
+const (
+	FormApplyActionCreated   FormApplyAction = "CREATED"
+	FormApplyActionUpdated   FormApplyAction = "UPDATED"
+	FormApplyActionUnchanged FormApplyAction = "UNCHANGED"
+)
+
+var AllFormApplyAction = []FormApplyAction{
+	FormApplyActionCreated,
+	FormApplyActionUpdated,
+	FormApplyActionUnchanged,
+}
+
+func (e FormApplyAction) IsValid() bool {
+	switch e {
+	case FormApplyActionCreated, FormApplyActionUpdated, FormApplyActionUnchanged:
+		return true
+	}
+	return false
+}
+
+func (e FormApplyAction) String() string {
+	return string(e)
+}
+
+func (e *FormApplyAction) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FormApplyAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FormApplyAction", str)
+	}
+	return nil
+}
+
+func (e FormApplyAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type FormChangeKind string
+
+const (
+	FormChangeKindFormUpdated       FormChangeKind = "FORM_UPDATED"
+	FormChangeKindQuestionsReplaced FormChangeKind = "QUESTIONS_REPLACED"
+	FormChangeKindQuestionUpdated   FormChangeKind = "QUESTION_UPDATED"
+	FormChangeKindQuestionDeleted   FormChangeKind = "QUESTION_DELETED"
+	FormChangeKindOptionUpdated     FormChangeKind = "OPTION_UPDATED"
+	FormChangeKindOptionDeleted     FormChangeKind = "OPTION_DELETED"
+	FormChangeKindFormClosed        FormChangeKind = "FORM_CLOSED"
+	FormChangeKindFormReopened      FormChangeKind = "FORM_REOPENED"
+	FormChangeKindPresenceChanged   FormChangeKind = "PRESENCE_CHANGED"
+)
+
+var AllFormChangeKind = []FormChangeKind{
+	FormChangeKindFormUpdated,
+	FormChangeKindQuestionsReplaced,
+	FormChangeKindQuestionUpdated,
+	FormChangeKindQuestionDeleted,
+	FormChangeKindOptionUpdated,
+	FormChangeKindOptionDeleted,
+	FormChangeKindFormClosed,
+	FormChangeKindFormReopened,
+	FormChangeKindPresenceChanged,
+}
+
+func (e FormChangeKind) IsValid() bool {
+	switch e {
+	case FormChangeKindFormUpdated, FormChangeKindQuestionsReplaced, FormChangeKindQuestionUpdated, FormChangeKindQuestionDeleted, FormChangeKindOptionUpdated, FormChangeKindOptionDeleted, FormChangeKindFormClosed, FormChangeKindFormReopened, FormChangeKindPresenceChanged:
+		return true
+	}
+	return false
+}
+
+func (e FormChangeKind) String() string {
+	return string(e)
+}
+
+func (e *FormChangeKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FormChangeKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FormChangeKind", str)
+	}
+	return nil
+}
+
+func (e FormChangeKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type FormDocumentFormat string
+
+const (
+	FormDocumentFormatJSON FormDocumentFormat = "JSON"
+	FormDocumentFormatYaml FormDocumentFormat = "YAML"
+)
+
+var AllFormDocumentFormat = []FormDocumentFormat{
+	FormDocumentFormatJSON,
+	FormDocumentFormatYaml,
+}
+
+func (e FormDocumentFormat) IsValid() bool {
+	switch e {
+	case FormDocumentFormatJSON, FormDocumentFormatYaml:
+		return true
+	}
+	return false
+}
+
+func (e FormDocumentFormat) String() string {
+	return string(e)
+}
+
+func (e *FormDocumentFormat) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FormDocumentFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FormDocumentFormat", str)
+	}
+	return nil
+}
+
+func (e FormDocumentFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type FormInvitationStatus string
+
+const (
+	FormInvitationStatusPending  FormInvitationStatus = "PENDING"
+	FormInvitationStatusAccepted FormInvitationStatus = "ACCEPTED"
+	FormInvitationStatusDeclined FormInvitationStatus = "DECLINED"
+	FormInvitationStatusRevoked  FormInvitationStatus = "REVOKED"
+)
+
+var AllFormInvitationStatus = []FormInvitationStatus{
+	FormInvitationStatusPending,
+	FormInvitationStatusAccepted,
+	FormInvitationStatusDeclined,
+	FormInvitationStatusRevoked,
+}
+
+func (e FormInvitationStatus) IsValid() bool {
+	switch e {
+	case FormInvitationStatusPending, FormInvitationStatusAccepted, FormInvitationStatusDeclined, FormInvitationStatusRevoked:
+		return true
+	}
+	return false
+}
+
+func (e FormInvitationStatus) String() string {
+	return string(e)
+}
+
+func (e *FormInvitationStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FormInvitationStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FormInvitationStatus", str)
+	}
+	return nil
+}
+
+func (e FormInvitationStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type FormRole string
+
+const (
+	FormRoleOwner   FormRole = "OWNER"
+	FormRoleEditor  FormRole = "EDITOR"
+	FormRoleAnalyst FormRole = "ANALYST"
+	FormRoleViewer  FormRole = "VIEWER"
+)
+
+var AllFormRole = []FormRole{
+	FormRoleOwner,
+	FormRoleEditor,
+	FormRoleAnalyst,
+	FormRoleViewer,
+}
+
+func (e FormRole) IsValid() bool {
+	switch e {
+	case FormRoleOwner, FormRoleEditor, FormRoleAnalyst, FormRoleViewer:
+		return true
+	}
+	return false
+}
+
+func (e FormRole) String() string {
+	return string(e)
+}
+
+func (e *FormRole) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FormRole(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FormRole", str)
+	}
+	return nil
+}
+
+func (e FormRole) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type FormSort string
+
+const (
+	FormSortUpdatedAt    FormSort = "UPDATED_AT"
+	FormSortLastResponse FormSort = "LAST_RESPONSE"
+	FormSortTitle        FormSort = "TITLE"
+)
+
+var AllFormSort = []FormSort{
+	FormSortUpdatedAt,
+	FormSortLastResponse,
+	FormSortTitle,
+}
+
+func (e FormSort) IsValid() bool {
+	switch e {
+	case FormSortUpdatedAt, FormSortLastResponse, FormSortTitle:
+		return true
+	}
+	return false
+}
+
+func (e FormSort) String() string {
+	return string(e)
+}
+
+func (e *FormSort) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FormSort(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FormSort", str)
+	}
+	return nil
+}
+
+func (e FormSort) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type QuestionType string
 
 const (
@@ -227,3 +919,222 @@ func (e *QuestionType) UnmarshalGQL(v any) error {
 func (e QuestionType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type ResponseActivityAction string
+
+const (
+	ResponseActivityActionStatusChanged   ResponseActivityAction = "STATUS_CHANGED"
+	ResponseActivityActionAssigneeChanged ResponseActivityAction = "ASSIGNEE_CHANGED"
+	ResponseActivityActionTagsChanged     ResponseActivityAction = "TAGS_CHANGED"
+	ResponseActivityActionNoteAdded       ResponseActivityAction = "NOTE_ADDED"
+	ResponseActivityActionNoteDeleted     ResponseActivityAction = "NOTE_DELETED"
+)
+
+var AllResponseActivityAction = []ResponseActivityAction{
+	ResponseActivityActionStatusChanged,
+	ResponseActivityActionAssigneeChanged,
+	ResponseActivityActionTagsChanged,
+	ResponseActivityActionNoteAdded,
+	ResponseActivityActionNoteDeleted,
+}
+
+func (e ResponseActivityAction) IsValid() bool {
+	switch e {
+	case ResponseActivityActionStatusChanged, ResponseActivityActionAssigneeChanged, ResponseActivityActionTagsChanged, ResponseActivityActionNoteAdded, ResponseActivityActionNoteDeleted:
+		return true
+	}
+	return false
+}
+
+func (e ResponseActivityAction) String() string {
+	return string(e)
+}
+
+func (e *ResponseActivityAction) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ResponseActivityAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ResponseActivityAction", str)
+	}
+	return nil
+}
+
+func (e ResponseActivityAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ResponseNotifications string
+
+const (
+	ResponseNotificationsNone   ResponseNotifications = "NONE"
+	ResponseNotificationsEach   ResponseNotifications = "EACH"
+	ResponseNotificationsHourly ResponseNotifications = "HOURLY"
+	ResponseNotificationsDaily  ResponseNotifications = "DAILY"
+)
+
+var AllResponseNotifications = []ResponseNotifications{
+	ResponseNotificationsNone,
+	ResponseNotificationsEach,
+	ResponseNotificationsHourly,
+	ResponseNotificationsDaily,
+}
+
+func (e ResponseNotifications) IsValid() bool {
+	switch e {
+	case ResponseNotificationsNone, ResponseNotificationsEach, ResponseNotificationsHourly, ResponseNotificationsDaily:
+		return true
+	}
+	return false
+}
+
+func (e ResponseNotifications) String() string {
+	return string(e)
+}
+
+func (e *ResponseNotifications) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ResponseNotifications(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ResponseNotifications", str)
+	}
+	return nil
+}
+
+func (e ResponseNotifications) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+var AllWebhookDeliveryStatus = []WebhookDeliveryStatus{
+	WebhookDeliveryStatusPending,
+	WebhookDeliveryStatusSucceeded,
+	WebhookDeliveryStatusFailed,
+}
+
+func (e WebhookDeliveryStatus) IsValid() bool {
+	switch e {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusSucceeded, WebhookDeliveryStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e WebhookDeliveryStatus) String() string {
+	return string(e)
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookDeliveryStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookDeliveryStatus", str)
+	}
+	return nil
+}
+
+func (e WebhookDeliveryStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type WebhookEvent string
+
+const (
+	WebhookEventResponseCreated WebhookEvent = "RESPONSE_CREATED"
+	WebhookEventResponseUpdated WebhookEvent = "RESPONSE_UPDATED"
+	WebhookEventFormClosed      WebhookEvent = "FORM_CLOSED"
+)
+
+var AllWebhookEvent = []WebhookEvent{
+	WebhookEventResponseCreated,
+	WebhookEventResponseUpdated,
+	WebhookEventFormClosed,
+}
+
+func (e WebhookEvent) IsValid() bool {
+	switch e {
+	case WebhookEventResponseCreated, WebhookEventResponseUpdated, WebhookEventFormClosed:
+		return true
+	}
+	return false
+}
+
+func (e WebhookEvent) String() string {
+	return string(e)
+}
+
+func (e *WebhookEvent) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookEvent(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookEvent", str)
+	}
+	return nil
+}
+
+func (e WebhookEvent) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type WorkspaceRole string
+
+const (
+	WorkspaceRoleAdmin  WorkspaceRole = "ADMIN"
+	WorkspaceRoleMember WorkspaceRole = "MEMBER"
+)
+
+var AllWorkspaceRole = []WorkspaceRole{
+	WorkspaceRoleAdmin,
+	WorkspaceRoleMember,
+}
+
+func (e WorkspaceRole) IsValid() bool {
+	switch e {
+	case WorkspaceRoleAdmin, WorkspaceRoleMember:
+		return true
+	}
+	return false
+}
+
+func (e WorkspaceRole) String() string {
+	return string(e)
+}
+
+func (e *WorkspaceRole) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WorkspaceRole(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WorkspaceRole", str)
+	}
+	return nil
+}
+
+func (e WorkspaceRole) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
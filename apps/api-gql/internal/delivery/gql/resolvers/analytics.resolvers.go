@@ -0,0 +1,100 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.70
+
+import (
+	"context"
+
+	gqlmodel "github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph/model"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/analytics"
+)
+
+// CrossTab is the resolver for the crossTab field.
+func (r *queryResolver) CrossTab(ctx context.Context, formID string, rowQuestionID string, columnQuestionID string, filters []*gqlmodel.CrossTabFilterInput, rowBuckets *gqlmodel.NumberBucketsInput, columnBuckets *gqlmodel.NumberBucketsInput) (*gqlmodel.CrossTab, error) {
+	if _, err := r.authorizedForm(ctx, formID, access.ViewResponses); err != nil {
+		return nil, err
+	}
+
+	params := analytics.CrossTabParams{
+		FormID:           formID,
+		RowQuestionID:    rowQuestionID,
+		ColumnQuestionID: columnQuestionID,
+		RowBuckets:       bucketsFromGraphQL(rowBuckets),
+		ColumnBuckets:    bucketsFromGraphQL(columnBuckets),
+		Filters:          make([]analytics.Filter, 0, len(filters)),
+	}
+
+	for _, f := range filters {
+		params.Filters = append(params.Filters, analytics.Filter{
+			QuestionID: f.QuestionID,
+			OptionIDs:  f.OptionIds,
+			BoolValue:  f.BoolValue,
+			NumberMin:  f.NumberMin,
+			NumberMax:  f.NumberMax,
+			TextValue:  f.TextValue,
+		})
+	}
+
+	table, err := r.deps.Analytics.CrossTab(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return crossTabToGraphQL(table), nil
+}
+
+func bucketsFromGraphQL(b *gqlmodel.NumberBucketsInput) *analytics.Buckets {
+	if b == nil {
+		return nil
+	}
+	return &analytics.Buckets{
+		Min:   b.Min,
+		Max:   b.Max,
+		Count: int(b.Count),
+	}
+}
+
+func crossTabToGraphQL(t *analytics.CrossTab) *gqlmodel.CrossTab {
+	result := &gqlmodel.CrossTab{
+		FormID:           t.FormID,
+		RowQuestion:      questionToGraphQL(&t.RowQuestion),
+		ColumnQuestion:   questionToGraphQL(&t.ColumnQuestion),
+		Rows:             crossTabCategoriesToGraphQL(t.Rows),
+		Columns:          crossTabCategoriesToGraphQL(t.Columns),
+		Cells:            make([]*gqlmodel.CrossTabCell, len(t.Cells)),
+		Total:            int32(t.Total),
+		ChiSquare:        t.ChiSquare,
+		DegreesOfFreedom: int32(t.DegreesOfFreedom),
+		PValue:           t.PValue,
+	}
+
+	for i, c := range t.Cells {
+		result.Cells[i] = &gqlmodel.CrossTabCell{
+			RowKey:        c.RowKey,
+			ColumnKey:     c.ColumnKey,
+			Count:         int32(c.Count),
+			Expected:      c.Expected,
+			RowPercent:    c.RowPercent,
+			ColumnPercent: c.ColumnPercent,
+			TotalPercent:  c.TotalPercent,
+		}
+	}
+
+	return result
+}
+
+func crossTabCategoriesToGraphQL(categories []analytics.Category) []*gqlmodel.CrossTabCategory {
+	result := make([]*gqlmodel.CrossTabCategory, len(categories))
+	for i, c := range categories {
+		result[i] = &gqlmodel.CrossTabCategory{
+			Key:     c.Key,
+			Label:   c.Label,
+			Total:   int32(c.Total),
+			Percent: c.Percent,
+		}
+	}
+	return result
+}
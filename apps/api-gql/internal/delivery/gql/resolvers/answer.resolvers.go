@@ -8,43 +8,54 @@ import (
 	"log"
 	"time"
 
+	"github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph"
 	gqlmodel "github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph/model"
+	"github.com/TrySquadDF/formify/api-gql/internal/server/gincontext"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/responses"
 	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
-	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
+// maxSubmitBatchSize limits how many responses submitFormResponses accepts at once
+const maxSubmitBatchSize = 100
+
 // Retrieves all form responses for a given form
-func (r *queryResolver) FormResponses(ctx context.Context, formID string) ([]*gqlmodel.FormResponse, error) {
-    // Check authorization
-    userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
-    if err != nil {
-        return nil, errors.New("authorization required")
+func (r *queryResolver) FormResponses(ctx context.Context, formID string, filter *gqlmodel.FormResponseFilter, limit *int32, offset *int32) ([]*gqlmodel.FormResponse, error) {
+    if _, err := r.authorizedForm(ctx, formID, access.ViewResponses); err != nil {
+        return nil, err
     }
 
-    // Check if form exists
-    var form gomodel.Form
-    if err := r.deps.Gorm.First(&form, "id = ?", formID).Error; err != nil {
-        if errors.Is(err, gorm.ErrRecordNotFound) {
-            return nil, errors.New("form not found")
+    // Get all responses matching the filter
+    query := r.deps.Gorm.
+        Preload("Answers").
+        Preload("Answers.Question").
+        Preload("Answers.SelectedOptions").
+        Where("form_id = ?", formID)
+
+    if filter != nil {
+        if len(filter.Statuses) > 0 {
+            query = query.Where("status IN ?", filter.Statuses)
+        }
+        if filter.AssigneeID != nil {
+            query = query.Where("assignee_id = ?", *filter.AssigneeID)
+        }
+        if filter.Unassigned != nil && *filter.Unassigned {
+            query = query.Where("assignee_id IS NULL")
+        }
+        if len(filter.Tags) > 0 {
+            query = query.Where("tags @> ?", pq.StringArray(filter.Tags))
         }
-        return nil, err
     }
 
-    // Check ownership
-    if form.OwnerID != userID {
-        return nil, errors.New("access denied")
+    query, err := paginate(query, limit, offset)
+    if err != nil {
+        return nil, err
     }
 
-    // Get all responses
     var responses []gomodel.FormResponse
-    if err := r.deps.Gorm.
-        Preload("Answers").
-        Preload("Answers.Question").
-        Preload("Answers.SelectedOptions").
-        Where("form_id = ?", formID).
-        Order("created_at DESC").
-        Find(&responses).Error; err != nil {
+    if err := query.Order("created_at DESC, id").Find(&responses).Error; err != nil {
         return nil, err
     }
 
@@ -76,14 +87,9 @@ func (r *queryResolver) FormResponse(ctx context.Context, id string) (*gqlmodel.
         return nil, err
     }
 
-    // Get form to check ownership
-    var form gomodel.Form
-    if err := r.deps.Gorm.First(&form, "id = ?", response.FormID).Error; err != nil {
+    if _, err := r.deps.Access.Authorize(ctx, response.FormID, userID, access.ViewResponses); err != nil {
         return nil, err
     }
-    if form.OwnerID != userID {
-        return nil, errors.New("access denied")
-    }
 
     return FormResponseToGraphQL(&response), nil
 }
@@ -92,150 +98,330 @@ func (r *queryResolver) FormResponse(ctx context.Context, id string) (*gqlmodel.
 func (r *mutationResolver) SubmitFormResponse(ctx context.Context, input gqlmodel.FormResponseInput) (*gqlmodel.FormResponse, error) {
     log.Printf("SubmitFormResponse called for form: %s with %d answers", input.FormID, len(input.Answers))
 
-    // Check form existence
+    submission, err := r.submitResponse(ctx, &input, make(map[string]*gomodel.Form))
+    if err != nil {
+        log.Printf("Error submitting response to form %s: %v", input.FormID, err)
+        return nil, err
+    }
+
+    return submissionToGraphQL(submission), nil
+}
+
+// Submits responses collected offline one by one, reporting the outcome of each
+func (r *mutationResolver) SubmitFormResponses(ctx context.Context, batch []*gqlmodel.FormResponseInput) ([]*gqlmodel.FormResponseBatchResult, error) {
+    if len(batch) > maxSubmitBatchSize {
+        return nil, fmt.Errorf("batch may contain at most %d responses", maxSubmitBatchSize)
+    }
+
+    forms := make(map[string]*gomodel.Form)
+    results := make([]*gqlmodel.FormResponseBatchResult, len(batch))
+    for i, input := range batch {
+        result := &gqlmodel.FormResponseBatchResult{
+            Index:          int32(i),
+            IdempotencyKey: input.IdempotencyKey,
+        }
+
+        submission, err := r.submitResponse(ctx, input, forms)
+        if err != nil {
+            message := err.Error()
+            result.Error = &message
+        } else {
+            result.Response = submissionToGraphQL(submission)
+            result.Duplicate = submission.Duplicate
+        }
+        results[i] = result
+    }
+
+    return results, nil
+}
+
+// submitResponse checks access to the form and stores a response to it. Loaded forms
+// are kept in the cache so a batch loads every form only once.
+func (r *Resolver) submitResponse(ctx context.Context, input *gqlmodel.FormResponseInput, forms map[string]*gomodel.Form) (*responses.Submission, error) {
+    if err := r.requireScope(ctx, gomodel.ApiKeyScopeResponsesWrite); err != nil {
+        return nil, err
+    }
+
+    form, ok := forms[input.FormID]
+    if !ok {
+        form = &gomodel.Form{}
+        if err := r.deps.Gorm.Preload("Questions.Options").First(form, "id = ?", input.FormID).Error; err != nil {
+            if errors.Is(err, gorm.ErrRecordNotFound) {
+                return nil, errors.New("form not found")
+            }
+            return nil, err
+        }
+        forms[input.FormID] = form
+    }
+
+    // Check form access
+    if !r.canViewForm(ctx, form) {
+        return nil, errors.New("access denied")
+    }
+
+    params := responses.SubmitParams{
+        Form:           form,
+        Answers:        answerInputsFromGraphQL(input.Answers),
+        DraftToken:     input.DraftToken,
+        IdempotencyKey: input.IdempotencyKey,
+    }
+    if input.SubmittedAt != nil {
+        submittedAt, err := time.Parse(time.RFC3339, *input.SubmittedAt)
+        if err != nil {
+            return nil, errors.New("invalid submittedAt format: " + *input.SubmittedAt)
+        }
+        params.SubmittedAt = &submittedAt
+    }
+    if ginCtx, err := gincontext.GetGinContext(ctx); err == nil {
+        params.RemoteIP = ginCtx.ClientIP()
+    }
+    if userID, err := r.deps.Sessions.GetUserIDFromContext(ctx); err == nil {
+        params.SubmitterID = userID
+    }
+
+    return r.deps.Responses.Submit(ctx, params)
+}
+
+func submissionToGraphQL(submission *responses.Submission) *gqlmodel.FormResponse {
+    response := FormResponseToGraphQL(submission.Response)
+    if submission.EditToken != "" {
+        response.EditToken = &submission.EditToken
+    }
+    return response
+}
+
+// Stores partially filled answers so the respondent can resume later
+func (r *mutationResolver) SaveResponseDraft(ctx context.Context, formID string, answers []*gqlmodel.AnswerInput, draftToken *string) (*gqlmodel.ResponseDraft, error) {
+    if err := r.requireScope(ctx, gomodel.ApiKeyScopeResponsesWrite); err != nil {
+        return nil, err
+    }
+
     var form gomodel.Form
-    if err := r.deps.Gorm.Preload("Questions.Options").First(&form, "id = ?", input.FormID).Error; err != nil {
+    if err := r.deps.Gorm.Preload("Questions.Options").First(&form, "id = ?", formID).Error; err != nil {
         if errors.Is(err, gorm.ErrRecordNotFound) {
             return nil, errors.New("form not found")
         }
         return nil, err
     }
 
-    // Check form access
-    if form.Access == gomodel.FormAccessPrivate {
-        userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
-        if err != nil || userID != form.OwnerID {
-            return nil, errors.New("access denied")
+    if !r.canViewForm(ctx, &form) {
+        return nil, errors.New("access denied")
+    }
+
+    draft, err := r.deps.Responses.SaveDraft(ctx, &form, answerInputsFromGraphQL(answers), draftToken)
+    if err != nil {
+        return nil, err
+    }
+    return responseDraftToGraphQL(draft), nil
+}
+
+// Loads a draft back by its resume token
+func (r *queryResolver) ResponseDraft(ctx context.Context, token string) (*gqlmodel.ResponseDraft, error) {
+    draft, err := r.deps.Responses.LoadDraft(ctx, token)
+    if err != nil {
+        if errors.Is(err, responses.ErrDraftNotFound) {
+            return nil, nil
         }
+        return nil, err
     }
+    return responseDraftToGraphQL(draft), nil
+}
 
-    savedAnswers := make([]gomodel.Answer, 0, len(input.Answers))
-    tx := r.deps.Gorm.Begin()
-    defer func() {
-        if r := recover(); r != nil {
-            tx.Rollback()
-            log.Printf("Panic recovered in SubmitFormResponse: %v", r)
+func responseDraftToGraphQL(d *responses.Draft) *gqlmodel.ResponseDraft {
+    draft := &gqlmodel.ResponseDraft{
+        FormID:    d.FormID,
+        Token:     d.Token,
+        Answers:   make([]*gqlmodel.DraftAnswer, len(d.Answers)),
+        UpdatedAt: d.UpdatedAt.Format(time.RFC3339),
+        ExpiresAt: d.ExpiresAt.Format(time.RFC3339),
+    }
+    for i, a := range d.Answers {
+        draft.Answers[i] = &gqlmodel.DraftAnswer{
+            QuestionID:  a.QuestionID,
+            TextValue:   a.TextValue,
+            BoolValue:   a.BoolValue,
+            NumberValue: a.NumberValue,
+            DateValue:   a.DateValue,
+            OptionIds:   a.OptionIDs,
         }
-    }()
+    }
+    return draft
+}
 
-    formResponse := gomodel.FormResponse{
-        ID:        uuid.New().String(),
-        FormID:    input.FormID,
-        CreatedAt: time.Now(),
+// Loads a response by the edit token issued to its respondent
+func (r *queryResolver) EditableFormResponse(ctx context.Context, token string) (*gqlmodel.FormResponse, error) {
+    response, err := r.deps.Responses.FindByEditToken(ctx, token)
+    if err != nil {
+        return nil, err
     }
+    return FormResponseToGraphQL(response), nil
+}
 
-    if err := tx.Create(&formResponse).Error; err != nil {
-        tx.Rollback()
-        log.Printf("Error creating FormResponse: %v", err)
+// Replaces the answers of a response using the edit token issued to its respondent
+func (r *mutationResolver) UpdateFormResponse(ctx context.Context, token string, input gqlmodel.FormResponseUpdateInput) (*gqlmodel.FormResponse, error) {
+    response, err := r.deps.Responses.UpdateByEditToken(ctx, token, answerInputsFromGraphQL(input.Answers))
+    if err != nil {
         return nil, err
     }
+    return FormResponseToGraphQL(response), nil
+}
 
-    for _, answerInput := range input.Answers {
+// Retrieves the earlier answer sets of a response
+func (r *queryResolver) FormResponseRevisions(ctx context.Context, responseID string) ([]*gqlmodel.FormResponseRevision, error) {
+    if _, _, _, err := r.reviewableResponse(ctx, responseID, access.ViewResponses); err != nil {
+        return nil, err
+    }
 
-        var question gomodel.Question
-        if err := tx.First(&question, "id = ?", answerInput.QuestionID).Error; err != nil {
-            tx.Rollback()
-            return nil, fmt.Errorf("question not found: %s", answerInput.QuestionID)
-        }
+    revisions, err := r.deps.Responses.Revisions(ctx, responseID)
+    if err != nil {
+        return nil, err
+    }
 
-        answer := gomodel.Answer{
-            ID:              uuid.New().String(),
-            ResponseID:      formResponse.ID,
-            QuestionID:      answerInput.QuestionID,
-            Question:        question,
-            SelectedOptions: make([]gomodel.Option, 0),
+    result := make([]*gqlmodel.FormResponseRevision, 0, len(revisions))
+    for _, rev := range revisions {
+        var answers []gomodel.Answer
+        if err := json.Unmarshal([]byte(rev.Answers), &answers); err != nil {
+            return nil, err
         }
 
-        // Match the question type to store corresponding values
-        switch question.Type {
-        case "BOOLEAN": // Changed from gomodel.QuestionTypeBoolean
-            if answerInput.BoolValue != nil {
-                boolCopy := *answerInput.BoolValue
-                answer.BoolValue = &boolCopy
-            }
-        case "NUMBER": // Changed from gomodel.QuestionTypeNumber
-            if answerInput.NumberValue != nil {
-                numCopy := *answerInput.NumberValue
-                answer.NumberValue = &numCopy
-            }
-        case "DATE": // Changed from gomodel.QuestionTypeDate
-            if answerInput.DateValue != nil {
-                parsedTime, err := time.Parse(time.RFC3339, *answerInput.DateValue)
-                if err != nil {
-                    tx.Rollback()
-                    log.Printf("Date format error: %s - %v", *answerInput.DateValue, err)
-                    return nil, errors.New("invalid date format: " + *answerInput.DateValue)
-                }
-                answer.DateValue = &parsedTime
-            }
-        case "SINGLE_CHOICE", "MULTIPLE_CHOICE": // Changed from gomodel.QuestionTypeSingleChoice, etc.
-            if len(answerInput.OptionIds) > 0 {
-                optionsForThisAnswer := make([]gomodel.Option, 0, len(answerInput.OptionIds))
-                for _, optionID := range answerInput.OptionIds {
-                    var opt gomodel.Option
-                    if err := tx.First(&opt, "id = ?", optionID).Error; err != nil {
-                        tx.Rollback()
-                        log.Printf("Option not found: %s", optionID)
-                        return nil, fmt.Errorf("option not found: %s", optionID)
-                    }
-                    optionsForThisAnswer = append(optionsForThisAnswer, opt)
-
-                    answerOption := gomodel.AnswerOption{
-                        ID:       uuid.New().String(),
-                        AnswerID: answer.ID,
-                        OptionID: optionID,
-                    }
-
-                    if err := tx.Create(&answerOption).Error; err != nil {
-                        tx.Rollback()
-                        log.Printf("Error creating AnswerOption: %v", err)
-                        return nil, err
-                    }
-                }
-                answer.SelectedOptions = optionsForThisAnswer
-            }
-        case "EMAIL", "SHORT_TEXT", "PARAGRAPH", "PHONE": // Explicitly handle text types
-            log.Println(answerInput)
-            if answerInput.TextValue != nil {
-                answer.TextValue = *answerInput.TextValue
-            }
-        default:
-            log.Printf("Unknown question type: %s", question.Type)
-            if answerInput.TextValue != nil {
-                answer.TextValue = *answerInput.TextValue
-            }
+        revision := &gqlmodel.FormResponseRevision{
+            ID:         rev.ID,
+            ResponseID: rev.ResponseID,
+            Revision:   rev.Revision,
+            CreatedAt:  rev.CreatedAt.Format(time.RFC3339),
+            Answers:    make([]*gqlmodel.Answer, 0, len(answers)),
         }
-        
-        answerJSON, err := json.MarshalIndent(answer, "", "  ")
-        if err != nil {
-            log.Printf("Error marshaling answer to JSON: %v", err)
-        } else {
-            log.Printf("Saving answer: %s", string(answerJSON))
+        for i := range answers {
+            revision.Answers = append(revision.Answers, AnswerToGraphQL(&answers[i]))
         }
-        if err := tx.Create(&answer).Error; err != nil {
-            tx.Rollback()
-            log.Printf("Error creating Answer: %v", err)
-            return nil, err
+        result = append(result, revision)
+    }
+    return result, nil
+}
+
+func answerInputsFromGraphQL(inputs []*gqlmodel.AnswerInput) []responses.AnswerInput {
+    result := make([]responses.AnswerInput, len(inputs))
+    for i, in := range inputs {
+        result[i] = responses.AnswerInput{
+            QuestionID:  in.QuestionID,
+            TextValue:   in.TextValue,
+            BoolValue:   in.BoolValue,
+            NumberValue: in.NumberValue,
+            DateValue:   in.DateValue,
+            OptionIDs:   in.OptionIds,
         }
-        savedAnswers = append(savedAnswers, answer)
     }
+    return result
+}
+
+// Moves a single response to the trash
+func (r *mutationResolver) DeleteResponse(ctx context.Context, id string) (bool, error) {
+    deleted, err := r.DeleteResponses(ctx, []string{id})
+    if err != nil {
+        return false, err
+    }
+    return deleted > 0, nil
+}
+
+// Moves several responses to the trash at once
+func (r *mutationResolver) DeleteResponses(ctx context.Context, ids []string) (int32, error) {
+    ids, err := r.editableResponseIDs(ctx, ids, false)
+    if err != nil {
+        return 0, err
+    }
+
+    deleted, err := r.deps.Responses.Delete(ctx, ids)
+    if err != nil {
+        return 0, err
+    }
+    return int32(deleted), nil
+}
 
-    if err := tx.Commit().Error; err != nil {
-        log.Printf("Transaction commit error: %v", err)
+// Takes a response back out of the trash
+func (r *mutationResolver) RestoreResponse(ctx context.Context, id string) (*gqlmodel.FormResponse, error) {
+    if _, err := r.RestoreResponses(ctx, []string{id}); err != nil {
         return nil, err
     }
 
-    var completeResponse gomodel.FormResponse
-    if err := r.deps.Gorm.
-        Preload("Answers").
-        Preload("Answers.Question").
-        Preload("Answers.SelectedOptions").
-        First(&completeResponse, "id = ?", formResponse.ID).Error; err != nil {
-        log.Printf("Error fetching complete response: %v", err)
+    return r.loadFormResponse(id)
+}
+
+// Takes several responses back out of the trash at once
+func (r *mutationResolver) RestoreResponses(ctx context.Context, ids []string) (int32, error) {
+    ids, err := r.editableResponseIDs(ctx, ids, true)
+    if err != nil {
+        return 0, err
+    }
+
+    restored, err := r.deps.Responses.Restore(ctx, ids)
+    if err != nil {
+        return 0, err
+    }
+    return int32(restored), nil
+}
+
+// Retrieves the trashed responses of a form
+func (r *queryResolver) TrashedFormResponses(ctx context.Context, formID string) ([]*gqlmodel.FormResponse, error) {
+    if _, err := r.authorizedForm(ctx, formID, access.EditForm); err != nil {
+        return nil, err
+    }
+
+    responses, err := r.deps.Responses.Trashed(ctx, formID)
+    if err != nil {
+        return nil, err
+    }
+
+    result := make([]*gqlmodel.FormResponse, 0, len(responses))
+    for _, resp := range responses {
+        result = append(result, FormResponseToGraphQL(&resp))
+    }
+    return result, nil
+}
+
+// Checks that every response exists (in the trash when trashed is set) and belongs
+// to a form the current user may edit. Returns the deduplicated IDs.
+func (r *Resolver) editableResponseIDs(ctx context.Context, ids []string, trashed bool) ([]string, error) {
+    userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+    if err != nil {
+        return nil, errors.New("authorization required")
+    }
+
+    unique := make([]string, 0, len(ids))
+    seen := make(map[string]struct{}, len(ids))
+    for _, id := range ids {
+        if _, ok := seen[id]; !ok {
+            seen[id] = struct{}{}
+            unique = append(unique, id)
+        }
+    }
+    if len(unique) == 0 {
+        return nil, errors.New("no responses specified")
+    }
+
+    query := r.deps.Gorm.Model(&gomodel.FormResponse{})
+    if trashed {
+        query = query.Unscoped().Where("deleted_at IS NOT NULL")
+    }
+
+    var responses []gomodel.FormResponse
+    if err := query.Where("id IN ?", unique).Find(&responses).Error; err != nil {
         return nil, err
     }
-    
-    return FormResponseToGraphQL(&completeResponse), nil
+    if len(responses) != len(unique) {
+        return nil, errors.New("response not found")
+    }
+
+    formIDs := make(map[string]struct{})
+    for _, resp := range responses {
+        formIDs[resp.FormID] = struct{}{}
+    }
+    for formID := range formIDs {
+        if _, err := r.deps.Access.Authorize(ctx, formID, userID, access.EditForm); err != nil {
+            return nil, err
+        }
+    }
+
+    return unique, nil
 }
 
 // Convert FormResponse to GraphQL model
@@ -246,10 +432,18 @@ func FormResponseToGraphQL(fr *gomodel.FormResponse) *gqlmodel.FormResponse {
     }
 
     response := &gqlmodel.FormResponse{
-        ID:        fr.ID,
-        FormID:    fr.FormID,
-        CreatedAt: fr.CreatedAt.Format(time.RFC3339),
-        Answers:   make([]*gqlmodel.Answer, 0, len(fr.Answers)),
+        ID:         fr.ID,
+        FormID:     fr.FormID,
+        CreatedAt:  fr.CreatedAt.Format(time.RFC3339),
+        DeletedAt:  deletedAtToGraphQL(fr.DeletedAt),
+        Status:     fr.Status,
+        AssigneeID: fr.AssigneeID,
+        Tags:       fr.Tags,
+        UpdatedAt:  timeToGraphQL(fr.UpdatedAt),
+        Answers:    make([]*gqlmodel.Answer, 0, len(fr.Answers)),
+    }
+    if response.Tags == nil {
+        response.Tags = []string{}
     }
 
     for i := range fr.Answers {
@@ -320,4 +514,41 @@ func AnswerToGraphQL(a *gomodel.Answer) *gqlmodel.Answer {
     }
 
     return answer
-}
\ No newline at end of file
+}
+
+// FormResponse returns graph.FormResponseResolver implementation.
+func (r *Resolver) FormResponse() graph.FormResponseResolver { return &formResponseResolver{r} }
+
+type formResponseResolver struct{ *Resolver }
+
+// Streams responses to the form as they are submitted on any API instance
+func (r *subscriptionResolver) ResponseSubmitted(ctx context.Context, formID string) (<-chan *gqlmodel.FormResponse, error) {
+    if _, err := r.authorize(ctx, formID, access.ViewResponses); err != nil {
+        return nil, err
+    }
+
+    ids, err := r.deps.PubSub.ResponseSubmitted(ctx, formID)
+    if err != nil {
+        return nil, err
+    }
+
+    out := make(chan *gqlmodel.FormResponse)
+    go func() {
+        defer close(out)
+        for id := range ids {
+            response, err := r.deps.Responses.Find(ctx, id)
+            if err != nil {
+                log.Printf("Error loading submitted response %s: %v", id, err)
+                continue
+            }
+
+            select {
+            case out <- FormResponseToGraphQL(response):
+            case <-ctx.Done():
+                return
+            }
+        }
+    }()
+
+    return out, nil
+}
@@ -0,0 +1,106 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.70
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	gqlmodel "github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph/model"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/apikeys"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// CreateAPIKey is the resolver for the createApiKey field.
+func (r *mutationResolver) CreateAPIKey(ctx context.Context, input gqlmodel.APIKeyInput) (*gqlmodel.APIKey, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	params := apikeys.KeyParams{
+		Name:   input.Name,
+		Scopes: make([]gomodel.ApiKeyScope, len(input.Scopes)),
+	}
+	for i, scope := range input.Scopes {
+		params.Scopes[i] = apiKeyScopeFromGraphQL(scope)
+	}
+	if input.ExpiresAt != nil && *input.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, *input.ExpiresAt)
+		if err != nil {
+			return nil, errors.New("invalid date format: " + *input.ExpiresAt)
+		}
+		params.ExpiresAt = &expiresAt
+	}
+
+	apiKey, key, err := r.deps.ApiKeys.Create(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := apiKeyToGraphQL(apiKey)
+	result.Key = &key
+	return result, nil
+}
+
+// RevokeAPIKey is the resolver for the revokeApiKey field.
+func (r *mutationResolver) RevokeAPIKey(ctx context.Context, id string) (bool, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, errors.New("authorization required")
+	}
+
+	if err := r.deps.ApiKeys.Revoke(ctx, userID, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// APIKeys is the resolver for the apiKeys field.
+func (r *queryResolver) APIKeys(ctx context.Context) ([]*gqlmodel.APIKey, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	keys, err := r.deps.ApiKeys.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*gqlmodel.APIKey, len(keys))
+	for i := range keys {
+		result[i] = apiKeyToGraphQL(&keys[i])
+	}
+	return result, nil
+}
+
+// Scopes are "forms:read" on keys and FORMS_READ in the schema
+func apiKeyScopeFromGraphQL(s gqlmodel.APIKeyScope) gomodel.ApiKeyScope {
+	return gomodel.ApiKeyScope(strings.Replace(strings.ToLower(string(s)), "_", ":", 1))
+}
+
+func apiKeyScopeToGraphQL(s string) gqlmodel.APIKeyScope {
+	return gqlmodel.APIKeyScope(strings.Replace(strings.ToUpper(s), ":", "_", 1))
+}
+
+func apiKeyToGraphQL(k *gomodel.ApiKey) *gqlmodel.APIKey {
+	scopes := make([]gqlmodel.APIKeyScope, len(k.Scopes))
+	for i, s := range k.Scopes {
+		scopes[i] = apiKeyScopeToGraphQL(s)
+	}
+
+	return &gqlmodel.APIKey{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  timeToGraphQL(k.ExpiresAt),
+		LastUsedAt: timeToGraphQL(k.LastUsedAt),
+		CreatedAt:  k.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,189 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.70
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	gqlmodel "github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph/model"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/presence"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/pubsub"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"gorm.io/gorm"
+)
+
+// FormHeartbeat is the resolver for the formHeartbeat field.
+func (r *mutationResolver) FormHeartbeat(ctx context.Context, formID string, editing *string) ([]*gqlmodel.FormPresence, error) {
+	form, err := r.authorizedForm(ctx, formID, access.EditForm)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.deps.Sessions.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	editor := presence.Editor{
+		UserID:      user.ID,
+		DisplayName: user.DisplayName,
+		Picture:     user.Picture,
+	}
+	if editing != nil {
+		editor.Editing = *editing
+	}
+
+	changed, err := r.deps.Presence.Heartbeat(ctx, formID, editor)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		r.publishFormChange(ctx, pubsub.FormChangePresenceChanged, form)
+	}
+
+	return r.formPresence(ctx, formID)
+}
+
+// LeaveForm is the resolver for the leaveForm field.
+func (r *mutationResolver) LeaveForm(ctx context.Context, formID string) (bool, error) {
+	form, err := r.authorizedForm(ctx, formID, access.EditForm)
+	if err != nil {
+		return false, err
+	}
+
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, errors.New("authorization required")
+	}
+
+	if err := r.deps.Presence.Leave(ctx, formID, userID); err != nil {
+		return false, err
+	}
+	r.publishFormChange(ctx, pubsub.FormChangePresenceChanged, form)
+
+	return true, nil
+}
+
+// FormPresence is the resolver for the formPresence field.
+func (r *queryResolver) FormPresence(ctx context.Context, formID string) ([]*gqlmodel.FormPresence, error) {
+	if _, err := r.authorizedForm(ctx, formID, access.EditForm); err != nil {
+		return nil, err
+	}
+
+	return r.formPresence(ctx, formID)
+}
+
+// FormChanges is the resolver for the formChanges field.
+func (r *subscriptionResolver) FormChanges(ctx context.Context, formID string) (<-chan *gqlmodel.FormChangeEvent, error) {
+	if _, err := r.authorizedForm(ctx, formID, access.EditForm); err != nil {
+		return nil, err
+	}
+
+	changes, err := r.deps.PubSub.FormChanges(ctx, formID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *gqlmodel.FormChangeEvent)
+	go func() {
+		defer close(out)
+		for change := range changes {
+			event, err := r.formChangeEvent(ctx, change)
+			if err != nil {
+				log.Printf("Error loading change %s of form %s: %v", change.Kind, formID, err)
+				continue
+			}
+
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// formChangeEvent loads the current state of whatever the change touched.
+func (r *Resolver) formChangeEvent(ctx context.Context, change pubsub.FormChange) (*gqlmodel.FormChangeEvent, error) {
+	event := &gqlmodel.FormChangeEvent{
+		FormID:  change.FormID,
+		Kind:    gqlmodel.FormChangeKind(change.Kind),
+		Version: int32(change.Version),
+		ActorID: change.ActorID,
+	}
+	if change.QuestionID != "" {
+		event.QuestionID = &change.QuestionID
+	}
+	if change.OptionID != "" {
+		event.OptionID = &change.OptionID
+	}
+
+	switch change.Kind {
+	case pubsub.FormChangeQuestionUpdated:
+		var question gomodel.Question
+		if err := r.deps.Gorm.Preload("Options").First(&question, "id = ?", change.QuestionID).Error; err != nil {
+			return nil, changedEntityError(err)
+		}
+		event.Question = questionToGraphQL(&question)
+	case pubsub.FormChangeOptionUpdated:
+		var option gomodel.Option
+		if err := r.deps.Gorm.First(&option, "id = ?", change.OptionID).Error; err != nil {
+			return nil, changedEntityError(err)
+		}
+		event.Option = optionToGraphQL(&option)
+	case pubsub.FormChangeQuestionDeleted, pubsub.FormChangeOptionDeleted:
+	case pubsub.FormChangePresenceChanged:
+		editors, err := r.formPresence(ctx, change.FormID)
+		if err != nil {
+			return nil, err
+		}
+		event.Presence = editors
+	default:
+		var form gomodel.Form
+		if err := r.deps.Gorm.Preload("Questions.Options").First(&form, "id = ?", change.FormID).Error; err != nil {
+			return nil, changedEntityError(err)
+		}
+		event.Form = FormToGraphQL(&form)
+	}
+
+	return event, nil
+}
+
+// changedEntityError explains a failed lookup of something deleted in the meantime;
+// a later change event reports the deletion itself.
+func changedEntityError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("changed entity no longer exists")
+	}
+	return err
+}
+
+func (r *Resolver) formPresence(ctx context.Context, formID string) ([]*gqlmodel.FormPresence, error) {
+	editors, err := r.deps.Presence.Editors(ctx, formID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*gqlmodel.FormPresence, len(editors))
+	for i, e := range editors {
+		result[i] = &gqlmodel.FormPresence{
+			UserID:      e.UserID,
+			DisplayName: e.DisplayName,
+			Picture:     e.Picture,
+			LastSeen:    e.LastSeen.Format(time.RFC3339),
+		}
+		if e.Editing != "" {
+			editing := e.Editing
+			result[i].Editing = &editing
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,234 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.70
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	gqlmodel "github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph/model"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/folders"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// Starred is the resolver for the starred field.
+func (r *formResolver) Starred(ctx context.Context, obj *gqlmodel.Form) (bool, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, nil
+	}
+	return r.isStarred(ctx, userID, obj.ID)
+}
+
+// CreateFolder is the resolver for the createFolder field.
+func (r *mutationResolver) CreateFolder(ctx context.Context, name string, parentID *string, workspaceID *string) (*gqlmodel.Folder, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	var parent *gomodel.Folder
+	if parentID != nil {
+		if parent, err = r.authorizedFolder(ctx, *parentID); err != nil {
+			return nil, err
+		}
+	}
+
+	scope := folders.Scope{OwnerID: &userID}
+	if workspaceID != nil {
+		if _, err := r.authorizedWorkspace(ctx, *workspaceID, false); err != nil {
+			return nil, err
+		}
+		scope = folders.Scope{WorkspaceID: workspaceID}
+	}
+
+	folder, err := r.deps.Folders.Create(ctx, scope, parent, name)
+	if err != nil {
+		return nil, err
+	}
+	return folderToGraphQL(folder), nil
+}
+
+// RenameFolder is the resolver for the renameFolder field.
+func (r *mutationResolver) RenameFolder(ctx context.Context, id string, name string) (*gqlmodel.Folder, error) {
+	folder, err := r.authorizedFolder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.deps.Folders.Rename(ctx, folder, name); err != nil {
+		return nil, err
+	}
+	return folderToGraphQL(folder), nil
+}
+
+// MoveFolder is the resolver for the moveFolder field.
+func (r *mutationResolver) MoveFolder(ctx context.Context, id string, parentID *string) (*gqlmodel.Folder, error) {
+	folder, err := r.authorizedFolder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var parent *gomodel.Folder
+	if parentID != nil {
+		if parent, err = r.authorizedFolder(ctx, *parentID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.deps.Folders.Move(ctx, folder, parent); err != nil {
+		return nil, err
+	}
+	return folderToGraphQL(folder), nil
+}
+
+// DeleteFolder is the resolver for the deleteFolder field.
+func (r *mutationResolver) DeleteFolder(ctx context.Context, id string) (bool, error) {
+	folder, err := r.authorizedFolder(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.deps.Folders.Delete(ctx, folder); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// MoveFormToFolder is the resolver for the moveFormToFolder field.
+func (r *mutationResolver) MoveFormToFolder(ctx context.Context, formID string, folderID *string) (*gqlmodel.Form, error) {
+	form, err := r.authorizedForm(ctx, formID, access.EditForm)
+	if err != nil {
+		return nil, err
+	}
+
+	var folder *gomodel.Folder
+	if folderID != nil {
+		if folder, err = r.authorizedFolder(ctx, *folderID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.deps.Folders.MoveForm(ctx, form, folder); err != nil {
+		return nil, err
+	}
+	return FormToGraphQL(form), nil
+}
+
+// SetFormTags is the resolver for the setFormTags field.
+func (r *mutationResolver) SetFormTags(ctx context.Context, formID string, tags []string) (*gqlmodel.Form, error) {
+	form, err := r.authorizedForm(ctx, formID, access.EditForm)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.deps.Forms.SetTags(ctx, form, tags); err != nil {
+		return nil, err
+	}
+	return FormToGraphQL(form), nil
+}
+
+// StarForm is the resolver for the starForm field.
+func (r *mutationResolver) StarForm(ctx context.Context, formID string) (*gqlmodel.Form, error) {
+	form, err := r.authorizedForm(ctx, formID, access.ViewForm)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	if err := r.deps.Forms.Star(ctx, userID, form.ID); err != nil {
+		return nil, err
+	}
+	setStarred(ctx, form.ID, true)
+	return FormToGraphQL(form), nil
+}
+
+// UnstarForm is the resolver for the unstarForm field.
+func (r *mutationResolver) UnstarForm(ctx context.Context, formID string) (*gqlmodel.Form, error) {
+	form, err := r.authorizedForm(ctx, formID, access.ViewForm)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	if err := r.deps.Forms.Unstar(ctx, userID, form.ID); err != nil {
+		return nil, err
+	}
+	setStarred(ctx, form.ID, false)
+	return FormToGraphQL(form), nil
+}
+
+// Folders is the resolver for the folders field.
+func (r *queryResolver) Folders(ctx context.Context, workspaceID *string) ([]*gqlmodel.Folder, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	scope := folders.Scope{OwnerID: &userID}
+	if workspaceID != nil {
+		if _, err := r.authorizedWorkspace(ctx, *workspaceID, false); err != nil {
+			return nil, err
+		}
+		scope = folders.Scope{WorkspaceID: workspaceID}
+	}
+
+	list, err := r.deps.Folders.List(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*gqlmodel.Folder, len(list))
+	for i := range list {
+		result[i] = folderToGraphQL(&list[i])
+	}
+	return result, nil
+}
+
+// authorizedFolder loads a folder and checks that it is a personal folder of the current
+// user or a folder of a workspace the user is a member of.
+func (r *Resolver) authorizedFolder(ctx context.Context, id string) (*gomodel.Folder, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	folder, err := r.deps.Folders.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if folder.WorkspaceID != nil {
+		if _, err := r.deps.Access.AuthorizeWorkspace(ctx, *folder.WorkspaceID, userID, false); err != nil {
+			return nil, err
+		}
+		return folder, nil
+	}
+
+	if folder.OwnerID == nil || *folder.OwnerID != userID {
+		return nil, access.ErrAccessDenied
+	}
+	return folder, nil
+}
+
+func folderToGraphQL(f *gomodel.Folder) *gqlmodel.Folder {
+	return &gqlmodel.Folder{
+		ID:          f.ID,
+		Name:        f.Name,
+		ParentID:    f.ParentID,
+		WorkspaceID: f.WorkspaceID,
+		CreatedAt:   f.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -7,12 +7,21 @@ package resolvers
 import (
 	"context"
 	"errors"
+	"log"
 	"time"
 
 	gqlmodel "github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph/model"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/folders"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/forms"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/formschema"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/labels"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/pubsub"
 	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func FormsToGraphQL(forms []gomodel.Form) []*gqlmodel.Form {
@@ -25,28 +34,84 @@ func FormsToGraphQL(forms []gomodel.Form) []*gqlmodel.Form {
 
 func FormToGraphQL(f *gomodel.Form) *gqlmodel.Form {
     access := gqlmodel.FormAccess(f.Access)
+    tags := []string(f.Tags)
+    if tags == nil {
+        tags = []string{}
+    }
     return &gqlmodel.Form{
-        ID:          f.ID,
-        OwnerID:     f.OwnerID,
-        Title:       f.Title,
-        Description: f.Description,
-        Access:      access,
-        CreatedAt:   f.CreatedAt.Format(time.RFC3339),
-        UpdatedAt:   f.UpdatedAt.Format(time.RFC3339),
-        Questions:   questionsToGraphQL(f.Questions),
+        ID:                    f.ID,
+        OwnerID:               f.OwnerID,
+        WorkspaceID:           f.WorkspaceID,
+        FolderID:              f.FolderID,
+        Tags:                  tags,
+        IsTemplate:            f.IsTemplate,
+        ExternalKey:           f.ExternalKey,
+        Title:                 f.Title,
+        Description:           f.Description,
+        Access:                access,
+        CreatedAt:             f.CreatedAt.Format(time.RFC3339),
+        UpdatedAt:             f.UpdatedAt.Format(time.RFC3339),
+        DeletedAt:             deletedAtToGraphQL(f.DeletedAt),
+        Questions:             questionsToGraphQL(f.Questions),
+        ResponseStatuses:      f.Statuses(),
+        AllowResponseEditing:  f.AllowResponseEditing,
+        ResponseEditDeadline:  timeToGraphQL(f.ResponseEditDeadline),
+        ClosedAt:              timeToGraphQL(f.ClosedAt),
+        ResponseNotifications: gqlmodel.ResponseNotifications(f.ResponseNotifications),
+        Version:               int32(f.Version),
+    }
+}
+
+func timeToGraphQL(t *time.Time) *string {
+    if t == nil {
+        return nil
+    }
+    formatted := t.Format(time.RFC3339)
+    return &formatted
+}
+
+// paginate applies the limit and offset arguments of a list; without a limit the whole
+// list is returned, as before pagination was added.
+func paginate(query *gorm.DB, limit, offset *int32) (*gorm.DB, error) {
+    if offset != nil && *offset < 0 {
+        return nil, errors.New("offset must not be negative")
+    }
+    if limit == nil {
+        if offset != nil {
+            query = query.Offset(int(*offset))
+        }
+        return query, nil
+    }
+    if *limit < 1 || *limit > 500 {
+        return nil, errors.New("limit must be between 1 and 500")
+    }
+    query = query.Limit(int(*limit))
+    if offset != nil {
+        query = query.Offset(int(*offset))
+    }
+    return query, nil
+}
+
+func deletedAtToGraphQL(d gorm.DeletedAt) *string {
+    if !d.Valid {
+        return nil
     }
+    deletedAt := d.Time.Format(time.RFC3339)
+    return &deletedAt
 }
 
 
 func questionToGraphQL(q *gomodel.Question) *gqlmodel.Question {
     return &gqlmodel.Question{
-        ID:       q.ID,
-        FormID:   q.FormID,
-        Text:     q.Text,
-        Type:     gqlmodel.QuestionType(q.Type),
-        Required: q.Required,
-        Order:    q.Order,
-        Options:  optionsToGraphQL(q.Options),
+        ID:              q.ID,
+        FormID:          q.FormID,
+        Key:             q.Key,
+        Text:            q.Text,
+        Type:            gqlmodel.QuestionType(q.Type),
+        Required:        q.Required,
+        Order:           q.Order,
+        RespondentEmail: q.RespondentEmail,
+        Options:         optionsToGraphQL(q.Options),
     }
 }
 
@@ -75,13 +140,77 @@ func optionToGraphQL(o *gomodel.Option) *gqlmodel.Option {
     }
 }
 
+// validateQuestionInputs checks the keys of the questions, which must be valid and
+// distinct, and the respondent email flag: it is only allowed on EMAIL questions and
+// on at most one question of a form.
+func validateQuestionInputs(questions []*gqlmodel.QuestionInput) error {
+	keys := make(map[string]bool, len(questions))
+	for _, q := range questions {
+		key := questionKey(q.Key)
+		if key == nil {
+			continue
+		}
+		if !formschema.ValidQuestionKey(*key) {
+			return errors.New("invalid question key: " + *key)
+		}
+		if keys[*key] {
+			return errors.New("question key used more than once: " + *key)
+		}
+		keys[*key] = true
+	}
+
+	respondentEmail := false
+	for _, q := range questions {
+		if q.RespondentEmail == nil || !*q.RespondentEmail {
+			continue
+		}
+		if q.Type != gqlmodel.QuestionTypeEmail {
+			return errors.New("only an EMAIL question can hold the respondent address")
+		}
+		if respondentEmail {
+			return errors.New("only one question can hold the respondent address")
+		}
+		respondentEmail = true
+	}
+	return nil
+}
+
+// questionKey treats an empty key as no key.
+func questionKey(key *string) *string {
+	if key == nil || *key == "" {
+		return nil
+	}
+	return key
+}
+
 // CreateForm is the resolver for the createForm field.
 func (r *mutationResolver) CreateForm(ctx context.Context, input gqlmodel.FormInput) (*gqlmodel.Form, error) {
-	// Get user ID from context
-	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	user, err := r.deps.Sessions.GetAuthenticatedUser(ctx)
 	if err != nil {
 		return nil, err
 	}
+	userID := user.ID
+
+	workspaceID, err := r.targetWorkspace(ctx, user, input.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var folderID *string
+	if input.FolderID != nil {
+		folder, err := r.authorizedFolder(ctx, *input.FolderID)
+		if err != nil {
+			return nil, err
+		}
+		if !folder.Holds(&gomodel.Form{OwnerID: userID, WorkspaceID: workspaceID}) {
+			return nil, folders.ErrWrongScope
+		}
+		folderID = &folder.ID
+	}
+
+	if err := validateQuestionInputs(input.Questions); err != nil {
+		return nil, err
+	}
 
 	tx := r.deps.Gorm.Begin()
 	defer func() {
@@ -93,11 +222,29 @@ func (r *mutationResolver) CreateForm(ctx context.Context, input gqlmodel.FormIn
 	form := &gomodel.Form{
 		ID:          uuid.New().String(),
 		OwnerID:     userID,
+		WorkspaceID: workspaceID,
+		FolderID:    folderID,
+		Tags:        labels.Normalize(input.Tags),
 		Title:       input.Title,
 		Description: *input.Description,
 		Access:      gomodel.FormAccess(string(*input.Access)),
 	}
 
+	if input.AllowResponseEditing != nil {
+		form.AllowResponseEditing = *input.AllowResponseEditing
+	}
+	if input.ResponseNotifications != nil {
+		form.ResponseNotifications = gomodel.ResponseNotifications(*input.ResponseNotifications)
+	}
+	if input.ResponseEditDeadline != nil && *input.ResponseEditDeadline != "" {
+		deadline, err := time.Parse(time.RFC3339, *input.ResponseEditDeadline)
+		if err != nil {
+			tx.Rollback()
+			return nil, errors.New("invalid date format: " + *input.ResponseEditDeadline)
+		}
+		form.ResponseEditDeadline = &deadline
+	}
+
 	if err := tx.Create(form).Error; err != nil {
 		tx.Rollback()
 		return nil, err
@@ -109,11 +256,15 @@ func (r *mutationResolver) CreateForm(ctx context.Context, input gqlmodel.FormIn
 			question := gomodel.Question{
 				ID:       uuid.New().String(),
 				FormID:   form.ID,
+				Key:      questionKey(qInput.Key),
 				Text:     qInput.Text,
 				Type:     gomodel.QuestionType(qInput.Type),
 				Required: qInput.Required,
 				Order:    qInput.Order,
 			}
+			if qInput.RespondentEmail != nil {
+				question.RespondentEmail = *qInput.RespondentEmail
+			}
 
 			if err := tx.Create(&question).Error; err != nil {
 				tx.Rollback()
@@ -154,8 +305,7 @@ func (r *mutationResolver) CreateForm(ctx context.Context, input gqlmodel.FormIn
 
 // UpdateForm is the resolver for the updateForm field.
 func (r *mutationResolver) UpdateForm(ctx context.Context, id string, input gqlmodel.FormUpdateInput) (*gqlmodel.Form, error) {
-	// Get user ID for authorization
-	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	userID, err := r.authorize(ctx, id, access.EditForm)
 	if err != nil {
 		return nil, err
 	}
@@ -165,9 +315,8 @@ func (r *mutationResolver) UpdateForm(ctx context.Context, id string, input gqlm
 		return nil, err
 	}
 
-	// Check ownership
-	if form.OwnerID != userID {
-		return nil, errors.New("not authorized to update this form")
+	if err := validateQuestionInputs(input.Questions); err != nil {
+		return nil, err
 	}
 
 	// Begin transaction
@@ -178,6 +327,12 @@ func (r *mutationResolver) UpdateForm(ctx context.Context, id string, input gqlm
 		}
 	}()
 
+	version, err := forms.BumpVersion(tx, id, versionFromGraphQL(input.Version))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	// Update basic form properties
 	updates := map[string]interface{}{
 		"updatedAt": time.Now(),
@@ -192,6 +347,27 @@ func (r *mutationResolver) UpdateForm(ctx context.Context, id string, input gqlm
 	if input.Access != nil {
 		updates["access"] = string(*input.Access)
 	}
+	if input.ReviewStatuses != nil {
+		updates["reviewStatuses"] = labels.Normalize(input.ReviewStatuses)
+	}
+	if input.AllowResponseEditing != nil {
+		updates["allowResponseEditing"] = *input.AllowResponseEditing
+	}
+	if input.ResponseNotifications != nil {
+		updates["responseNotifications"] = string(*input.ResponseNotifications)
+	}
+	if input.ResponseEditDeadline != nil {
+		if *input.ResponseEditDeadline == "" {
+			updates["responseEditDeadline"] = nil
+		} else {
+			deadline, err := time.Parse(time.RFC3339, *input.ResponseEditDeadline)
+			if err != nil {
+				tx.Rollback()
+				return nil, errors.New("invalid date format: " + *input.ResponseEditDeadline)
+			}
+			updates["responseEditDeadline"] = deadline
+		}
+	}
 
 	if err := tx.Model(&form).Updates(updates).Error; err != nil {
 		tx.Rollback()
@@ -217,11 +393,15 @@ func (r *mutationResolver) UpdateForm(ctx context.Context, id string, input gqlm
 			question := gomodel.Question{
 				ID:       uuid.New().String(),
 				FormID:   form.ID,
+				Key:      questionKey(qInput.Key),
 				Text:     qInput.Text,
 				Type:     gomodel.QuestionType(qInput.Type),
 				Required: qInput.Required,
 				Order:    qInput.Order,
 			}
+			if qInput.RespondentEmail != nil {
+				question.RespondentEmail = *qInput.RespondentEmail
+			}
 
 			if err := tx.Create(&question).Error; err != nil {
 				tx.Rollback()
@@ -251,6 +431,12 @@ func (r *mutationResolver) UpdateForm(ctx context.Context, id string, input gqlm
 		return nil, err
 	}
 
+	change := pubsub.FormChange{Kind: pubsub.FormChangeFormUpdated, FormID: id, Version: version, ActorID: userID}
+	if input.Questions != nil {
+		change.Kind = pubsub.FormChangeQuestionsReplaced
+	}
+	r.deps.PubSub.PublishFormChange(ctx, change)
+
 	// Fetch updated form with all relations
 	var result gomodel.Form
 	if err := r.deps.Gorm.Preload("Questions.Options").First(&result, "id = ?", id).Error; err != nil {
@@ -261,78 +447,102 @@ func (r *mutationResolver) UpdateForm(ctx context.Context, id string, input gqlm
 }
 
 // DeleteForm is the resolver for the deleteForm field.
+// The form is moved to the trash and purged later together with its responses.
 func (r *mutationResolver) DeleteForm(ctx context.Context, id string) (bool, error) {
-    // Get user ID for authorization
-    userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
-    if err != nil {
+    if _, err := r.authorizedForm(ctx, id, access.ManageForm); err != nil {
         return false, err
     }
 
-    // Check if form exists and user has permission
-    var form gomodel.Form
-    if err := r.deps.Gorm.First(&form, "id = ?", id).Error; err != nil {
-        if errors.Is(err, gorm.ErrRecordNotFound) {
-            return false, errors.New("form not found")
-        }
+    if err := r.deps.Forms.Trash(ctx, id); err != nil {
         return false, err
     }
 
-    if form.OwnerID != userID {
-        return false, errors.New("not authorized to delete this form")
-    }
+    return true, nil
+}
 
-    // Begin transaction
-    tx := r.deps.Gorm.Begin()
-    defer func() {
-        if r := recover(); r != nil {
-            tx.Rollback()
-        }
-    }()
+// RestoreForm is the resolver for the restoreForm field.
+func (r *mutationResolver) RestoreForm(ctx context.Context, id string) (*gqlmodel.Form, error) {
+	if _, err := r.authorize(ctx, id, access.ManageForm); err != nil {
+		return nil, err
+	}
 
-    // 1. Find all questions for this form
-    var questions []gomodel.Question
-    if err := tx.Where("form_id = ?", id).Find(&questions).Error; err != nil {
-        tx.Rollback()
-        return false, err
-    }
+	if _, err := r.deps.Forms.FindTrashed(ctx, id); err != nil {
+		return nil, err
+	}
 
-    // Extract question IDs
-    questionIDs := make([]string, len(questions))
-    for i, q := range questions {
-        questionIDs[i] = q.ID
-    }
+	if err := r.deps.Forms.Restore(ctx, id); err != nil {
+		return nil, err
+	}
 
-    // 2. Delete answers for all questions in this form
-    if len(questionIDs) > 0 {
-        if err := tx.Exec("DELETE FROM answers WHERE question_id IN ?", questionIDs).Error; err != nil {
-            tx.Rollback()
-            return false, err
-        }
-    }
+	var result gomodel.Form
+	if err := r.deps.Gorm.Preload("Questions.Options").First(&result, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
 
-    // 3. Delete options for each question
-    if err := tx.Where("question_id IN ?", questionIDs).Delete(&gomodel.Option{}).Error; err != nil {
-        tx.Rollback()
-        return false, err
-    }
+	return FormToGraphQL(&result), nil
+}
 
-    // 4. Delete questions
-    if err := tx.Where("form_id = ?", id).Delete(&gomodel.Question{}).Error; err != nil {
-        tx.Rollback()
-        return false, err
-    }
+// PurgeForm is the resolver for the purgeForm field.
+func (r *mutationResolver) PurgeForm(ctx context.Context, id string) (bool, error) {
+	if _, err := r.authorize(ctx, id, access.ManageForm); err != nil {
+		return false, err
+	}
 
-    // 5. Finally delete the form
-    if err := tx.Delete(&gomodel.Form{}, "id = ?", id).Error; err != nil {
-        tx.Rollback()
-        return false, err
-    }
+	if _, err := r.deps.Forms.FindTrashed(ctx, id); err != nil {
+		return false, err
+	}
 
-    if err := tx.Commit().Error; err != nil {
-        return false, err
-    }
+	if err := r.deps.Forms.Purge(ctx, id); err != nil {
+		return false, err
+	}
 
-    return true, nil
+	return true, nil
+}
+
+// CloseForm is the resolver for the closeForm field.
+func (r *mutationResolver) CloseForm(ctx context.Context, id string) (*gqlmodel.Form, error) {
+	form, err := r.authorizedForm(ctx, id, access.EditForm)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.deps.Forms.Close(ctx, form); err != nil {
+		return nil, err
+	}
+	r.publishFormChange(ctx, pubsub.FormChangeFormClosed, form)
+
+	return FormToGraphQL(form), nil
+}
+
+// ReopenForm is the resolver for the reopenForm field.
+func (r *mutationResolver) ReopenForm(ctx context.Context, id string) (*gqlmodel.Form, error) {
+	form, err := r.authorizedForm(ctx, id, access.EditForm)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.deps.Forms.Reopen(ctx, form); err != nil {
+		return nil, err
+	}
+	r.publishFormChange(ctx, pubsub.FormChangeFormReopened, form)
+
+	return FormToGraphQL(form), nil
+}
+
+// publishFormChange announces a change of the form as a whole made by the current user.
+func (r *Resolver) publishFormChange(ctx context.Context, kind string, form *gomodel.Form) {
+	userID, _ := r.deps.Sessions.GetUserIDFromContext(ctx)
+	r.deps.PubSub.PublishFormChange(ctx, pubsub.FormChange{
+		Kind:    kind,
+		FormID:  form.ID,
+		Version: form.Version,
+		ActorID: userID,
+	})
+}
+
+func versionFromGraphQL(v int32) *int64 {
+	version := int64(v)
+	return &version
 }
 
 func (r *mutationResolver) UpdateQuestion(ctx context.Context, id string, input gqlmodel.QuestionUpdateInput) (*gqlmodel.Question, error) {
@@ -351,8 +561,8 @@ func (r *mutationResolver) UpdateQuestion(ctx context.Context, id string, input
 		return nil, err
 	}
 
-	if form.OwnerID != userID {
-		return nil, errors.New("not authorized to modify this question")
+	if _, err := r.deps.Access.Authorize(ctx, form.ID, userID, access.EditForm); err != nil {
+		return nil, err
 	}
 
 	tx := r.deps.Gorm.Begin()
@@ -362,6 +572,12 @@ func (r *mutationResolver) UpdateQuestion(ctx context.Context, id string, input
 		}
 	}()
 
+	version, err := forms.BumpVersion(tx, form.ID, versionFromGraphQL(input.Version))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	updates := map[string]interface{}{}
 
 	if input.Text != nil {
@@ -376,6 +592,27 @@ func (r *mutationResolver) UpdateQuestion(ctx context.Context, id string, input
 	if input.Order != nil {
 		updates["order"] = *input.Order
 	}
+	if input.Key != nil {
+		key := questionKey(input.Key)
+		if key != nil {
+			if !formschema.ValidQuestionKey(*key) {
+				tx.Rollback()
+				return nil, errors.New("invalid question key: " + *key)
+			}
+			var taken int64
+			if err := tx.Model(&gomodel.Question{}).
+				Where("form_id = ? AND key = ? AND id <> ?", form.ID, *key, id).
+				Count(&taken).Error; err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+			if taken > 0 {
+				tx.Rollback()
+				return nil, errors.New("question key used more than once: " + *key)
+			}
+		}
+		updates["key"] = key
+	}
 
 	if len(updates) > 0 {
 		if err := tx.Model(&question).Updates(updates).Error; err != nil {
@@ -408,6 +645,13 @@ func (r *mutationResolver) UpdateQuestion(ctx context.Context, id string, input
 	if err := tx.Commit().Error; err != nil {
 		return nil, err
 	}
+	r.deps.PubSub.PublishFormChange(ctx, pubsub.FormChange{
+		Kind:       pubsub.FormChangeQuestionUpdated,
+		FormID:     form.ID,
+		Version:    version,
+		ActorID:    userID,
+		QuestionID: question.ID,
+	})
 
 	var result gomodel.Question
 	if err := r.deps.Gorm.Preload("Options").First(&result, "id = ?", id).Error; err != nil {
@@ -417,7 +661,7 @@ func (r *mutationResolver) UpdateQuestion(ctx context.Context, id string, input
 	return questionToGraphQL(&result), nil
 }
 
-func (r *mutationResolver) DeleteQuestion(ctx context.Context, id string) (bool, error) {
+func (r *mutationResolver) DeleteQuestion(ctx context.Context, id string, version int32) (bool, error) {
 	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
 	if err != nil {
 		return false, err
@@ -433,13 +677,27 @@ func (r *mutationResolver) DeleteQuestion(ctx context.Context, id string) (bool,
 		return false, err
 	}
 
-	if form.OwnerID != userID {
-		return false, errors.New("not authorized to delete this question")
+	if _, err := r.deps.Access.Authorize(ctx, form.ID, userID, access.EditForm); err != nil {
+		return false, err
 	}
 
-	if err := r.deps.Gorm.Delete(&gomodel.Question{}, "id = ?", id).Error; err != nil {
+	var newVersion int64
+	err = r.deps.Gorm.Transaction(func(tx *gorm.DB) error {
+		if newVersion, err = forms.BumpVersion(tx, form.ID, versionFromGraphQL(version)); err != nil {
+			return err
+		}
+		return tx.Delete(&gomodel.Question{}, "id = ?", id).Error
+	})
+	if err != nil {
 		return false, err
 	}
+	r.deps.PubSub.PublishFormChange(ctx, pubsub.FormChange{
+		Kind:       pubsub.FormChangeQuestionDeleted,
+		FormID:     form.ID,
+		Version:    newVersion,
+		ActorID:    userID,
+		QuestionID: id,
+	})
 
 	return true, nil
 }
@@ -465,8 +723,8 @@ func (r *mutationResolver) UpdateOption(ctx context.Context, id string, input gq
 		return nil, err
 	}
 
-	if form.OwnerID != userID {
-		return nil, errors.New("not authorized to modify this option")
+	if _, err := r.deps.Access.Authorize(ctx, form.ID, userID, access.EditForm); err != nil {
+		return nil, err
 	}
 
 	updates := map[string]interface{}{}
@@ -479,9 +737,25 @@ func (r *mutationResolver) UpdateOption(ctx context.Context, id string, input gq
 	}
 
 	if len(updates) > 0 {
-		if err := r.deps.Gorm.Model(&option).Updates(updates).Error; err != nil {
+		var version int64
+		err := r.deps.Gorm.Transaction(func(tx *gorm.DB) error {
+			var err error
+			if version, err = forms.BumpVersion(tx, form.ID, versionFromGraphQL(input.Version)); err != nil {
+				return err
+			}
+			return tx.Model(&option).Updates(updates).Error
+		})
+		if err != nil {
 			return nil, err
 		}
+		r.deps.PubSub.PublishFormChange(ctx, pubsub.FormChange{
+			Kind:       pubsub.FormChangeOptionUpdated,
+			FormID:     form.ID,
+			Version:    version,
+			ActorID:    userID,
+			QuestionID: question.ID,
+			OptionID:   option.ID,
+		})
 	}
 
 	if err := r.deps.Gorm.First(&option, "id = ?", id).Error; err != nil {
@@ -491,7 +765,7 @@ func (r *mutationResolver) UpdateOption(ctx context.Context, id string, input gq
 	return optionToGraphQL(&option), nil
 }
 
-func (r *mutationResolver) DeleteOption(ctx context.Context, id string) (bool, error) {
+func (r *mutationResolver) DeleteOption(ctx context.Context, id string, version int32) (bool, error) {
 	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
 	if err != nil {
 		return false, err
@@ -512,13 +786,28 @@ func (r *mutationResolver) DeleteOption(ctx context.Context, id string) (bool, e
 		return false, err
 	}
 
-	if form.OwnerID != userID {
-		return false, errors.New("not authorized to delete this option")
+	if _, err := r.deps.Access.Authorize(ctx, form.ID, userID, access.EditForm); err != nil {
+		return false, err
 	}
 
-	if err := r.deps.Gorm.Delete(&gomodel.Option{}, "id = ?", id).Error; err != nil {
+	var newVersion int64
+	err = r.deps.Gorm.Transaction(func(tx *gorm.DB) error {
+		if newVersion, err = forms.BumpVersion(tx, form.ID, versionFromGraphQL(version)); err != nil {
+			return err
+		}
+		return tx.Delete(&gomodel.Option{}, "id = ?", id).Error
+	})
+	if err != nil {
 		return false, err
 	}
+	r.deps.PubSub.PublishFormChange(ctx, pubsub.FormChange{
+		Kind:       pubsub.FormChangeOptionDeleted,
+		FormID:     form.ID,
+		Version:    newVersion,
+		ActorID:    userID,
+		QuestionID: question.ID,
+		OptionID:   id,
+	})
 
 	return true, nil
 }
@@ -527,6 +816,9 @@ func (r *queryResolver) Form(ctx context.Context, id string) (*gqlmodel.Form, er
     if _, err := uuid.Parse(id); err != nil {
         return nil, errors.New("invalid form ID format")
     }
+    if err := r.requireScope(ctx, gomodel.ApiKeyScopeFormsRead); err != nil {
+        return nil, err
+    }
 
     var form gomodel.Form
     if err := r.deps.Gorm.Preload("Questions.Options").First(&form, "id = ?", id).Error; err != nil {
@@ -536,19 +828,54 @@ func (r *queryResolver) Form(ctx context.Context, id string) (*gqlmodel.Form, er
         return nil, err
     }
 
-    if form.Access == gomodel.FormAccessPrivate {
-        userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
-
-        if err != nil || userID != form.OwnerID {
-            return nil, errors.New("access denied")
-        }
+    if !r.canViewForm(ctx, &form) {
+        return nil, errors.New("access denied")
     }
 
     return FormToGraphQL(&form), nil
 }
 
-func (r *queryResolver) Forms(ctx context.Context, ownerID *string, access *gqlmodel.FormAccess) ([]*gqlmodel.Form, error) {
-	query := r.deps.Gorm.Model(&gomodel.Form{}).Preload("Questions.Options")
+// TrashedForms is the resolver for the trashedForms field.
+func (r *queryResolver) TrashedForms(ctx context.Context, workspaceID *string) ([]*gqlmodel.Form, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if workspaceID != nil {
+		if _, err := r.authorizedWorkspace(ctx, *workspaceID, true); err != nil {
+			return nil, err
+		}
+
+		forms, err := r.deps.Forms.TrashedInWorkspace(ctx, *workspaceID)
+		if err != nil {
+			return nil, err
+		}
+		return FormsToGraphQL(forms), nil
+	}
+
+	forms, err := r.deps.Forms.Trashed(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return FormsToGraphQL(forms), nil
+}
+
+func (r *queryResolver) Forms(ctx context.Context, ownerID *string, access *gqlmodel.FormAccess, workspaceID *string, folderID *string, tag *string, starred *bool, sort *gqlmodel.FormSort, limit *int32, offset *int32) ([]*gqlmodel.Form, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	query := r.deps.Access.ViewableForms(r.deps.Gorm.Model(&gomodel.Form{}).Preload("Questions.Options"), userID)
+
+	if workspaceID != nil {
+		if _, err := r.authorizedWorkspace(ctx, *workspaceID, false); err != nil {
+			return nil, err
+		}
+		query = query.Where(`"workspaceId" = ?`, *workspaceID)
+	}
 
 	if ownerID != nil {
 		query = query.Where("owner_id = ?", *ownerID)
@@ -558,6 +885,41 @@ func (r *queryResolver) Forms(ctx context.Context, ownerID *string, access *gqlm
 		query = query.Where("access = ?", string(*access))
 	}
 
+	if folderID != nil {
+		if _, err := r.authorizedFolder(ctx, *folderID); err != nil {
+			return nil, err
+		}
+		query = query.Where(`"folderId" = ?`, *folderID)
+	}
+
+	if tag != nil {
+		query = query.Where("tags @> ?", pq.StringArray{*tag})
+	}
+
+	// Forms pinned by the current user come first
+	query = query.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:  "EXISTS (SELECT 1 FROM form_stars WHERE form_stars.form_id = forms.id AND form_stars.user_id = ?) DESC",
+		Vars: []interface{}{userID},
+	}})
+	if starred != nil && *starred {
+		query = query.Where("id IN (?)", r.deps.Gorm.Model(&gomodel.FormStar{}).Select("form_id").Where("user_id = ?", userID))
+	}
+
+	switch {
+	case sort != nil && *sort == gqlmodel.FormSortTitle:
+		query = query.Order("title")
+	case sort != nil && *sort == gqlmodel.FormSortLastResponse:
+		query = query.Order("(SELECT MAX(created_at) FROM form_responses WHERE form_responses.form_id = forms.id AND form_responses.deleted_at IS NULL) DESC NULLS LAST")
+	default:
+		query = query.Order(`"updatedAt" DESC`)
+	}
+
+	// A stable order keeps pages from overlapping
+	query, err = paginate(query.Order("id"), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
 	var forms []gomodel.Form
 	if err := query.Find(&forms).Error; err != nil {
 		return nil, err
@@ -566,6 +928,42 @@ func (r *queryResolver) Forms(ctx context.Context, ownerID *string, access *gqlm
 	return FormsToGraphQL(forms), nil
 }
 
+// FormUpdated is the resolver for the formUpdated field.
+func (r *subscriptionResolver) FormUpdated(ctx context.Context, id string) (<-chan *gqlmodel.Form, error) {
+	if _, err := r.authorizedForm(ctx, id, access.EditForm); err != nil {
+		return nil, err
+	}
+
+	updates, err := r.deps.PubSub.FormUpdated(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *gqlmodel.Form)
+	go func() {
+		defer close(out)
+		for range updates {
+			var form gomodel.Form
+			if err := r.deps.Gorm.Preload("Questions.Options").First(&form, "id = ?", id).Error; err != nil {
+				// The form went to the trash, nothing more to follow
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return
+				}
+				log.Printf("Error loading updated form %s: %v", id, err)
+				continue
+			}
+
+			select {
+			case out <- FormToGraphQL(&form):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
 // !!! WARNING !!!
 // The code below was going to be deleted when updating resolvers. It has been copied here so you have
 // one last chance to move it out of harms way if you want. There are two reasons this happens:
@@ -0,0 +1,180 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.70
+
+import (
+	"context"
+	"errors"
+
+	gqlmodel "github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph/model"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/formdoc"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/forms"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/pubsub"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// ImportForm is the resolver for the importForm field.
+func (r *mutationResolver) ImportForm(ctx context.Context, document string, format *gqlmodel.FormDocumentFormat, workspaceID *string) (*gqlmodel.FormImport, error) {
+	user, err := r.deps.Sessions.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	var docFormat formdoc.Format
+	if format != nil {
+		docFormat = formdoc.Format(*format)
+	}
+	doc, err := formdoc.Parse([]byte(document), docFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	params := forms.CopyParams{OwnerID: user.ID, ExternalKey: doc.Form().ExternalKey}
+	if params.WorkspaceID, err = r.targetWorkspace(ctx, user, workspaceID); err != nil {
+		return nil, err
+	}
+	if doc.Key != "" {
+		if _, err := r.deps.Forms.FindByKey(ctx, user.ID, params.WorkspaceID, doc.Key); err == nil {
+			return nil, forms.ErrKeyTaken
+		} else if !errors.Is(err, forms.ErrFormNotFound) {
+			return nil, err
+		}
+	}
+
+	form, err := r.deps.Forms.Duplicate(ctx, doc.Form(), params)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, options := doc.IDMap(form)
+	return &gqlmodel.FormImport{
+		Form:        FormToGraphQL(form),
+		QuestionIds: importedIDsToGraphQL(questions),
+		OptionIds:   importedIDsToGraphQL(options),
+	}, nil
+}
+
+// ApplyForm is the resolver for the applyForm field.
+func (r *mutationResolver) ApplyForm(ctx context.Context, document string, format *gqlmodel.FormDocumentFormat, workspaceID *string) (*gqlmodel.FormApply, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	var docFormat formdoc.Format
+	if format != nil {
+		docFormat = formdoc.Format(*format)
+	}
+	doc, err := formdoc.Parse([]byte(document), docFormat)
+	if err != nil {
+		return nil, err
+	}
+	if doc.Key == "" {
+		return nil, &formdoc.ValidationError{Issues: []formdoc.Issue{{Path: "key", Message: "is required to apply a document"}}}
+	}
+
+	form, err := r.formByKey(ctx, userID, workspaceID, doc.Key)
+	if errors.Is(err, forms.ErrFormNotFound) {
+		definition := doc.Form()
+		created, err := r.deps.Forms.Duplicate(ctx, definition, forms.CopyParams{
+			OwnerID:     userID,
+			WorkspaceID: workspaceID,
+			ExternalKey: definition.ExternalKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &gqlmodel.FormApply{Form: FormToGraphQL(created), Action: gqlmodel.FormApplyActionCreated}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.authorize(ctx, form.ID, access.EditForm); err != nil {
+		return nil, err
+	}
+
+	current := formdoc.Export(form)
+	if formdoc.Equal(current, doc) {
+		return &gqlmodel.FormApply{Form: FormToGraphQL(form), Action: gqlmodel.FormApplyActionUnchanged}, nil
+	}
+
+	sameQuestions := formdoc.SameQuestions(current, doc)
+	version, err := r.deps.Forms.ReplaceDefinition(ctx, form, doc.Form(), !sameQuestions)
+	if err != nil {
+		return nil, err
+	}
+
+	change := pubsub.FormChange{Kind: pubsub.FormChangeFormUpdated, FormID: form.ID, Version: version, ActorID: userID}
+	if !sameQuestions {
+		change.Kind = pubsub.FormChangeQuestionsReplaced
+	}
+	r.deps.PubSub.PublishFormChange(ctx, change)
+
+	var result gomodel.Form
+	if err := r.deps.Gorm.Preload("Questions.Options").First(&result, "id = ?", form.ID).Error; err != nil {
+		return nil, err
+	}
+	return &gqlmodel.FormApply{Form: FormToGraphQL(&result), Action: gqlmodel.FormApplyActionUpdated}, nil
+}
+
+// ExportForm is the resolver for the exportForm field.
+func (r *queryResolver) ExportForm(ctx context.Context, id string, format *gqlmodel.FormDocumentFormat) (string, error) {
+	form, err := r.authorizedForm(ctx, id, access.ViewForm)
+	if err != nil {
+		return "", err
+	}
+
+	docFormat := formdoc.FormatYAML
+	if format != nil {
+		docFormat = formdoc.Format(*format)
+	}
+	data, err := formdoc.Marshal(formdoc.Export(form), docFormat)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// FormByKey is the resolver for the formByKey field.
+func (r *queryResolver) FormByKey(ctx context.Context, key string, workspaceID *string) (*gqlmodel.Form, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	form, err := r.formByKey(ctx, userID, workspaceID, key)
+	if errors.Is(err, forms.ErrFormNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.authorize(ctx, form.ID, access.ViewForm); err != nil {
+		return nil, err
+	}
+	return FormToGraphQL(form), nil
+}
+
+// formByKey finds a form by its external key in the workspace, which the user must be
+// a member of, or among the personal forms of the user when workspaceID is nil.
+func (r *Resolver) formByKey(ctx context.Context, userID string, workspaceID *string, key string) (*gomodel.Form, error) {
+	if workspaceID != nil {
+		if _, err := r.deps.Access.AuthorizeWorkspace(ctx, *workspaceID, userID, false); err != nil {
+			return nil, err
+		}
+	}
+	return r.deps.Forms.FindByKey(ctx, userID, workspaceID, key)
+}
+
+func importedIDsToGraphQL(mappings []formdoc.Mapping) []*gqlmodel.ImportedID {
+	result := make([]*gqlmodel.ImportedID, len(mappings))
+	for i, m := range mappings {
+		result[i] = &gqlmodel.ImportedID{SourceID: m.SourceID, ID: m.ID}
+	}
+	return result
+}
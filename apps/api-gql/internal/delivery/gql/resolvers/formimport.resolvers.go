@@ -0,0 +1,133 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.70
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	gqlmodel "github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph/model"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/csvimport"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/formimport"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/forms"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// ImportExternalForm is the resolver for the importExternalForm field.
+func (r *mutationResolver) ImportExternalForm(ctx context.Context, source gqlmodel.ExternalFormSource, definition string, responsesCsv *string, workspaceID *string) (*gqlmodel.ExternalFormImport, error) {
+	user, err := r.deps.Sessions.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	converted, err := formimport.Convert(formimport.Source(source), []byte(definition))
+	if err != nil {
+		return nil, err
+	}
+
+	// The CSV is read before the form is created, so a broken file creates nothing
+	var file *csvimport.File
+	if responsesCsv != nil {
+		if err := r.requireScope(ctx, gomodel.ApiKeyScopeResponsesWrite); err != nil {
+			return nil, err
+		}
+		if file, err = csvimport.Read([]byte(*responsesCsv)); err != nil {
+			return nil, err
+		}
+	}
+
+	params := forms.CopyParams{OwnerID: user.ID}
+	if params.WorkspaceID, err = r.targetWorkspace(ctx, user, workspaceID); err != nil {
+		return nil, err
+	}
+
+	form, err := r.deps.Forms.Duplicate(ctx, converted.Form, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &gqlmodel.ExternalFormImport{
+		Form:  FormToGraphQL(form),
+		Notes: make([]*gqlmodel.ImportNote, len(converted.Notes)),
+	}
+	for i, n := range converted.Notes {
+		result.Notes[i] = &gqlmodel.ImportNote{
+			Title:   n.Title,
+			Type:    n.Type,
+			Skipped: n.Skipped,
+			Message: n.Message,
+		}
+	}
+
+	if file != nil {
+		report, err := r.deps.CSVImport.Import(ctx, form, file, csvimport.Params{})
+		if err != nil {
+			// Nothing of a failed import is kept, the form included, even when the request was cancelled
+			if purgeErr := r.deps.Forms.Purge(context.WithoutCancel(ctx), form.ID); purgeErr != nil {
+				log.Printf("Error removing form %s after a failed import: %v", form.ID, purgeErr)
+			}
+			return nil, err
+		}
+		result.Responses = responseImportReportToGraphQL(report)
+	}
+	return result, nil
+}
+
+// ImportResponses is the resolver for the importResponses field.
+func (r *mutationResolver) ImportResponses(ctx context.Context, formID string, csv string, columns []*gqlmodel.ResponseColumnInput, timestampColumn *string, dryRun *bool) (*gqlmodel.ResponseImportReport, error) {
+	form, err := r.authorizedForm(ctx, formID, access.ManageForm)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := csvimport.Read([]byte(csv))
+	if err != nil {
+		return nil, err
+	}
+
+	var params csvimport.Params
+	if columns != nil {
+		params.Columns = make([]csvimport.Column, len(columns))
+		for i, c := range columns {
+			params.Columns[i] = csvimport.Column{Header: c.Column, QuestionID: c.QuestionID}
+		}
+	}
+	if timestampColumn != nil {
+		params.TimestampColumn = *timestampColumn
+	}
+	if dryRun != nil {
+		params.DryRun = *dryRun
+	}
+
+	report, err := r.deps.CSVImport.Import(ctx, form, file, params)
+	if err != nil {
+		return nil, err
+	}
+	return responseImportReportToGraphQL(report), nil
+}
+
+func responseImportReportToGraphQL(report *csvimport.Report) *gqlmodel.ResponseImportReport {
+	result := &gqlmodel.ResponseImportReport{
+		Imported:         int32(report.Imported),
+		Errors:           make([]*gqlmodel.ResponseImportError, len(report.Errors)),
+		UnmatchedColumns: report.UnmatchedColumns,
+	}
+	if result.UnmatchedColumns == nil {
+		result.UnmatchedColumns = []string{}
+	}
+	for i, e := range report.Errors {
+		result.Errors[i] = &gqlmodel.ResponseImportError{
+			Row:     int32(e.Row),
+			Message: e.Message,
+		}
+		if e.Column != "" {
+			column := e.Column
+			result.Errors[i].Column = &column
+		}
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.70
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	gqlmodel "github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph/model"
+	"github.com/TrySquadDF/formify/lib/oauth"
+)
+
+// LinkIdentity is the resolver for the linkIdentity field.
+func (r *mutationResolver) LinkIdentity(ctx context.Context, provider string) (string, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return "", errors.New("authorization required")
+	}
+
+	p, err := r.deps.Providers.Get(provider)
+	if err != nil {
+		return "", err
+	}
+
+	attempt, err := oauth.NewAttempt(provider)
+	if err != nil {
+		return "", err
+	}
+	attempt.LinkUserID = userID
+
+	url, err := p.AuthCodeURL(ctx, attempt)
+	if err != nil {
+		return "", errors.New("login provider is unavailable")
+	}
+
+	r.deps.Sessions.Put(ctx, oauth.AttemptSessionKey, attempt)
+	return url, nil
+}
+
+// UnlinkIdentity is the resolver for the unlinkIdentity field.
+func (r *mutationResolver) UnlinkIdentity(ctx context.Context, id string) (bool, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, errors.New("authorization required")
+	}
+
+	if err := r.deps.Identities.Unlink(ctx, userID, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// LoginProviders is the resolver for the loginProviders field.
+func (r *queryResolver) LoginProviders(ctx context.Context) ([]string, error) {
+	return r.deps.Providers.Names(), nil
+}
+
+// Identities is the resolver for the identities field.
+func (r *queryResolver) Identities(ctx context.Context) ([]*gqlmodel.Identity, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	identities, err := r.deps.Identities.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*gqlmodel.Identity, len(identities))
+	for i, identity := range identities {
+		result[i] = &gqlmodel.Identity{
+			ID:        identity.ID,
+			Provider:  identity.Provider,
+			Email:     identity.Email,
+			CreatedAt: identity.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,285 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.70
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph"
+	gqlmodel "github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph/model"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// MyRole is the resolver for the myRole field.
+func (r *formResolver) MyRole(ctx context.Context, obj *gqlmodel.Form) (*gqlmodel.FormRole, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, nil
+	}
+
+	role, err := r.deps.Access.Role(ctx, obj.ID, userID)
+	if err != nil || role == "" {
+		return nil, err
+	}
+
+	result := gqlmodel.FormRole(role)
+	return &result, nil
+}
+
+// InviteFormMember is the resolver for the inviteFormMember field.
+func (r *mutationResolver) InviteFormMember(ctx context.Context, formID string, email string, role gqlmodel.FormRole) (*gqlmodel.FormInvitation, error) {
+	form, err := r.authorizedForm(ctx, formID, access.ManageForm)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	invitation, err := r.deps.Members.Invite(ctx, form, userID, email, gomodel.FormRole(role))
+	if err != nil {
+		return nil, err
+	}
+
+	return formInvitationToGraphQL(invitation), nil
+}
+
+// RevokeFormInvitation is the resolver for the revokeFormInvitation field.
+func (r *mutationResolver) RevokeFormInvitation(ctx context.Context, id string) (bool, error) {
+	invitation, err := r.deps.Members.FindInvitation(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := r.authorize(ctx, invitation.FormID, access.ManageForm); err != nil {
+		return false, err
+	}
+
+	if err := r.deps.Members.RevokeInvitation(ctx, invitation); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AcceptFormInvitation is the resolver for the acceptFormInvitation field.
+func (r *mutationResolver) AcceptFormInvitation(ctx context.Context, id string, token string) (*gqlmodel.FormMember, error) {
+	user, err := r.deps.Sessions.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	member, err := r.deps.Members.Accept(ctx, id, token, user)
+	if err != nil {
+		return nil, err
+	}
+
+	member.User = user
+	return formMemberToGraphQL(member), nil
+}
+
+// DeclineFormInvitation is the resolver for the declineFormInvitation field.
+func (r *mutationResolver) DeclineFormInvitation(ctx context.Context, id string) (bool, error) {
+	user, err := r.deps.Sessions.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return false, errors.New("authorization required")
+	}
+
+	if err := r.deps.Members.Decline(ctx, id, user); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ChangeFormMemberRole is the resolver for the changeFormMemberRole field.
+func (r *mutationResolver) ChangeFormMemberRole(ctx context.Context, formID string, userID string, role gqlmodel.FormRole) (*gqlmodel.FormMember, error) {
+	if _, err := r.authorize(ctx, formID, access.ManageForm); err != nil {
+		return nil, err
+	}
+
+	member, err := r.deps.Members.ChangeRole(ctx, formID, userID, gomodel.FormRole(role))
+	if err != nil {
+		return nil, err
+	}
+	return formMemberToGraphQL(member), nil
+}
+
+// RemoveFormMember is the resolver for the removeFormMember field.
+func (r *mutationResolver) RemoveFormMember(ctx context.Context, formID string, userID string) (bool, error) {
+	currentUserID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, errors.New("authorization required")
+	}
+
+	// Members may leave on their own
+	if userID != currentUserID {
+		if _, err := r.authorize(ctx, formID, access.ManageForm); err != nil {
+			return false, err
+		}
+	}
+
+	if err := r.deps.Members.Remove(ctx, formID, userID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// TransferFormOwnership is the resolver for the transferFormOwnership field.
+func (r *mutationResolver) TransferFormOwnership(ctx context.Context, formID string, userID string) (*gqlmodel.Form, error) {
+	if _, err := r.authorize(ctx, formID, access.ManageForm); err != nil {
+		return nil, err
+	}
+
+	if err := r.deps.Members.TransferOwnership(ctx, formID, userID); err != nil {
+		return nil, err
+	}
+
+	var form gomodel.Form
+	if err := r.deps.Gorm.Preload("Questions.Options").First(&form, "id = ?", formID).Error; err != nil {
+		return nil, err
+	}
+	return FormToGraphQL(&form), nil
+}
+
+// FormMembers is the resolver for the formMembers field.
+func (r *queryResolver) FormMembers(ctx context.Context, formID string) ([]*gqlmodel.FormMember, error) {
+	if _, err := r.authorize(ctx, formID, access.ViewForm); err != nil {
+		return nil, err
+	}
+
+	var form gomodel.Form
+	if err := r.deps.Gorm.Unscoped().First(&form, "id = ?", formID).Error; err != nil {
+		return nil, err
+	}
+
+	var owner gomodel.Users
+	if err := r.deps.Gorm.First(&owner, "id = ?", form.OwnerID).Error; err != nil {
+		return nil, err
+	}
+
+	members, err := r.deps.Members.Members(ctx, formID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*gqlmodel.FormMember, 0, len(members)+1)
+	result = append(result, formMemberToGraphQL(&gomodel.FormMember{
+		FormID:    form.ID,
+		UserID:    owner.ID,
+		Role:      gomodel.FormRoleOwner,
+		CreatedAt: form.CreatedAt,
+		User:      &owner,
+	}))
+	for i := range members {
+		result = append(result, formMemberToGraphQL(&members[i]))
+	}
+	return result, nil
+}
+
+// FormInvitations is the resolver for the formInvitations field.
+func (r *queryResolver) FormInvitations(ctx context.Context, formID string) ([]*gqlmodel.FormInvitation, error) {
+	if _, err := r.authorize(ctx, formID, access.ManageForm); err != nil {
+		return nil, err
+	}
+
+	invitations, err := r.deps.Members.Invitations(ctx, formID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*gqlmodel.FormInvitation, len(invitations))
+	for i := range invitations {
+		result[i] = formInvitationToGraphQL(&invitations[i])
+	}
+	return result, nil
+}
+
+// MyInvitations is the resolver for the myInvitations field.
+func (r *queryResolver) MyInvitations(ctx context.Context) ([]*gqlmodel.FormInvitation, error) {
+	user, err := r.deps.Sessions.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	invitations, err := r.deps.Members.InvitationsFor(ctx, user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*gqlmodel.FormInvitation, len(invitations))
+	for i := range invitations {
+		result[i] = formInvitationToGraphQL(&invitations[i])
+	}
+	return result, nil
+}
+
+// SharedForms is the resolver for the sharedForms field.
+func (r *queryResolver) SharedForms(ctx context.Context) ([]*gqlmodel.Form, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	var forms []gomodel.Form
+	if err := r.deps.Gorm.Preload("Questions.Options").
+		Where("id IN (?)", r.deps.Gorm.Model(&gomodel.FormMember{}).Select("form_id").Where("user_id = ?", userID)).
+		Order(`"updatedAt" DESC`).
+		Find(&forms).Error; err != nil {
+		return nil, err
+	}
+
+	return FormsToGraphQL(forms), nil
+}
+
+// Form returns graph.FormResolver implementation.
+func (r *Resolver) Form() graph.FormResolver { return &formResolver{r} }
+
+type formResolver struct{ *Resolver }
+
+func formMemberToGraphQL(m *gomodel.FormMember) *gqlmodel.FormMember {
+	return &gqlmodel.FormMember{
+		User:      memberUserToGraphQL(m.User),
+		Role:      gqlmodel.FormRole(m.Role),
+		CreatedAt: m.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func formInvitationToGraphQL(i *gomodel.FormInvitation) *gqlmodel.FormInvitation {
+	result := &gqlmodel.FormInvitation{
+		ID:        i.ID,
+		FormID:    i.FormID,
+		Email:     i.Email,
+		Role:      gqlmodel.FormRole(i.Role),
+		Status:    gqlmodel.FormInvitationStatus(i.Status),
+		CreatedAt: i.CreatedAt.Format(time.RFC3339),
+		ExpiresAt: i.ExpiresAt.Format(time.RFC3339),
+	}
+	if i.Form != nil {
+		result.FormTitle = &i.Form.Title
+	}
+	if i.InvitedBy != nil {
+		result.InvitedBy = memberUserToGraphQL(i.InvitedBy)
+	}
+	return result
+}
+
+// memberUserToGraphQL exposes another user's profile without their forms.
+func memberUserToGraphQL(u *gomodel.Users) *gqlmodel.User {
+	if u == nil {
+		return nil
+	}
+	return &gqlmodel.User{
+		ID:          u.ID,
+		Email:       u.Email,
+		DisplayName: u.DisplayName,
+		Picture:     u.Picture,
+		IsBanned:    u.IsBanned,
+		Forms:       []*gqlmodel.Form{},
+	}
+}
@@ -2,6 +2,21 @@ package resolvers
 
 import (
 	"github.com/TrySquadDF/formify/api-gql/internal/auth"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/analytics"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/apikeys"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/csvimport"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/folders"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/forms"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/identities"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/members"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/presence"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/pubsub"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/responses"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/review"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/webhooks"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/workspaces"
+	"github.com/TrySquadDF/formify/lib/oauth"
 	"go.uber.org/fx"
 	"gorm.io/gorm"
 )
@@ -14,7 +29,22 @@ type Deps struct {
 	fx.In
 
 	Sessions             *auth.Auth
+	ApiKeys              *apikeys.Service
+	Identities           *identities.Service
+	Providers            *oauth.Registry
 	Gorm                 *gorm.DB
+	Analytics            *analytics.Service
+	Responses            *responses.Service
+	Forms                *forms.Service
+	Review               *review.Service
+	Webhooks             *webhooks.Service
+	PubSub               *pubsub.Service
+	Presence             *presence.Service
+	Access               *access.Service
+	Members              *members.Service
+	Workspaces           *workspaces.Service
+	Folders              *folders.Service
+	CSVImport            *csvimport.Service
 }
 
 type Resolver struct {
@@ -0,0 +1,194 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.70
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	gqlmodel "github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph/model"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"gorm.io/gorm"
+)
+
+// Notes is the resolver for the notes field.
+func (r *formResponseResolver) Notes(ctx context.Context, obj *gqlmodel.FormResponse) ([]*gqlmodel.ResponseNote, error) {
+	if _, err := r.authorize(ctx, obj.FormID, access.ViewResponses); err != nil {
+		return nil, err
+	}
+
+	notes, err := r.deps.Review.Notes(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*gqlmodel.ResponseNote, len(notes))
+	for i := range notes {
+		result[i] = responseNoteToGraphQL(&notes[i])
+	}
+	return result, nil
+}
+
+// Activity is the resolver for the activity field.
+func (r *formResponseResolver) Activity(ctx context.Context, obj *gqlmodel.FormResponse) ([]*gqlmodel.ResponseActivity, error) {
+	if _, err := r.authorize(ctx, obj.FormID, access.ViewResponses); err != nil {
+		return nil, err
+	}
+
+	activity, err := r.deps.Review.Activity(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*gqlmodel.ResponseActivity, len(activity))
+	for i, a := range activity {
+		result[i] = &gqlmodel.ResponseActivity{
+			ID:         a.ID,
+			ResponseID: a.ResponseID,
+			ActorID:    a.ActorID,
+			ActorName:  a.Actor.DisplayName,
+			Action:     gqlmodel.ResponseActivityAction(a.Action),
+			OldValue:   a.OldValue,
+			NewValue:   a.NewValue,
+			CreatedAt:  a.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return result, nil
+}
+
+// SetResponseStatus is the resolver for the setResponseStatus field.
+func (r *mutationResolver) SetResponseStatus(ctx context.Context, id string, status string) (*gqlmodel.FormResponse, error) {
+	response, form, userID, err := r.reviewableResponse(ctx, id, access.EditForm)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.deps.Review.SetStatus(ctx, userID, form, response, status); err != nil {
+		return nil, err
+	}
+
+	return r.loadFormResponse(id)
+}
+
+// AssignResponse is the resolver for the assignResponse field.
+func (r *mutationResolver) AssignResponse(ctx context.Context, id string, assigneeID *string) (*gqlmodel.FormResponse, error) {
+	response, _, userID, err := r.reviewableResponse(ctx, id, access.EditForm)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.deps.Review.Assign(ctx, userID, response, assigneeID); err != nil {
+		return nil, err
+	}
+
+	return r.loadFormResponse(id)
+}
+
+// SetResponseTags is the resolver for the setResponseTags field.
+func (r *mutationResolver) SetResponseTags(ctx context.Context, id string, tags []string) (*gqlmodel.FormResponse, error) {
+	response, _, userID, err := r.reviewableResponse(ctx, id, access.EditForm)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.deps.Review.SetTags(ctx, userID, response, tags); err != nil {
+		return nil, err
+	}
+
+	return r.loadFormResponse(id)
+}
+
+// AddResponseNote is the resolver for the addResponseNote field.
+func (r *mutationResolver) AddResponseNote(ctx context.Context, responseID string, body string, parentID *string) (*gqlmodel.ResponseNote, error) {
+	response, _, userID, err := r.reviewableResponse(ctx, responseID, access.EditForm)
+	if err != nil {
+		return nil, err
+	}
+
+	note, err := r.deps.Review.AddNote(ctx, userID, response, parentID, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return responseNoteToGraphQL(note), nil
+}
+
+// DeleteResponseNote is the resolver for the deleteResponseNote field.
+func (r *mutationResolver) DeleteResponseNote(ctx context.Context, id string) (bool, error) {
+	note, err := r.deps.Review.FindNote(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	_, _, userID, err := r.reviewableResponse(ctx, note.ResponseID, access.EditForm)
+	if err != nil {
+		return false, err
+	}
+
+	if note.AuthorID != userID {
+		return false, errors.New("only the author can delete a note")
+	}
+
+	if err := r.deps.Review.DeleteNote(ctx, userID, note); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// reviewableResponse loads a response together with its form and checks that
+// the current user holds the permission on the form.
+func (r *Resolver) reviewableResponse(ctx context.Context, id string, permission access.Permission) (*gomodel.FormResponse, *gomodel.Form, string, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, nil, "", errors.New("authorization required")
+	}
+
+	var response gomodel.FormResponse
+	if err := r.deps.Gorm.First(&response, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, "", errors.New("response not found")
+		}
+		return nil, nil, "", err
+	}
+
+	var form gomodel.Form
+	if err := r.deps.Gorm.First(&form, "id = ?", response.FormID).Error; err != nil {
+		return nil, nil, "", err
+	}
+
+	if _, err := r.deps.Access.Authorize(ctx, form.ID, userID, permission); err != nil {
+		return nil, nil, "", err
+	}
+
+	return &response, &form, userID, nil
+}
+
+func (r *Resolver) loadFormResponse(id string) (*gqlmodel.FormResponse, error) {
+	var response gomodel.FormResponse
+	if err := r.deps.Gorm.
+		Preload("Answers").
+		Preload("Answers.Question").
+		Preload("Answers.SelectedOptions").
+		First(&response, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return FormResponseToGraphQL(&response), nil
+}
+
+func responseNoteToGraphQL(n *gomodel.ResponseNote) *gqlmodel.ResponseNote {
+	return &gqlmodel.ResponseNote{
+		ID:         n.ID,
+		ResponseID: n.ResponseID,
+		ParentID:   n.ParentID,
+		AuthorID:   n.AuthorID,
+		AuthorName: n.Author.DisplayName,
+		Body:       n.Body,
+		CreatedAt:  n.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,58 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+)
+
+// A list of forms resolves starred for every form; the stars of the user are loaded
+// once per operation instead of once per form.
+
+type starsKey struct{}
+
+// stars caches the IDs of the forms the current user starred for one operation.
+type stars struct {
+	mu      sync.Mutex
+	loaded  bool
+	starred map[string]bool
+}
+
+// WithStars gives the operation its own star cache.
+func WithStars(ctx context.Context) context.Context {
+	return context.WithValue(ctx, starsKey{}, &stars{})
+}
+
+// isStarred reports whether the user pinned the form, loading the stars of the user on first use.
+func (r *Resolver) isStarred(ctx context.Context, userID, formID string) (bool, error) {
+	cache, ok := ctx.Value(starsKey{}).(*stars)
+	if !ok {
+		cache = &stars{}
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if !cache.loaded {
+		starred, err := r.deps.Forms.StarredIDs(ctx, userID)
+		if err != nil {
+			return false, err
+		}
+		cache.starred, cache.loaded = starred, true
+	}
+	return cache.starred[formID], nil
+}
+
+// setStarred keeps the cache in step with a star or unstar made in the same operation.
+func setStarred(ctx context.Context, formID string, starred bool) {
+	cache, ok := ctx.Value(starsKey{}).(*stars)
+	if !ok {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.loaded {
+		cache.starred[formID] = starred
+	}
+}
@@ -0,0 +1,138 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.70
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	gqlmodel "github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph/model"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/forms"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// builtinTemplatePrefix marks the IDs of the templates shipped with the server
+const builtinTemplatePrefix = "builtin:"
+
+// DuplicateForm is the resolver for the duplicateForm field.
+func (r *mutationResolver) DuplicateForm(ctx context.Context, id string, title *string) (*gqlmodel.Form, error) {
+	source, err := r.authorizedForm(ctx, id, access.EditForm)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	// The copy stays next to the source when the user may create forms there
+	params := forms.CopyParams{OwnerID: userID}
+	if source.WorkspaceID != nil {
+		if _, err := r.deps.Access.AuthorizeWorkspace(ctx, *source.WorkspaceID, userID, false); err == nil {
+			params.WorkspaceID = source.WorkspaceID
+			params.FolderID = source.FolderID
+		}
+	} else if source.OwnerID == userID {
+		params.FolderID = source.FolderID
+	}
+	if title != nil {
+		params.Title = strings.TrimSpace(*title)
+	}
+
+	form, err := r.deps.Forms.Duplicate(ctx, source, params)
+	if err != nil {
+		return nil, err
+	}
+	return FormToGraphQL(form), nil
+}
+
+// SetFormTemplate is the resolver for the setFormTemplate field.
+func (r *mutationResolver) SetFormTemplate(ctx context.Context, id string, isTemplate bool) (*gqlmodel.Form, error) {
+	form, err := r.authorizedForm(ctx, id, access.ManageForm)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.deps.Forms.SetTemplate(ctx, form, isTemplate); err != nil {
+		return nil, err
+	}
+	return FormToGraphQL(form), nil
+}
+
+// CreateFormFromTemplate is the resolver for the createFormFromTemplate field.
+func (r *mutationResolver) CreateFormFromTemplate(ctx context.Context, templateID string, title *string, workspaceID *string) (*gqlmodel.Form, error) {
+	user, err := r.deps.Sessions.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	params := forms.CopyParams{OwnerID: user.ID}
+	if params.WorkspaceID, err = r.targetWorkspace(ctx, user, workspaceID); err != nil {
+		return nil, err
+	}
+	if title != nil {
+		params.Title = strings.TrimSpace(*title)
+	}
+
+	var form *gomodel.Form
+	if key, ok := strings.CutPrefix(templateID, builtinTemplatePrefix); ok {
+		template, found := forms.FindBuiltinTemplate(key)
+		if !found {
+			return nil, errors.New("template not found")
+		}
+		form, err = r.deps.Forms.CreateFromTemplate(ctx, template, params)
+	} else {
+		var source *gomodel.Form
+		source, err = r.authorizedForm(ctx, templateID, access.ViewForm)
+		if err != nil {
+			return nil, err
+		}
+		if !source.IsTemplate {
+			return nil, errors.New("template not found")
+		}
+		form, err = r.deps.Forms.Duplicate(ctx, source, params)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return FormToGraphQL(form), nil
+}
+
+// FormTemplates is the resolver for the formTemplates field.
+func (r *queryResolver) FormTemplates(ctx context.Context) ([]*gqlmodel.FormTemplate, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	templates, err := r.deps.Forms.Templates(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	builtin := forms.BuiltinTemplates()
+	result := make([]*gqlmodel.FormTemplate, 0, len(builtin)+len(templates))
+	for _, t := range builtin {
+		result = append(result, &gqlmodel.FormTemplate{
+			ID:            builtinTemplatePrefix + t.Key,
+			Title:         t.Title,
+			Description:   t.Description,
+			BuiltIn:       true,
+			QuestionCount: int32(len(t.Questions)),
+		})
+	}
+	for _, f := range templates {
+		result = append(result, &gqlmodel.FormTemplate{
+			ID:            f.ID,
+			Title:         f.Title,
+			Description:   f.Description,
+			QuestionCount: int32(len(f.Questions)),
+		})
+	}
+	return result, nil
+}
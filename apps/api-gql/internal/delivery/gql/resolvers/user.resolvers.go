@@ -19,7 +19,11 @@ func (r *queryResolver) Me(ctx context.Context) (*gqlmodel.User, error) {
 	}
 
 	var user gomodel.Users
-	if err := r.deps.Gorm.Preload("Forms.Questions.Options").First(&user, "id = ?", userID).Error; err != nil {
+	// Forms of workspaces are listed through the forms query
+	if err := r.deps.Gorm.
+		Preload("Forms", `"workspaceId" IS NULL`).
+		Preload("Forms.Questions.Options").
+		First(&user, "id = ?", userID).Error; err != nil {
 		return nil, err
 	}
 	return &gqlmodel.User{
@@ -0,0 +1,200 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.70
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	gqlmodel "github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph/model"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/webhooks"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// CreateWebhook is the resolver for the createWebhook field.
+func (r *mutationResolver) CreateWebhook(ctx context.Context, formID string, input gqlmodel.WebhookInput) (*gqlmodel.Webhook, error) {
+	if _, err := r.authorize(ctx, formID, access.EditForm); err != nil {
+		return nil, err
+	}
+
+	params := webhooks.WebhookParams{
+		URL:    input.URL,
+		Events: webhookEventsFromGraphQL(input.Events),
+	}
+	if input.Secret != nil {
+		params.Secret = *input.Secret
+	}
+
+	hook, secret, err := r.deps.Webhooks.Create(ctx, formID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := webhookToGraphQL(hook)
+	result.Secret = &secret
+	return result, nil
+}
+
+// UpdateWebhook is the resolver for the updateWebhook field.
+func (r *mutationResolver) UpdateWebhook(ctx context.Context, id string, input gqlmodel.WebhookUpdateInput) (*gqlmodel.Webhook, error) {
+	hook, err := r.editableWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	update := webhooks.WebhookUpdate{
+		URL:     input.URL,
+		Enabled: input.Enabled,
+	}
+	if input.Events != nil {
+		update.Events = webhookEventsFromGraphQL(input.Events)
+	}
+
+	if err := r.deps.Webhooks.Update(ctx, hook, update); err != nil {
+		return nil, err
+	}
+
+	return webhookToGraphQL(hook), nil
+}
+
+// DeleteWebhook is the resolver for the deleteWebhook field.
+func (r *mutationResolver) DeleteWebhook(ctx context.Context, id string) (bool, error) {
+	hook, err := r.editableWebhook(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.deps.Webhooks.Delete(ctx, hook.ID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReplayWebhookDelivery is the resolver for the replayWebhookDelivery field.
+func (r *mutationResolver) ReplayWebhookDelivery(ctx context.Context, id string) (*gqlmodel.WebhookDelivery, error) {
+	delivery, err := r.deps.Webhooks.FindDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.editableWebhook(ctx, delivery.WebhookID); err != nil {
+		return nil, err
+	}
+
+	replay, err := r.deps.Webhooks.Replay(ctx, delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookDeliveryToGraphQL(replay), nil
+}
+
+// Webhooks is the resolver for the webhooks field.
+func (r *queryResolver) Webhooks(ctx context.Context, formID string) ([]*gqlmodel.Webhook, error) {
+	if _, err := r.authorize(ctx, formID, access.EditForm); err != nil {
+		return nil, err
+	}
+
+	hooks, err := r.deps.Webhooks.List(ctx, formID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*gqlmodel.Webhook, len(hooks))
+	for i := range hooks {
+		result[i] = webhookToGraphQL(&hooks[i])
+	}
+	return result, nil
+}
+
+// WebhookDeliveries is the resolver for the webhookDeliveries field.
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, webhookID string, limit *int32) ([]*gqlmodel.WebhookDelivery, error) {
+	if _, err := r.editableWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	n := 50
+	if limit != nil && *limit > 0 && *limit < 500 {
+		n = int(*limit)
+	}
+
+	deliveries, err := r.deps.Webhooks.Deliveries(ctx, webhookID, n)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*gqlmodel.WebhookDelivery, len(deliveries))
+	for i := range deliveries {
+		result[i] = webhookDeliveryToGraphQL(&deliveries[i])
+	}
+	return result, nil
+}
+
+// editableWebhook loads a webhook and checks that the current user may edit its form.
+func (r *Resolver) editableWebhook(ctx context.Context, id string) (*gomodel.Webhook, error) {
+	hook, err := r.deps.Webhooks.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.authorize(ctx, hook.FormID, access.EditForm); err != nil {
+		return nil, err
+	}
+
+	return hook, nil
+}
+
+// Webhook events are "response.created" in payloads and RESPONSE_CREATED in the schema
+func webhookEventFromGraphQL(e gqlmodel.WebhookEvent) gomodel.WebhookEvent {
+	return gomodel.WebhookEvent(strings.Replace(strings.ToLower(string(e)), "_", ".", 1))
+}
+
+func webhookEventToGraphQL(e string) gqlmodel.WebhookEvent {
+	return gqlmodel.WebhookEvent(strings.Replace(strings.ToUpper(e), ".", "_", 1))
+}
+
+func webhookEventsFromGraphQL(events []gqlmodel.WebhookEvent) []gomodel.WebhookEvent {
+	result := make([]gomodel.WebhookEvent, len(events))
+	for i, e := range events {
+		result[i] = webhookEventFromGraphQL(e)
+	}
+	return result
+}
+
+func webhookToGraphQL(w *gomodel.Webhook) *gqlmodel.Webhook {
+	events := make([]gqlmodel.WebhookEvent, len(w.Events))
+	for i, e := range w.Events {
+		events[i] = webhookEventToGraphQL(e)
+	}
+
+	return &gqlmodel.Webhook{
+		ID:        w.ID,
+		FormID:    w.FormID,
+		URL:       w.URL,
+		Events:    events,
+		Enabled:   w.Enabled,
+		CreatedAt: w.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func webhookDeliveryToGraphQL(d *gomodel.WebhookDelivery) *gqlmodel.WebhookDelivery {
+	return &gqlmodel.WebhookDelivery{
+		ID:             d.ID,
+		WebhookID:      d.WebhookID,
+		Event:          webhookEventToGraphQL(string(d.Event)),
+		Status:         gqlmodel.WebhookDeliveryStatus(d.Status),
+		Attempts:       d.Attempts,
+		RequestBody:    d.RequestBody,
+		ResponseStatus: d.ResponseStatus,
+		ResponseBody:   d.ResponseBody,
+		Error:          d.Error,
+		CreatedAt:      d.CreatedAt.Format(time.RFC3339),
+		NextAttemptAt:  d.NextAttemptAt.Format(time.RFC3339),
+		DeliveredAt:    timeToGraphQL(d.DeliveredAt),
+	}
+}
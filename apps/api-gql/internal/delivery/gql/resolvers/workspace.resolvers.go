@@ -0,0 +1,283 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.70
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph"
+	gqlmodel "github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/graph/model"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// CreateWorkspace is the resolver for the createWorkspace field.
+func (r *mutationResolver) CreateWorkspace(ctx context.Context, name string) (*gqlmodel.Workspace, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	workspace, err := r.deps.Workspaces.Create(ctx, userID, name)
+	if err != nil {
+		return nil, err
+	}
+	return workspaceToGraphQL(workspace), nil
+}
+
+// RenameWorkspace is the resolver for the renameWorkspace field.
+func (r *mutationResolver) RenameWorkspace(ctx context.Context, id string, name string) (*gqlmodel.Workspace, error) {
+	workspace, err := r.authorizedWorkspace(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.deps.Workspaces.Rename(ctx, workspace, name); err != nil {
+		return nil, err
+	}
+	return workspaceToGraphQL(workspace), nil
+}
+
+// DeleteWorkspace is the resolver for the deleteWorkspace field.
+func (r *mutationResolver) DeleteWorkspace(ctx context.Context, id string) (bool, error) {
+	if _, err := r.authorizedWorkspace(ctx, id, true); err != nil {
+		return false, err
+	}
+
+	if err := r.deps.Workspaces.Delete(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AddWorkspaceMember is the resolver for the addWorkspaceMember field.
+func (r *mutationResolver) AddWorkspaceMember(ctx context.Context, workspaceID string, email string, role gqlmodel.WorkspaceRole) (*gqlmodel.WorkspaceMember, error) {
+	if _, err := r.authorizedWorkspace(ctx, workspaceID, true); err != nil {
+		return nil, err
+	}
+
+	member, err := r.deps.Workspaces.AddMember(ctx, workspaceID, email, gomodel.WorkspaceRole(role))
+	if err != nil {
+		return nil, err
+	}
+	return workspaceMemberToGraphQL(member), nil
+}
+
+// ChangeWorkspaceMemberRole is the resolver for the changeWorkspaceMemberRole field.
+func (r *mutationResolver) ChangeWorkspaceMemberRole(ctx context.Context, workspaceID string, userID string, role gqlmodel.WorkspaceRole) (*gqlmodel.WorkspaceMember, error) {
+	if _, err := r.authorizedWorkspace(ctx, workspaceID, true); err != nil {
+		return nil, err
+	}
+
+	member, err := r.deps.Workspaces.ChangeRole(ctx, workspaceID, userID, gomodel.WorkspaceRole(role))
+	if err != nil {
+		return nil, err
+	}
+	return workspaceMemberToGraphQL(member), nil
+}
+
+// RemoveWorkspaceMember is the resolver for the removeWorkspaceMember field.
+func (r *mutationResolver) RemoveWorkspaceMember(ctx context.Context, workspaceID string, userID string) (bool, error) {
+	currentUserID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, errors.New("authorization required")
+	}
+
+	// Members may leave on their own
+	if _, err := r.authorizedWorkspace(ctx, workspaceID, userID != currentUserID); err != nil {
+		return false, err
+	}
+
+	if err := r.deps.Workspaces.RemoveMember(ctx, workspaceID, userID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SwitchWorkspace is the resolver for the switchWorkspace field.
+func (r *mutationResolver) SwitchWorkspace(ctx context.Context, id *string) (*gqlmodel.User, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	if id != nil {
+		if _, err := r.authorizedWorkspace(ctx, *id, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.deps.Workspaces.Switch(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	return r.Query().Me(ctx)
+}
+
+// MoveFormToWorkspace is the resolver for the moveFormToWorkspace field.
+func (r *mutationResolver) MoveFormToWorkspace(ctx context.Context, formID string, workspaceID *string) (*gqlmodel.Form, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	form, err := r.authorizedForm(ctx, formID, access.ManageForm)
+	if err != nil {
+		return nil, err
+	}
+
+	// The author of a team form owns it as a member, but only an admin may take it out of the team.
+	if form.WorkspaceID != nil {
+		if _, err := r.authorizedWorkspace(ctx, *form.WorkspaceID, true); err != nil {
+			return nil, err
+		}
+	}
+	if workspaceID != nil {
+		if _, err := r.authorizedWorkspace(ctx, *workspaceID, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.deps.Workspaces.MoveForm(ctx, form, userID, workspaceID); err != nil {
+		return nil, err
+	}
+	return FormToGraphQL(form), nil
+}
+
+// Workspace is the resolver for the workspace field.
+func (r *queryResolver) Workspace(ctx context.Context, id string) (*gqlmodel.Workspace, error) {
+	workspace, err := r.authorizedWorkspace(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	return workspaceToGraphQL(workspace), nil
+}
+
+// Workspaces is the resolver for the workspaces field.
+func (r *userResolver) Workspaces(ctx context.Context, obj *gqlmodel.User) ([]*gqlmodel.Workspace, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil || userID != obj.ID {
+		return []*gqlmodel.Workspace{}, nil
+	}
+
+	workspaces, err := r.deps.Workspaces.ForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*gqlmodel.Workspace, len(workspaces))
+	for i := range workspaces {
+		result[i] = workspaceToGraphQL(&workspaces[i])
+	}
+	return result, nil
+}
+
+// CurrentWorkspace is the resolver for the currentWorkspace field.
+func (r *userResolver) CurrentWorkspace(ctx context.Context, obj *gqlmodel.User) (*gqlmodel.Workspace, error) {
+	user, err := r.deps.Sessions.GetAuthenticatedUser(ctx)
+	if err != nil || user.ID != obj.ID || user.CurrentWorkspaceID == nil {
+		return nil, nil
+	}
+
+	workspace, err := r.deps.Workspaces.Find(ctx, *user.CurrentWorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return workspaceToGraphQL(workspace), nil
+}
+
+// MyRole is the resolver for the myRole field.
+func (r *workspaceResolver) MyRole(ctx context.Context, obj *gqlmodel.Workspace) (*gqlmodel.WorkspaceRole, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, nil
+	}
+
+	role, err := r.deps.Access.WorkspaceRole(ctx, obj.ID, userID)
+	if err != nil || role == "" {
+		return nil, err
+	}
+
+	result := gqlmodel.WorkspaceRole(role)
+	return &result, nil
+}
+
+// Members is the resolver for the members field.
+func (r *workspaceResolver) Members(ctx context.Context, obj *gqlmodel.Workspace) ([]*gqlmodel.WorkspaceMember, error) {
+	if _, err := r.authorizedWorkspace(ctx, obj.ID, false); err != nil {
+		return nil, err
+	}
+
+	members, err := r.deps.Workspaces.Members(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*gqlmodel.WorkspaceMember, len(members))
+	for i := range members {
+		result[i] = workspaceMemberToGraphQL(&members[i])
+	}
+	return result, nil
+}
+
+// User returns graph.UserResolver implementation.
+func (r *Resolver) User() graph.UserResolver { return &userResolver{r} }
+
+// Workspace returns graph.WorkspaceResolver implementation.
+func (r *Resolver) Workspace() graph.WorkspaceResolver { return &workspaceResolver{r} }
+
+type userResolver struct{ *Resolver }
+type workspaceResolver struct{ *Resolver }
+
+// authorizedWorkspace loads a workspace and checks that the current user is a member
+// of it, or an admin when admin is set.
+func (r *Resolver) authorizedWorkspace(ctx context.Context, id string, admin bool) (*gomodel.Workspace, error) {
+	userID, err := r.deps.Sessions.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, errors.New("authorization required")
+	}
+
+	workspace, err := r.deps.Workspaces.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.deps.Access.AuthorizeWorkspace(ctx, id, userID, admin); err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// targetWorkspace picks the workspace a new form of the user goes to: the requested one,
+// or the one the user is working in. Nil means the personal workspace.
+func (r *Resolver) targetWorkspace(ctx context.Context, user *gomodel.Users, requested *string) (*string, error) {
+	workspaceID := user.CurrentWorkspaceID
+	if requested != nil {
+		workspaceID = requested
+	}
+	if workspaceID != nil {
+		if _, err := r.deps.Access.AuthorizeWorkspace(ctx, *workspaceID, user.ID, false); err != nil {
+			return nil, err
+		}
+	}
+	return workspaceID, nil
+}
+
+func workspaceToGraphQL(w *gomodel.Workspace) *gqlmodel.Workspace {
+	return &gqlmodel.Workspace{
+		ID:        w.ID,
+		Name:      w.Name,
+		CreatedAt: w.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func workspaceMemberToGraphQL(m *gomodel.WorkspaceMember) *gqlmodel.WorkspaceMember {
+	return &gqlmodel.WorkspaceMember{
+		User:      memberUserToGraphQL(m.User),
+		Role:      gqlmodel.WorkspaceRole(m.Role),
+		CreatedAt: m.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -81,7 +81,7 @@ func New(opts AuthOpts, cfg config.Config) {
 			return
 		}
 
-		opts.Auth.Put(ctx.Request.Context(), "dbUser", *user)
+		opts.Auth.SignIn(ctx.Request.Context(), *user)
 		ctx.JSON(http.StatusOK, gin.H{"data": entity.UserInfo{
 			ID:          user.ID,
 			Email:       user.Email,
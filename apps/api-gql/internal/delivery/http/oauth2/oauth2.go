@@ -1,79 +1,125 @@
 package oauth2
 
 import (
+	"errors"
 	"net/http"
+	"net/url"
 
 	"github.com/TrySquadDF/formify/api-gql/internal/auth"
 	"github.com/TrySquadDF/formify/api-gql/internal/delivery/gql/resolvers"
-	"github.com/TrySquadDF/formify/api-gql/internal/entity"
 	"github.com/TrySquadDF/formify/api-gql/internal/server"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/identities"
 	"github.com/TrySquadDF/formify/api-gql/internal/services/users"
 	"github.com/TrySquadDF/formify/lib/config"
-	"github.com/TrySquadDF/formify/lib/google"
+	"github.com/TrySquadDF/formify/lib/oauth"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/fx"
 )
 
-type GoogleOpts struct {
+type Opts struct {
 	fx.In
 
 	UserService *users.Service
+	Identities  *identities.Service
 	Resolver    *resolvers.Resolver
 	Server      *server.Server
-	Auth        *auth.Auth 
+	Auth        *auth.Auth
+	Providers   *oauth.Registry
 }
 
-func New(opts GoogleOpts, cfg config.Config, gcfg google.GoogleConfig) {
-	opts.Server.GET("/google/login", gcfg.AutharizationHandler)
+func New(opts Opts, cfg config.Config) {
+	login := func(ctx *gin.Context, name string) {
+		provider, err := opts.Providers.Get(name)
+		if err != nil {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 
-	opts.Server.GET("/google/callback", func(ctx *gin.Context) {
-		code := ctx.Query("code")
+		attempt, err := oauth.NewAttempt(name)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start login"})
+			return
+		}
 
-		token, err := gcfg.Exchange(ctx.Request.Context(), code)
+		url, err := provider.AuthCodeURL(ctx.Request.Context(), attempt)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Failed to exchange token"})
+			ctx.JSON(http.StatusBadGateway, gin.H{"error": "Login provider is unavailable"})
 			return
 		}
 
-		client := gcfg.Client(ctx.Request.Context(), token)
-		resp, err := client.Get(google.GoogleUserEndpoint.UserInfoV3)
+		opts.Auth.Put(ctx.Request.Context(), oauth.AttemptSessionKey, attempt)
+		ctx.Redirect(http.StatusTemporaryRedirect, url)
+	}
+
+	callback := func(ctx *gin.Context, name string) {
+		provider, err := opts.Providers.Get(name)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Failed to get user info"})
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
 
-		defer resp.Body.Close()
+		// The attempt is used once, whatever the outcome
+		attempt, _ := opts.Auth.Pop(ctx.Request.Context(), oauth.AttemptSessionKey).(oauth.Attempt)
+		if err := attempt.Check(name, ctx.Query("state")); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if ctx.Query("error") != "" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Login was cancelled"})
+			return
+		}
 
-		ud, err := google.UnmarshalUserData(resp.Body)
+		info, err := provider.Exchange(ctx.Request.Context(), attempt, ctx.Query("code"))
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unmarshal user data"})
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Failed to exchange token"})
+			return
+		}
+
+		if attempt.LinkUserID != "" {
+			linkIdentity(ctx, opts, name, attempt, info, cfg)
 			return
 		}
 
-		user, err := opts.UserService.FindByGoogleID(ctx.Request.Context(), ud.Sub)
+		user, pending, err := opts.Identities.SignIn(ctx.Request.Context(), name, info)
+		if errors.Is(err, identities.ErrEmailTaken) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, identities.ErrEmailNotVerified) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		if err != nil {
 			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find user"})
 			return
 		}
 
-		if user == nil {
-			newUser, err := opts.UserService.CreateUser(ctx.Request.Context(), entity.Users{
-				Email:       ud.Email,
-				DisplayName: ud.Name,
-				Picture:     ud.Picture,
-				GoogleID:    ud.Sub,
-			})
-			if err != nil {
-				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
-				return
-			}
-
-			user = newUser
+		// The email has an account already: the login is linked to it once the user
+		// signs in to that account
+		if pending != nil {
+			opts.Auth.Put(ctx.Request.Context(), identities.PendingLinkSessionKey, *pending)
+			ctx.Redirect(http.StatusTemporaryRedirect, redirectURL(cfg, "pendingLink", name))
+			return
 		}
-		
 
-		opts.Auth.Put(ctx.Request.Context(), "dbUser", *user)
-		ctx.Redirect(http.StatusTemporaryRedirect, "https://localhost:3000")
+		opts.Auth.SignIn(ctx.Request.Context(), *user)
+		ctx.Redirect(http.StatusTemporaryRedirect, cfg.LoginRedirectUrl)
+	}
+
+	opts.Server.GET("/oauth/:provider/login", func(ctx *gin.Context) {
+		login(ctx, ctx.Param("provider"))
+	})
+	opts.Server.GET("/oauth/:provider/callback", func(ctx *gin.Context) {
+		callback(ctx, ctx.Param("provider"))
+	})
+
+	// Google clients are registered with these
+	opts.Server.GET("/google/login", func(ctx *gin.Context) {
+		login(ctx, oauth.Google)
+	})
+	opts.Server.GET("/google/callback", func(ctx *gin.Context) {
+		callback(ctx, oauth.Google)
 	})
 
 	opts.Server.GET("/logout", func(ctx *gin.Context) {
@@ -81,3 +127,37 @@ func New(opts GoogleOpts, cfg config.Config, gcfg google.GoogleConfig) {
 		ctx.Redirect(http.StatusTemporaryRedirect, cfg.SiteBaseUrl)
 	})
 }
+
+// linkIdentity finishes a login started by linkIdentity: the provider account becomes
+// a way to sign in to the account that started it.
+func linkIdentity(ctx *gin.Context, opts Opts, name string, attempt oauth.Attempt, info *oauth.UserInfo, cfg config.Config) {
+	user, err := opts.Auth.GetAuthenticatedUser(ctx.Request.Context())
+	if err != nil || user.ID != attempt.LinkUserID {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Sign in to the account to link this login to"})
+		return
+	}
+
+	_, err = opts.Identities.Link(ctx.Request.Context(), user.ID, name, info)
+	if errors.Is(err, identities.ErrIdentityInUse) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to link login"})
+		return
+	}
+
+	ctx.Redirect(http.StatusTemporaryRedirect, redirectURL(cfg, "linked", name))
+}
+
+// redirectURL tells the app what came of the login
+func redirectURL(cfg config.Config, key, value string) string {
+	u, err := url.Parse(cfg.LoginRedirectUrl)
+	if err != nil {
+		return cfg.LoginRedirectUrl
+	}
+	q := u.Query()
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
@@ -0,0 +1,251 @@
+package restapi
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/formdoc"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/forms"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/pubsub"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/gin-gonic/gin"
+)
+
+type Form struct {
+	ID          string             `json:"id"`
+	Key         *string            `json:"key"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Access      gomodel.FormAccess `json:"access"`
+	WorkspaceID *string            `json:"workspaceId"`
+	FolderID    *string            `json:"folderId"`
+	Tags        []string           `json:"tags"`
+	IsTemplate  bool               `json:"isTemplate"`
+	Version     int64              `json:"version"`
+	ClosedAt    *time.Time         `json:"closedAt"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+	Questions   []Question         `json:"questions"`
+}
+
+type Question struct {
+	ID              string               `json:"id"`
+	Key             *string              `json:"key"`
+	Text            string               `json:"text"`
+	Type            gomodel.QuestionType `json:"type"`
+	Required        bool                 `json:"required"`
+	RespondentEmail bool                 `json:"respondentEmail"`
+	Options         []Option             `json:"options"`
+}
+
+type Option struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+type FormList struct {
+	Items []Form `json:"items"`
+	Total int64  `json:"total"`
+	// NextOffset is the offset of the next page; null on the last page
+	NextOffset *int `json:"nextOffset"`
+}
+
+func (h *handler) listForms(ctx *gin.Context) {
+	limit, offset, ok := page(ctx)
+	if !ok {
+		return
+	}
+
+	user := h.user(ctx)
+	params := forms.ListParams{OwnerID: user.ID, Limit: limit, Offset: offset}
+	if workspaceID := ctx.Query("workspaceId"); workspaceID != "" {
+		if _, err := h.access.AuthorizeWorkspace(ctx.Request.Context(), workspaceID, user.ID, false); err != nil {
+			h.fail(ctx, err)
+			return
+		}
+		params.WorkspaceID = &workspaceID
+	}
+	if tag := ctx.Query("tag"); tag != "" {
+		params.Tag = &tag
+	}
+
+	list, total, err := h.forms.List(ctx.Request.Context(), params)
+	if err != nil {
+		h.fail(ctx, err)
+		return
+	}
+
+	items := make([]Form, len(list))
+	for i := range list {
+		items[i] = formToREST(&list[i])
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": FormList{Items: items, Total: total, NextOffset: nextOffset(limit, offset, total)}})
+}
+
+// createForm creates a form from a document, the way importForm does.
+func (h *handler) createForm(ctx *gin.Context) {
+	doc, ok := readDocument(ctx)
+	if !ok {
+		return
+	}
+
+	user := h.user(ctx)
+	definition := doc.Form()
+	params := forms.CopyParams{OwnerID: user.ID, ExternalKey: definition.ExternalKey}
+	if workspaceID := ctx.Query("workspaceId"); workspaceID != "" {
+		if _, err := h.access.AuthorizeWorkspace(ctx.Request.Context(), workspaceID, user.ID, false); err != nil {
+			h.fail(ctx, err)
+			return
+		}
+		params.WorkspaceID = &workspaceID
+	}
+	if doc.Key != "" {
+		if _, err := h.forms.FindByKey(ctx.Request.Context(), user.ID, params.WorkspaceID, doc.Key); err == nil {
+			h.fail(ctx, forms.ErrKeyTaken)
+			return
+		} else if !errors.Is(err, forms.ErrFormNotFound) {
+			h.fail(ctx, err)
+			return
+		}
+	}
+
+	form, err := h.forms.Duplicate(ctx.Request.Context(), definition, params)
+	if err != nil {
+		h.fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"data": formToREST(form)})
+}
+
+func (h *handler) getForm(ctx *gin.Context) {
+	form, ok := h.authorizedForm(ctx, ctx.Param("id"), access.ViewForm)
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": formToREST(form)})
+}
+
+// replaceForm gives the form the definition of a document, the way applyForm updates
+// a form. Questions are written only when they changed and are then updated in place,
+// so answers keep their questions.
+func (h *handler) replaceForm(ctx *gin.Context) {
+	form, ok := h.authorizedForm(ctx, ctx.Param("id"), access.EditForm)
+	if !ok {
+		return
+	}
+	doc, ok := readDocument(ctx)
+	if !ok {
+		return
+	}
+	if doc.Key != "" && (form.ExternalKey == nil || *form.ExternalKey != doc.Key) {
+		h.fail(ctx, &formdoc.ValidationError{Issues: []formdoc.Issue{{Path: "key", Message: "does not match the key of the form"}}})
+		return
+	}
+
+	current := formdoc.Export(form)
+	if !formdoc.Equal(current, doc) {
+		sameQuestions := formdoc.SameQuestions(current, doc)
+		version, err := h.forms.ReplaceDefinition(ctx.Request.Context(), form, doc.Form(), !sameQuestions)
+		if err != nil {
+			h.fail(ctx, err)
+			return
+		}
+
+		change := pubsub.FormChange{Kind: pubsub.FormChangeFormUpdated, FormID: form.ID, Version: version, ActorID: h.user(ctx).ID}
+		if !sameQuestions {
+			change.Kind = pubsub.FormChangeQuestionsReplaced
+		}
+		h.pubsub.PublishFormChange(ctx.Request.Context(), change)
+
+		if form, ok = h.authorizedForm(ctx, form.ID, access.EditForm); !ok {
+			return
+		}
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": formToREST(form)})
+}
+
+func (h *handler) deleteForm(ctx *gin.Context) {
+	form, ok := h.authorizedForm(ctx, ctx.Param("id"), access.ManageForm)
+	if !ok {
+		return
+	}
+
+	if err := h.forms.Trash(ctx.Request.Context(), form.ID); err != nil {
+		h.fail(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// readDocument parses the body as a form document, YAML when the content type says so.
+// On failure the error response is already written.
+func readDocument(ctx *gin.Context) (*formdoc.Document, bool) {
+	data, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBodySize))
+	if err != nil {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	format := formdoc.FormatJSON
+	if strings.Contains(ctx.ContentType(), "yaml") {
+		format = formdoc.FormatYAML
+	}
+	doc, err := formdoc.Parse(data, format)
+	if err != nil {
+		var invalid *formdoc.ValidationError
+		if errors.As(err, &invalid) {
+			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "issues": invalid.Issues})
+		} else {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return nil, false
+	}
+	return doc, true
+}
+
+func formToREST(f *gomodel.Form) Form {
+	questions := make([]gomodel.Question, len(f.Questions))
+	copy(questions, f.Questions)
+	sort.SliceStable(questions, func(i, j int) bool { return questions[i].Order < questions[j].Order })
+
+	result := Form{
+		ID:          f.ID,
+		Key:         f.ExternalKey,
+		Title:       f.Title,
+		Description: f.Description,
+		Access:      f.Access,
+		WorkspaceID: f.WorkspaceID,
+		FolderID:    f.FolderID,
+		Tags:        append([]string{}, f.Tags...),
+		IsTemplate:  f.IsTemplate,
+		Version:     f.Version,
+		ClosedAt:    f.ClosedAt,
+		CreatedAt:   f.CreatedAt,
+		UpdatedAt:   f.UpdatedAt,
+		Questions:   make([]Question, len(questions)),
+	}
+	for i, q := range questions {
+		options := make([]*gomodel.Option, len(q.Options))
+		copy(options, q.Options)
+		sort.SliceStable(options, func(i, j int) bool { return options[i].Order < options[j].Order })
+
+		result.Questions[i] = Question{
+			ID:              q.ID,
+			Key:             q.Key,
+			Text:            q.Text,
+			Type:            q.Type,
+			Required:        q.Required,
+			RespondentEmail: q.RespondentEmail,
+			Options:         make([]Option, len(options)),
+		}
+		for j, o := range options {
+			result.Questions[i].Options[j] = Option{ID: o.ID, Text: o.Text}
+		}
+	}
+	return result
+}
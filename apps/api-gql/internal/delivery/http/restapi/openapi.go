@@ -0,0 +1,265 @@
+package restapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/formdoc"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// object is a node of the OpenAPI document
+type object = map[string]interface{}
+
+// ErrorBody is what every failed call returns. Issues list the problems of an invalid
+// form document.
+type ErrorBody struct {
+	Error  string          `json:"error"`
+	Issues []formdoc.Issue `json:"issues,omitempty"`
+}
+
+// enums are the values of the string types the API exposes
+var enums = map[reflect.Type][]string{
+	reflect.TypeOf(gomodel.FormAccess("")): {
+		string(gomodel.FormAccessPrivate), string(gomodel.FormAccessByLink), string(gomodel.FormAccessPublic),
+	},
+	reflect.TypeOf(gomodel.QuestionType("")): {
+		string(gomodel.QuestionTypeShortText), string(gomodel.QuestionTypeParagraph), string(gomodel.QuestionTypeBoolean),
+		string(gomodel.QuestionTypeNumber), string(gomodel.QuestionTypePhone), string(gomodel.QuestionTypeDate),
+		string(gomodel.QuestionTypeEmail), string(gomodel.QuestionTypeSingleChoice), string(gomodel.QuestionTypeMultipleChoice),
+	},
+	reflect.TypeOf(gomodel.ResponseNotifications("")): {
+		string(gomodel.ResponseNotificationsNone), string(gomodel.ResponseNotificationsEach),
+		string(gomodel.ResponseNotificationsHourly), string(gomodel.ResponseNotificationsDaily),
+	},
+	reflect.TypeOf(gomodel.WebhookEvent("")): {
+		string(gomodel.WebhookEventResponseCreated), string(gomodel.WebhookEventResponseUpdated), string(gomodel.WebhookEventFormClosed),
+	},
+	reflect.TypeOf(gomodel.WebhookDeliveryStatus("")): {
+		string(gomodel.WebhookDeliveryPending), string(gomodel.WebhookDeliverySucceeded), string(gomodel.WebhookDeliveryFailed),
+	},
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// openAPI builds the OpenAPI 3 document of the routes. Go types of bodies and results
+// become component schemas named after the type; a field is required unless it is
+// omitempty, and pointers are nullable.
+func openAPI(routes []route) object {
+	s := &schemas{components: object{}, types: map[string]reflect.Type{}}
+	errorSchema := s.of(reflect.TypeOf(ErrorBody{}))
+
+	paths := object{}
+	for _, r := range routes {
+		path, pathParams := openAPIPath(r.path)
+		item, _ := paths[path].(object)
+		if item == nil {
+			item = object{}
+			paths[path] = item
+		}
+
+		parameters := []object{}
+		for _, name := range pathParams {
+			parameters = append(parameters, object{
+				"name": name, "in": "path", "required": true,
+				"schema": object{"type": "string"},
+			})
+		}
+		for _, p := range r.query {
+			parameters = append(parameters, p.openAPI())
+		}
+
+		operation := object{
+			"operationId": r.id,
+			"summary":     r.summary,
+			"description": "Requires an API key with the " + string(r.scope) + " scope.",
+			"tags":        []string{r.tag},
+			"parameters":  parameters,
+			"responses": object{
+				strconv.Itoa(r.status): s.result(r),
+				"default": object{
+					"description": "Error",
+					"content":     object{"application/json": object{"schema": errorSchema}},
+				},
+			},
+		}
+
+		if r.body != nil {
+			consumes := r.consumes
+			if len(consumes) == 0 {
+				consumes = []string{"application/json"}
+			}
+			schema := s.of(reflect.TypeOf(r.body))
+			content := object{}
+			for _, contentType := range consumes {
+				content[contentType] = object{"schema": schema}
+			}
+			operation["requestBody"] = object{"required": true, "content": content}
+		}
+
+		item[strings.ToLower(r.method)] = operation
+	}
+
+	return object{
+		"openapi": "3.0.3",
+		"info": object{
+			"title":   "Formify API",
+			"version": "1",
+		},
+		"servers":  []object{{"url": BasePath}},
+		"security": []object{{"apiKey": []string{}}},
+		"paths":    paths,
+		"components": object{
+			"schemas": s.components,
+			"securitySchemes": object{
+				"apiKey": object{"type": "apiKey", "in": "header", "name": "api-key"},
+			},
+		},
+	}
+}
+
+// openAPIPath turns "/forms/:id" into "/forms/{id}" and returns the parameter names.
+func openAPIPath(path string) (string, []string) {
+	var params []string
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			params = append(params, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+func (p param) openAPI() object {
+	schema := object{"type": "string"}
+	switch p.kind {
+	case paramInteger:
+		schema = object{"type": "integer"}
+	case paramStrings:
+		schema = object{"type": "array", "items": object{"type": "string"}}
+	}
+	return object{"name": p.name, "in": "query", "description": p.description, "schema": schema}
+}
+
+type schemas struct {
+	components object
+	// types remembers which Go type a component name was given to
+	types map[string]reflect.Type
+}
+
+func (s *schemas) result(r route) object {
+	description := http.StatusText(r.status)
+	switch {
+	case r.produces != "":
+		return object{
+			"description": description,
+			"content":     object{r.produces: object{"schema": object{"type": "string"}}},
+		}
+	case r.result == nil:
+		return object{"description": description}
+	}
+
+	envelope := object{
+		"type":       "object",
+		"required":   []string{"data"},
+		"properties": object{"data": s.of(reflect.TypeOf(r.result))},
+	}
+	return object{
+		"description": description,
+		"content":     object{"application/json": object{"schema": envelope}},
+	}
+}
+
+// of returns the schema of a Go type, registering named structs as components.
+func (s *schemas) of(t reflect.Type) object {
+	if values, ok := enums[t]; ok {
+		return object{"type": "string", "enum": values}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := s.of(t.Elem())
+		if _, ok := schema["$ref"]; ok {
+			return object{"allOf": []object{schema}, "nullable": true}
+		}
+		schema["nullable"] = true
+		return schema
+	case reflect.String:
+		return object{"type": "string"}
+	case reflect.Bool:
+		return object{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return object{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return object{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return object{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return object{"type": "array", "items": s.of(t.Elem())}
+	case reflect.Map:
+		return object{"type": "object", "additionalProperties": s.of(t.Elem())}
+	case reflect.Interface:
+		return object{}
+	case reflect.Struct:
+		if t == timeType {
+			return object{"type": "string", "format": "date-time"}
+		}
+		if t.Name() == "" {
+			return s.structSchema(t)
+		}
+		name := s.componentName(t)
+		if _, ok := s.components[name]; !ok {
+			// Placeholder first, so recursive types end
+			s.components[name] = object{}
+			s.components[name] = s.structSchema(t)
+		}
+		return object{"$ref": "#/components/schemas/" + name}
+	}
+	return object{}
+}
+
+// componentName is the name of the type, prefixed with its package when another type
+// already took the name (formdoc.Question next to Question).
+func (s *schemas) componentName(t reflect.Type) string {
+	name := t.Name()
+	if existing, ok := s.types[name]; ok && existing != t {
+		pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	s.types[name] = t
+	return name
+}
+
+func (s *schemas) structSchema(t reflect.Type) object {
+	properties := object{}
+	required := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = s.of(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	schema := object{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
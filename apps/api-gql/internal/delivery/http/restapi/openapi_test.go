@@ -0,0 +1,210 @@
+package restapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// refs collects every $ref of a node of the document.
+func refs(node interface{}, found map[string]bool) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for key, value := range n {
+			if ref, ok := value.(string); ok && key == "$ref" {
+				found[ref] = true
+			}
+			refs(value, found)
+		}
+	case []interface{}:
+		for _, value := range n {
+			refs(value, found)
+		}
+	}
+}
+
+// decodedDocument is the document of the routes as a client reads it.
+func decodedDocument(t *testing.T) map[string]interface{} {
+	t.Helper()
+	data, err := json.Marshal(openAPI(routes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestOpenAPIDocumentsEveryRoute(t *testing.T) {
+	doc := decodedDocument(t)
+	paths := doc["paths"].(map[string]interface{})
+
+	ids := make(map[string]bool)
+	for _, r := range routes {
+		path, params := openAPIPath(r.path)
+		operation, ok := paths[path].(map[string]interface{})[strings.ToLower(r.method)].(map[string]interface{})
+		if !ok {
+			t.Errorf("%s %s is not documented", r.method, path)
+			continue
+		}
+
+		if ids[r.id] {
+			t.Errorf("operation ID %s is used twice", r.id)
+		}
+		ids[r.id] = true
+		if operation["operationId"] != r.id || !strings.Contains(operation["description"].(string), string(r.scope)) {
+			t.Errorf("%s = %v, want its ID and scope", r.id, operation)
+		}
+
+		// Path parameters come first and are required
+		parameters := operation["parameters"].([]interface{})
+		if len(parameters) != len(params)+len(r.query) {
+			t.Errorf("%s has %d parameters, want %d", r.id, len(parameters), len(params)+len(r.query))
+			continue
+		}
+		for i, name := range params {
+			p := parameters[i].(map[string]interface{})
+			if p["name"] != name || p["in"] != "path" || p["required"] != true {
+				t.Errorf("%s parameter %d = %v, want path parameter %s", r.id, i, p, name)
+			}
+		}
+
+		_, hasBody := operation["requestBody"]
+		if hasBody != (r.body != nil) {
+			t.Errorf("%s request body documented = %v, want %v", r.id, hasBody, r.body != nil)
+		}
+		responses := operation["responses"].(map[string]interface{})
+		if _, ok := responses["default"]; !ok {
+			t.Errorf("%s does not document errors", r.id)
+		}
+	}
+
+	// Every reference points to a component
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	found := make(map[string]bool)
+	refs(doc, found)
+	for ref := range found {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		if _, ok := schemas[name]; !ok {
+			t.Errorf("%s points nowhere", ref)
+		}
+	}
+}
+
+func TestOpenAPIOperations(t *testing.T) {
+	paths := decodedDocument(t)["paths"].(map[string]interface{})
+	operation := func(path, method string) map[string]interface{} {
+		return paths[path].(map[string]interface{})[method].(map[string]interface{})
+	}
+
+	// Form documents are accepted as JSON and YAML
+	content := operation("/forms", "post")["requestBody"].(map[string]interface{})["content"].(map[string]interface{})
+	if len(content) != 2 || content["application/json"] == nil || content["application/yaml"] == nil {
+		t.Errorf("createForm body = %v, want JSON and YAML", content)
+	}
+
+	// JSON results are wrapped in data
+	created := operation("/forms", "post")["responses"].(map[string]interface{})["201"].(map[string]interface{})
+	envelope := created["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	data := envelope["properties"].(map[string]interface{})["data"].(map[string]interface{})
+	if data["$ref"] != "#/components/schemas/Form" || !reflect.DeepEqual(envelope["required"], []interface{}{"data"}) {
+		t.Errorf("createForm result = %v, want a Form in data", envelope)
+	}
+
+	deleted := operation("/forms/{id}", "delete")["responses"].(map[string]interface{})["204"].(map[string]interface{})
+	if _, ok := deleted["content"]; ok {
+		t.Errorf("deleteForm result = %v, want no content", deleted)
+	}
+
+	exported := operation("/forms/{id}/responses/export", "get")["responses"].(map[string]interface{})["200"].(map[string]interface{})
+	if _, ok := exported["content"].(map[string]interface{})["text/csv"]; !ok {
+		t.Errorf("exportResponses result = %v, want CSV", exported)
+	}
+}
+
+type schemaSample struct {
+	Name     string             `json:"name"`
+	Nickname *string            `json:"nickname"`
+	Note     string             `json:"note,omitempty"`
+	Access   gomodel.FormAccess `json:"access"`
+	Count    int32              `json:"count"`
+	Total    int64              `json:"total"`
+	At       time.Time          `json:"at"`
+	Labels   map[string]string  `json:"labels"`
+	Parent   *schemaSample      `json:"parent,omitempty"`
+	Hidden   string             `json:"-"`
+	internal string
+}
+
+func TestSchemaOf(t *testing.T) {
+	s := &schemas{components: object{}, types: map[string]reflect.Type{}}
+
+	if ref := s.of(reflect.TypeOf(schemaSample{})); ref["$ref"] != "#/components/schemas/schemaSample" {
+		t.Fatalf("schema = %v, want a reference", ref)
+	}
+	schema := s.components["schemaSample"].(object)
+	properties := schema["properties"].(object)
+
+	if want := []string{"name", "nickname", "access", "count", "total", "at", "labels"}; !reflect.DeepEqual(schema["required"], want) {
+		t.Errorf("required = %v, want %v", schema["required"], want)
+	}
+	if _, ok := properties["Hidden"]; ok || len(properties) != 9 {
+		t.Errorf("properties = %v, want the JSON fields", properties)
+	}
+
+	tests := map[string]object{
+		"name":     {"type": "string"},
+		"nickname": {"type": "string", "nullable": true},
+		"access":   {"type": "string", "enum": enums[reflect.TypeOf(gomodel.FormAccess(""))]},
+		"count":    {"type": "integer", "format": "int32"},
+		"total":    {"type": "integer", "format": "int64"},
+		"at":       {"type": "string", "format": "date-time"},
+		"labels":   {"type": "object", "additionalProperties": object{"type": "string"}},
+		"parent":   {"allOf": []object{{"$ref": "#/components/schemas/schemaSample"}}, "nullable": true},
+	}
+	for name, want := range tests {
+		if !reflect.DeepEqual(properties[name], want) {
+			t.Errorf("%s = %v, want %v", name, properties[name], want)
+		}
+	}
+}
+
+func TestComponentNamesDoNotClash(t *testing.T) {
+	schemas := decodedDocument(t)["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+
+	// The API question comes first; the form document question is named after its package
+	question := schemas["Question"].(map[string]interface{})
+	if _, ok := question["properties"].(map[string]interface{})["id"]; !ok || !contains(question["required"], "id") {
+		t.Errorf("Question = %v, want the API question", question)
+	}
+	document := schemas["FormdocQuestion"].(map[string]interface{})
+	if contains(document["required"], "id") {
+		t.Errorf("FormdocQuestion = %v, want the form document question", document)
+	}
+}
+
+func contains(list interface{}, value string) bool {
+	items, _ := list.([]interface{})
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestOpenAPIPath(t *testing.T) {
+	path, params := openAPIPath("/forms/:id/webhooks/:webhookId")
+	if path != "/forms/{id}/webhooks/{webhookId}" || !reflect.DeepEqual(params, []string{"id", "webhookId"}) {
+		t.Errorf("openAPIPath() = %q, %v", path, params)
+	}
+	if path, params := openAPIPath("/forms"); path != "/forms" || params != nil {
+		t.Errorf("openAPIPath() = %q, %v", path, params)
+	}
+}
@@ -0,0 +1,140 @@
+package restapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/formschema"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/responses"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/gin-gonic/gin"
+)
+
+// Response carries answers the way POST /forms/{id}/responses accepts them: keyed by
+// question key or ID, with choices as option IDs and dates in RFC 3339.
+type Response struct {
+	ID        string                 `json:"id"`
+	CreatedAt time.Time              `json:"createdAt"`
+	UpdatedAt *time.Time             `json:"updatedAt"`
+	Status    string                 `json:"status"`
+	Tags      []string               `json:"tags"`
+	Answers   map[string]interface{} `json:"answers"`
+}
+
+type ResponseList struct {
+	Items []Response `json:"items"`
+	Total int64      `json:"total"`
+	// NextOffset is the offset of the next page; null on the last page
+	NextOffset *int `json:"nextOffset"`
+}
+
+func (h *handler) listResponses(ctx *gin.Context) {
+	form, ok := h.authorizedForm(ctx, ctx.Param("id"), access.ViewResponses)
+	if !ok {
+		return
+	}
+	limit, offset, ok := page(ctx)
+	if !ok {
+		return
+	}
+
+	params := responseFilter(ctx, form)
+	params.Limit, params.Offset = limit, offset
+	list, total, err := h.responses.List(ctx.Request.Context(), params)
+	if err != nil {
+		h.fail(ctx, err)
+		return
+	}
+
+	items := make([]Response, len(list))
+	for i := range list {
+		items[i] = responseToREST(form, &list[i])
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": ResponseList{Items: items, Total: total, NextOffset: nextOffset(limit, offset, total)}})
+}
+
+func (h *handler) exportResponses(ctx *gin.Context) {
+	form, ok := h.authorizedForm(ctx, ctx.Param("id"), access.ViewResponses)
+	if !ok {
+		return
+	}
+
+	list, _, err := h.responses.List(ctx.Request.Context(), responseFilter(ctx, form))
+	if err != nil {
+		h.fail(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	ctx.Header("Content-Disposition", `attachment; filename="responses-`+form.ID+`.csv"`)
+	ctx.Status(http.StatusOK)
+	if err := responses.WriteCSV(ctx.Writer, form, list); err != nil {
+		ctx.Error(err)
+	}
+}
+
+func responseFilter(ctx *gin.Context, form *gomodel.Form) responses.ListParams {
+	return responses.ListParams{
+		FormID:   form.ID,
+		Statuses: ctx.QueryArray("status"),
+		Tags:     ctx.QueryArray("tag"),
+	}
+}
+
+func responseToREST(form *gomodel.Form, r *gomodel.FormResponse) Response {
+	questions := make(map[string]*gomodel.Question, len(form.Questions))
+	for i := range form.Questions {
+		questions[form.Questions[i].ID] = &form.Questions[i]
+	}
+
+	answers := make(map[string]interface{}, len(r.Answers))
+	for _, a := range r.Answers {
+		q, ok := questions[a.QuestionID]
+		if !ok {
+			continue
+		}
+		if value := answerValue(q, &a); value != nil {
+			answers[formschema.Name(q)] = value
+		}
+	}
+
+	return Response{
+		ID:        r.ID,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+		Status:    r.Status,
+		Tags:      append([]string{}, r.Tags...),
+		Answers:   answers,
+	}
+}
+
+func answerValue(q *gomodel.Question, a *gomodel.Answer) interface{} {
+	switch q.Type {
+	case gomodel.QuestionTypeBoolean:
+		if a.BoolValue != nil {
+			return *a.BoolValue
+		}
+	case gomodel.QuestionTypeNumber:
+		if a.NumberValue != nil {
+			return *a.NumberValue
+		}
+	case gomodel.QuestionTypeDate:
+		if a.DateValue != nil {
+			return a.DateValue.Format(time.RFC3339)
+		}
+	case gomodel.QuestionTypeSingleChoice:
+		if len(a.SelectedOptions) > 0 {
+			return a.SelectedOptions[0].ID
+		}
+	case gomodel.QuestionTypeMultipleChoice:
+		ids := make([]string, len(a.SelectedOptions))
+		for i, o := range a.SelectedOptions {
+			ids[i] = o.ID
+		}
+		return ids
+	default:
+		return a.TextValue
+	}
+	return nil
+}
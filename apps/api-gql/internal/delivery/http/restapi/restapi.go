@@ -0,0 +1,190 @@
+// Package restapi serves a versioned REST API under /api/v1 for integrations that do not
+// speak GraphQL. It goes through the same services and permission checks as the resolvers
+// and authenticates every call with an API key in the api-key header; each route names
+// the scope the key must grant.
+//
+// The routes are declared in a table (see routes.go) from which both the gin handlers and
+// the OpenAPI 3 document at /api/v1/openapi.json are built, so the document cannot drift
+// from what is served.
+package restapi
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/auth"
+	"github.com/TrySquadDF/formify/api-gql/internal/server"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/formdoc"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/forms"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/pubsub"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/responses"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/webhooks"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// BasePath is where the current version of the API is mounted
+const BasePath = "/api/v1"
+
+// maxBodySize bounds the size of a request body
+const maxBodySize = 1 << 20
+
+// defaultLimit and maxLimit bound the page size of list endpoints
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+)
+
+// userKey is where the authenticated user is kept in the gin context
+const userKey = "restapi.user"
+
+type Opts struct {
+	fx.In
+
+	Server    *server.Server
+	Auth      *auth.Auth
+	Database  *gorm.DB
+	Access    *access.Service
+	Forms     *forms.Service
+	Responses *responses.Service
+	Webhooks  *webhooks.Service
+	PubSub    *pubsub.Service
+}
+
+type handler struct {
+	auth      *auth.Auth
+	database  *gorm.DB
+	access    *access.Service
+	forms     *forms.Service
+	responses *responses.Service
+	webhooks  *webhooks.Service
+	pubsub    *pubsub.Service
+}
+
+// New registers the routes of the API and its OpenAPI document, which needs no key.
+func New(opts Opts) {
+	h := &handler{
+		auth:      opts.Auth,
+		database:  opts.Database,
+		access:    opts.Access,
+		forms:     opts.Forms,
+		responses: opts.Responses,
+		webhooks:  opts.Webhooks,
+		pubsub:    opts.PubSub,
+	}
+
+	document := openAPI(routes)
+	api := opts.Server.Group(BasePath)
+	api.GET("/openapi.json", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, document)
+	})
+
+	api.Use(h.authenticate)
+	for _, r := range routes {
+		handle, scope := r.handle, r.scope
+		api.Handle(r.method, r.path, func(ctx *gin.Context) {
+			if err := h.auth.RequireScope(ctx.Request.Context(), scope); err != nil {
+				h.fail(ctx, err)
+				return
+			}
+			handle(h, ctx)
+		})
+	}
+}
+
+// authenticate lets through only requests with a valid API key; sessions are not accepted.
+func (h *handler) authenticate(ctx *gin.Context) {
+	user, err := h.auth.GetAuthenticatedUserByApiKey(ctx.Request.Context())
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "a valid api key is required"})
+		return
+	}
+	ctx.Set(userKey, user)
+	ctx.Next()
+}
+
+func (h *handler) user(ctx *gin.Context) *gomodel.Users {
+	return ctx.MustGet(userKey).(*gomodel.Users)
+}
+
+// authorizedForm loads a form with its questions and options and checks that the caller
+// holds the permission on it. On failure the error response is already written.
+func (h *handler) authorizedForm(ctx *gin.Context, id string, permission access.Permission) (*gomodel.Form, bool) {
+	if _, err := h.access.Authorize(ctx.Request.Context(), id, h.user(ctx).ID, permission); err != nil {
+		h.fail(ctx, err)
+		return nil, false
+	}
+
+	var form gomodel.Form
+	if err := h.database.WithContext(ctx.Request.Context()).
+		Preload("Questions.Options").
+		First(&form, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = forms.ErrFormNotFound
+		}
+		h.fail(ctx, err)
+		return nil, false
+	}
+	return &form, true
+}
+
+// fail writes the error with the status that matches it.
+func (h *handler) fail(ctx *gin.Context, err error) {
+	var invalid *formdoc.ValidationError
+	if errors.As(err, &invalid) {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "issues": invalid.Issues})
+		return
+	}
+
+	var scopeErr *auth.ScopeError
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, access.ErrAccessDenied), errors.As(err, &scopeErr):
+		status = http.StatusForbidden
+	case errors.Is(err, access.ErrFormNotFound), errors.Is(err, forms.ErrFormNotFound),
+		errors.Is(err, webhooks.ErrWebhookNotFound), errors.Is(err, webhooks.ErrDeliveryNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, forms.ErrKeyTaken):
+		status = http.StatusConflict
+	case errors.Is(err, webhooks.ErrInvalidURL), errors.Is(err, webhooks.ErrPrivateAddress),
+		errors.Is(err, webhooks.ErrNoEvents), errors.Is(err, webhooks.ErrUnknownEvent):
+		status = http.StatusUnprocessableEntity
+	}
+	ctx.JSON(status, gin.H{"error": err.Error()})
+}
+
+// page reads the limit and offset query parameters. On failure the error response is
+// already written.
+func page(ctx *gin.Context) (limit, offset int, ok bool) {
+	limit, offset = defaultLimit, 0
+	if value := ctx.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxLimit {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(maxLimit)})
+			return 0, 0, false
+		}
+		limit = n
+	}
+	if value := ctx.Query("offset"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "offset must not be negative"})
+			return 0, 0, false
+		}
+		offset = n
+	}
+	return limit, offset, true
+}
+
+// nextOffset is the offset of the page after the current one, or nil on the last page.
+func nextOffset(limit, offset int, total int64) *int {
+	if int64(offset+limit) >= total {
+		return nil
+	}
+	next := offset + limit
+	return &next
+}
@@ -0,0 +1,173 @@
+package restapi
+
+import (
+	"net/http"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/formdoc"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/gin-gonic/gin"
+)
+
+// route declares an endpoint of the API. Path parameters use the gin syntax (":id") and
+// are documented as strings. JSON results are wrapped in {"data": ...}.
+type route struct {
+	method  string
+	path    string
+	id      string
+	summary string
+	tag     string
+	// scope is what the API key must grant
+	scope gomodel.ApiKeyScope
+	query []param
+	// body is a value of the type of the request body, or nil when there is none
+	body interface{}
+	// consumes lists the accepted body content types; JSON when empty
+	consumes []string
+	// result is a value of the type of the data returned, or nil when there is none
+	result interface{}
+	// produces is the content type of a result that is not JSON, written as is
+	produces string
+	status   int
+	handle   func(*handler, *gin.Context)
+}
+
+type paramKind string
+
+const (
+	paramString  paramKind = "string"
+	paramInteger paramKind = "integer"
+	// paramStrings may be repeated: ?status=NEW&status=DONE
+	paramStrings paramKind = "strings"
+)
+
+type param struct {
+	name        string
+	kind        paramKind
+	description string
+}
+
+var pageParams = []param{
+	{"limit", paramInteger, "Page size, 50 by default and at most 500"},
+	{"offset", paramInteger, "Number of items to skip"},
+}
+
+var responseFilterParams = []param{
+	{"status", paramStrings, "Only responses with one of these review statuses"},
+	{"tag", paramStrings, "Only responses with all of these tags"},
+}
+
+var documentTypes = []string{"application/json", "application/yaml"}
+
+var routes = []route{
+	{
+		method: http.MethodGet, path: "/forms", id: "listForms", tag: "Forms",
+		scope:   gomodel.ApiKeyScopeFormsRead,
+		summary: "List personal forms of the caller, or the forms of a workspace",
+		query: append([]param{
+			{"workspaceId", paramString, "Workspace to list; personal forms when omitted"},
+			{"tag", paramString, "Only forms with this tag"},
+		}, pageParams...),
+		result: FormList{}, status: http.StatusOK,
+		handle: (*handler).listForms,
+	},
+	{
+		method: http.MethodPost, path: "/forms", id: "createForm", tag: "Forms",
+		scope:   gomodel.ApiKeyScopeFormsWrite,
+		summary: "Create a form from a form document",
+		query:   []param{{"workspaceId", paramString, "Workspace to create the form in; personal when omitted"}},
+		body:    formdoc.Document{}, consumes: documentTypes,
+		result: Form{}, status: http.StatusCreated,
+		handle: (*handler).createForm,
+	},
+	{
+		method: http.MethodGet, path: "/forms/:id", id: "getForm", tag: "Forms",
+		scope:   gomodel.ApiKeyScopeFormsRead,
+		summary: "Get a form with its questions",
+		result:  Form{}, status: http.StatusOK,
+		handle: (*handler).getForm,
+	},
+	{
+		method: http.MethodPut, path: "/forms/:id", id: "replaceForm", tag: "Forms",
+		scope:   gomodel.ApiKeyScopeFormsWrite,
+		summary: "Replace the definition of a form with a form document",
+		body:    formdoc.Document{}, consumes: documentTypes,
+		result: Form{}, status: http.StatusOK,
+		handle: (*handler).replaceForm,
+	},
+	{
+		method: http.MethodDelete, path: "/forms/:id", id: "deleteForm", tag: "Forms",
+		scope:   gomodel.ApiKeyScopeFormsWrite,
+		summary: "Move a form to the trash",
+		status:  http.StatusNoContent,
+		handle:  (*handler).deleteForm,
+	},
+	{
+		method: http.MethodGet, path: "/forms/:id/responses", id: "listResponses", tag: "Responses",
+		scope:   gomodel.ApiKeyScopeResponsesRead,
+		summary: "List responses of a form, newest first, with answers keyed by question key or ID",
+		query:   append(append([]param{}, responseFilterParams...), pageParams...),
+		result:  ResponseList{}, status: http.StatusOK,
+		handle: (*handler).listResponses,
+	},
+	{
+		method: http.MethodGet, path: "/forms/:id/responses/export", id: "exportResponses", tag: "Responses",
+		scope:    gomodel.ApiKeyScopeResponsesRead,
+		summary:  "Export responses of a form as CSV, one column per question",
+		query:    responseFilterParams,
+		produces: "text/csv", status: http.StatusOK,
+		handle: (*handler).exportResponses,
+	},
+	{
+		method: http.MethodGet, path: "/forms/:id/webhooks", id: "listWebhooks", tag: "Webhooks",
+		scope:   gomodel.ApiKeyScopeFormsRead,
+		summary: "List webhooks of a form",
+		result:  []Webhook{}, status: http.StatusOK,
+		handle: (*handler).listWebhooks,
+	},
+	{
+		method: http.MethodPost, path: "/forms/:id/webhooks", id: "createWebhook", tag: "Webhooks",
+		scope:   gomodel.ApiKeyScopeFormsWrite,
+		summary: "Subscribe a form to events; the signing secret is returned only here",
+		body:    WebhookInput{},
+		result:  Webhook{}, status: http.StatusCreated,
+		handle: (*handler).createWebhook,
+	},
+	{
+		method: http.MethodGet, path: "/webhooks/:id", id: "getWebhook", tag: "Webhooks",
+		scope:   gomodel.ApiKeyScopeFormsRead,
+		summary: "Get a webhook",
+		result:  Webhook{}, status: http.StatusOK,
+		handle: (*handler).getWebhook,
+	},
+	{
+		method: http.MethodPatch, path: "/webhooks/:id", id: "updateWebhook", tag: "Webhooks",
+		scope:   gomodel.ApiKeyScopeFormsWrite,
+		summary: "Change the url, events or state of a webhook",
+		body:    WebhookUpdate{},
+		result:  Webhook{}, status: http.StatusOK,
+		handle: (*handler).updateWebhook,
+	},
+	{
+		method: http.MethodDelete, path: "/webhooks/:id", id: "deleteWebhook", tag: "Webhooks",
+		scope:   gomodel.ApiKeyScopeFormsWrite,
+		summary: "Delete a webhook and its delivery log",
+		status:  http.StatusNoContent,
+		handle:  (*handler).deleteWebhook,
+	},
+	{
+		method: http.MethodGet, path: "/webhooks/:id/deliveries", id: "listWebhookDeliveries", tag: "Webhooks",
+		// The request bodies hold the answers
+		scope:   gomodel.ApiKeyScopeResponsesRead,
+		summary: "List recent deliveries of a webhook, newest first",
+		query:   []param{{"limit", paramInteger, "Number of deliveries, 50 by default and at most 500"}},
+		result:  []WebhookDelivery{}, status: http.StatusOK,
+		handle: (*handler).listWebhookDeliveries,
+	},
+	{
+		method: http.MethodPost, path: "/webhook-deliveries/:id/replay", id: "replayWebhookDelivery", tag: "Webhooks",
+		scope:   gomodel.ApiKeyScopeFormsWrite,
+		summary: "Send the payload of a delivery again",
+		result:  WebhookDelivery{}, status: http.StatusCreated,
+		handle: (*handler).replayWebhookDelivery,
+	},
+}
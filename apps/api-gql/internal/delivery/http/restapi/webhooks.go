@@ -0,0 +1,245 @@
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/webhooks"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/gin-gonic/gin"
+)
+
+type Webhook struct {
+	ID        string                 `json:"id"`
+	FormID    string                 `json:"formId"`
+	URL       string                 `json:"url"`
+	Events    []gomodel.WebhookEvent `json:"events"`
+	Enabled   bool                   `json:"enabled"`
+	CreatedAt time.Time              `json:"createdAt"`
+	// Secret signs the deliveries; returned only when the webhook is created
+	Secret *string `json:"secret,omitempty"`
+}
+
+type WebhookInput struct {
+	URL    string                 `json:"url"`
+	Events []gomodel.WebhookEvent `json:"events"`
+	// Secret is generated when omitted
+	Secret *string `json:"secret,omitempty"`
+}
+
+type WebhookUpdate struct {
+	URL     *string                `json:"url,omitempty"`
+	Events  []gomodel.WebhookEvent `json:"events,omitempty"`
+	Enabled *bool                  `json:"enabled,omitempty"`
+}
+
+type WebhookDelivery struct {
+	ID             string                        `json:"id"`
+	WebhookID      string                        `json:"webhookId"`
+	Event          gomodel.WebhookEvent          `json:"event"`
+	Status         gomodel.WebhookDeliveryStatus `json:"status"`
+	Attempts       int32                         `json:"attempts"`
+	RequestBody    string                        `json:"requestBody"`
+	ResponseStatus *int32                        `json:"responseStatus"`
+	ResponseBody   *string                       `json:"responseBody"`
+	Error          *string                       `json:"error"`
+	CreatedAt      time.Time                     `json:"createdAt"`
+	NextAttemptAt  time.Time                     `json:"nextAttemptAt"`
+	DeliveredAt    *time.Time                    `json:"deliveredAt"`
+}
+
+func (h *handler) listWebhooks(ctx *gin.Context) {
+	form, ok := h.authorizedForm(ctx, ctx.Param("id"), access.EditForm)
+	if !ok {
+		return
+	}
+
+	hooks, err := h.webhooks.List(ctx.Request.Context(), form.ID)
+	if err != nil {
+		h.fail(ctx, err)
+		return
+	}
+
+	result := make([]Webhook, len(hooks))
+	for i := range hooks {
+		result[i] = webhookToREST(&hooks[i])
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+func (h *handler) createWebhook(ctx *gin.Context) {
+	form, ok := h.authorizedForm(ctx, ctx.Param("id"), access.EditForm)
+	if !ok {
+		return
+	}
+
+	var input WebhookInput
+	if !readJSON(ctx, &input) {
+		return
+	}
+
+	params := webhooks.WebhookParams{URL: input.URL, Events: input.Events}
+	if input.Secret != nil {
+		params.Secret = *input.Secret
+	}
+	hook, secret, err := h.webhooks.Create(ctx.Request.Context(), form.ID, params)
+	if err != nil {
+		h.fail(ctx, err)
+		return
+	}
+
+	result := webhookToREST(hook)
+	result.Secret = &secret
+	ctx.JSON(http.StatusCreated, gin.H{"data": result})
+}
+
+func (h *handler) getWebhook(ctx *gin.Context) {
+	hook, ok := h.editableWebhook(ctx, ctx.Param("id"))
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": webhookToREST(hook)})
+}
+
+func (h *handler) updateWebhook(ctx *gin.Context) {
+	hook, ok := h.editableWebhook(ctx, ctx.Param("id"))
+	if !ok {
+		return
+	}
+
+	var input WebhookUpdate
+	if !readJSON(ctx, &input) {
+		return
+	}
+
+	update := webhooks.WebhookUpdate{URL: input.URL, Events: input.Events, Enabled: input.Enabled}
+	if err := h.webhooks.Update(ctx.Request.Context(), hook, update); err != nil {
+		h.fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": webhookToREST(hook)})
+}
+
+func (h *handler) deleteWebhook(ctx *gin.Context) {
+	hook, ok := h.editableWebhook(ctx, ctx.Param("id"))
+	if !ok {
+		return
+	}
+
+	if err := h.webhooks.Delete(ctx.Request.Context(), hook.ID); err != nil {
+		h.fail(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *handler) listWebhookDeliveries(ctx *gin.Context) {
+	hook, ok := h.editableWebhook(ctx, ctx.Param("id"))
+	if !ok {
+		return
+	}
+
+	limit := defaultLimit
+	if value := ctx.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxLimit {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(maxLimit)})
+			return
+		}
+		limit = n
+	}
+
+	deliveries, err := h.webhooks.Deliveries(ctx.Request.Context(), hook.ID, limit)
+	if err != nil {
+		h.fail(ctx, err)
+		return
+	}
+
+	result := make([]WebhookDelivery, len(deliveries))
+	for i := range deliveries {
+		result[i] = webhookDeliveryToREST(&deliveries[i])
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+func (h *handler) replayWebhookDelivery(ctx *gin.Context) {
+	delivery, err := h.webhooks.FindDelivery(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		h.fail(ctx, err)
+		return
+	}
+	if _, ok := h.editableWebhook(ctx, delivery.WebhookID); !ok {
+		return
+	}
+
+	replay, err := h.webhooks.Replay(ctx.Request.Context(), delivery)
+	if err != nil {
+		h.fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"data": webhookDeliveryToREST(replay)})
+}
+
+// editableWebhook loads a webhook and checks that the caller may edit its form. On
+// failure the error response is already written.
+func (h *handler) editableWebhook(ctx *gin.Context, id string) (*gomodel.Webhook, bool) {
+	hook, err := h.webhooks.Find(ctx.Request.Context(), id)
+	if err != nil {
+		h.fail(ctx, err)
+		return nil, false
+	}
+
+	if _, err := h.access.Authorize(ctx.Request.Context(), hook.FormID, h.user(ctx).ID, access.EditForm); err != nil {
+		h.fail(ctx, err)
+		return nil, false
+	}
+	return hook, true
+}
+
+// readJSON decodes the body into v, rejecting unknown fields. On failure the error
+// response is already written.
+func readJSON(ctx *gin.Context, v interface{}) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid body: " + err.Error()})
+		return false
+	}
+	return true
+}
+
+func webhookToREST(w *gomodel.Webhook) Webhook {
+	events := make([]gomodel.WebhookEvent, len(w.Events))
+	for i, e := range w.Events {
+		events[i] = gomodel.WebhookEvent(e)
+	}
+
+	return Webhook{
+		ID:        w.ID,
+		FormID:    w.FormID,
+		URL:       w.URL,
+		Events:    events,
+		Enabled:   w.Enabled,
+		CreatedAt: w.CreatedAt,
+	}
+}
+
+func webhookDeliveryToREST(d *gomodel.WebhookDelivery) WebhookDelivery {
+	return WebhookDelivery{
+		ID:             d.ID,
+		WebhookID:      d.WebhookID,
+		Event:          d.Event,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		RequestBody:    d.RequestBody,
+		ResponseStatus: d.ResponseStatus,
+		ResponseBody:   d.ResponseBody,
+		Error:          d.Error,
+		CreatedAt:      d.CreatedAt,
+		NextAttemptAt:  d.NextAttemptAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+}
@@ -0,0 +1,163 @@
+package submit
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/auth"
+	"github.com/TrySquadDF/formify/api-gql/internal/server"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/formschema"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/responses"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// maxBodySize bounds the size of a submitted JSON object
+const maxBodySize = 1 << 20
+
+type Opts struct {
+	fx.In
+
+	Server    *server.Server
+	Auth      *auth.Auth
+	Database  *gorm.DB
+	Access    *access.Service
+	Responses *responses.Service
+}
+
+type handler struct {
+	auth      *auth.Auth
+	database  *gorm.DB
+	access    *access.Service
+	responses *responses.Service
+}
+
+// New serves the JSON Schema of a form and accepts plain JSON submissions to it, for
+// services that do not speak GraphQL. Private forms need a session or an API key; a key
+// needs FORMS_READ for the schema and RESPONSES_WRITE to submit.
+func New(opts Opts) {
+	h := &handler{
+		auth:      opts.Auth,
+		database:  opts.Database,
+		access:    opts.Access,
+		responses: opts.Responses,
+	}
+
+	opts.Server.GET("/forms/:id/schema", h.schema)
+	opts.Server.POST("/forms/:id/responses", h.submit)
+}
+
+func (h *handler) schema(ctx *gin.Context) {
+	form, ok := h.viewableForm(ctx, gomodel.ApiKeyScopeFormsRead)
+	if !ok {
+		return
+	}
+
+	data, err := json.Marshal(formschema.Generate(form))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.Data(http.StatusOK, "application/schema+json", data)
+}
+
+// submit stores a JSON object keyed by question key or ID as a response, the same way
+// submitFormResponse does. An Idempotency-Key header makes retries safe.
+func (h *handler) submit(ctx *gin.Context) {
+	form, ok := h.viewableForm(ctx, gomodel.ApiKeyScopeResponsesWrite)
+	if !ok {
+		return
+	}
+
+	var body map[string]interface{}
+	decoder := json.NewDecoder(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBodySize))
+	if err := decoder.Decode(&body); err != nil || body == nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "the body must be a JSON object"})
+		return
+	}
+
+	answers, err := formschema.Validate(form, body)
+	var invalid *formschema.ValidationError
+	if errors.As(err, &invalid) {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "issues": invalid.Issues})
+		return
+	}
+
+	params := responses.SubmitParams{Form: form, Answers: answers, RemoteIP: ctx.ClientIP()}
+	if userID, err := h.auth.GetUserIDFromContext(ctx.Request.Context()); err == nil {
+		params.SubmitterID = userID
+	}
+	if key := ctx.GetHeader("Idempotency-Key"); key != "" {
+		params.IdempotencyKey = &key
+	}
+
+	submission, err := h.responses.Submit(ctx.Request.Context(), params)
+	switch {
+	case errors.Is(err, responses.ErrFormClosed), errors.Is(err, responses.ErrDuplicateDeleted):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// A repeat only learns the ID of the stored response
+	if submission.Duplicate {
+		ctx.JSON(http.StatusOK, gin.H{"data": gin.H{"id": submission.Response.ID}})
+		return
+	}
+	data := gin.H{
+		"id":        submission.Response.ID,
+		"createdAt": submission.Response.CreatedAt.Format(time.RFC3339),
+	}
+	if submission.EditToken != "" {
+		data["editToken"] = submission.EditToken
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"data": data})
+}
+
+// viewableForm loads the form of the request with its questions and checks that it may be
+// answered by the caller. A request with an API key needs the scope even for a public
+// form. On failure the error response is already written.
+func (h *handler) viewableForm(ctx *gin.Context, scope gomodel.ApiKeyScope) (*gomodel.Form, bool) {
+	var scopeErr *auth.ScopeError
+	if err := h.auth.RequireScope(ctx.Request.Context(), scope); errors.As(err, &scopeErr) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return nil, false
+	} else if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "authorization required"})
+		return nil, false
+	}
+
+	var form gomodel.Form
+	if err := h.database.WithContext(ctx.Request.Context()).
+		Preload("Questions.Options").
+		First(&form, "id = ?", ctx.Param("id")).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "form not found"})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return nil, false
+	}
+
+	if form.Access != gomodel.FormAccessPrivate {
+		return &form, true
+	}
+
+	user, err := h.auth.GetAuthenticatedUser(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "authorization required"})
+		return nil, false
+	}
+	if _, err := h.access.Authorize(ctx.Request.Context(), form.ID, user.ID, access.ViewForm); err != nil {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return nil, false
+	}
+	return &form, true
+}
@@ -0,0 +1,499 @@
+// Package redistest lets services be tested without Redis. Start runs an in-memory
+// server that speaks enough of the Redis protocol for the commands the services use:
+// strings with expiry, sorted sets, transactions and pub/sub.
+package redistest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Server is the in-memory Redis. Keys expire by its clock, which FastForward moves.
+type Server struct {
+	mu      sync.Mutex
+	offset  time.Duration
+	strings map[string]string
+	zsets   map[string]map[string]float64
+	expires map[string]time.Time
+	subs    map[string]map[*conn]bool
+}
+
+// Start runs a server for the test and returns a client connected to it.
+func Start(t testing.TB) (*redis.Client, *Server) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{
+		strings: make(map[string]string),
+		zsets:   make(map[string]map[string]float64),
+		expires: make(map[string]time.Time),
+		subs:    make(map[string]map[*conn]bool),
+	}
+	go s.serve(listener)
+
+	client := redis.NewClient(&redis.Options{Addr: listener.Addr().String(), Protocol: 2, DisableIdentity: true})
+	t.Cleanup(func() {
+		client.Close()
+		listener.Close()
+	})
+	return client, s
+}
+
+// FastForward moves the clock of the server, expiring keys as if d had passed.
+func (s *Server) FastForward(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offset += d
+}
+
+// TTL returns the time the key has left, or zero if it does not expire or does not exist.
+func (s *Server) TTL(key string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire(key)
+	if at, ok := s.expires[key]; ok {
+		return at.Sub(s.now())
+	}
+	return 0
+}
+
+// Exists reports whether the key holds a value.
+func (s *Server) Exists(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.exists(key)
+}
+
+func (s *Server) now() time.Time {
+	return time.Now().Add(s.offset)
+}
+
+// expire drops the key if its time is up.
+func (s *Server) expire(key string) {
+	if at, ok := s.expires[key]; ok && !s.now().Before(at) {
+		s.del(key)
+	}
+}
+
+func (s *Server) exists(key string) bool {
+	s.expire(key)
+	_, isString := s.strings[key]
+	_, isZSet := s.zsets[key]
+	return isString || isZSet
+}
+
+func (s *Server) del(key string) bool {
+	existed := false
+	if _, ok := s.strings[key]; ok {
+		existed = true
+	}
+	if _, ok := s.zsets[key]; ok {
+		existed = true
+	}
+	delete(s.strings, key)
+	delete(s.zsets, key)
+	delete(s.expires, key)
+	return existed
+}
+
+func (s *Server) serve(listener net.Listener) {
+	for {
+		nc, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		c := &conn{server: s, nc: nc, w: bufio.NewWriter(nc)}
+		go c.serve()
+	}
+}
+
+// conn is one client connection. Replies and pushed messages share the writer.
+type conn struct {
+	server *Server
+	nc     net.Conn
+
+	wmu sync.Mutex
+	w   *bufio.Writer
+
+	queue      [][]string
+	queuing    bool
+	subscribed map[string]bool
+}
+
+func (c *conn) serve() {
+	defer c.close()
+
+	r := bufio.NewReader(c.nc)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+
+		name := strings.ToUpper(args[0])
+		switch {
+		case name == "MULTI":
+			c.queuing, c.queue = true, nil
+			c.reply(func(w *bufio.Writer) { writeStatus(w, "OK") })
+		case name == "EXEC" && c.queuing:
+			c.queuing = false
+			replies := make([]func(*bufio.Writer), len(c.queue))
+			c.server.mu.Lock()
+			for i, cmd := range c.queue {
+				replies[i] = c.server.run(cmd)
+			}
+			c.server.mu.Unlock()
+			c.reply(func(w *bufio.Writer) {
+				fmt.Fprintf(w, "*%d\r\n", len(replies))
+				for _, reply := range replies {
+					reply(w)
+				}
+			})
+		case c.queuing:
+			c.queue = append(c.queue, args)
+			c.reply(func(w *bufio.Writer) { writeStatus(w, "QUEUED") })
+		case name == "SUBSCRIBE":
+			c.subscribe(args[1:])
+		case name == "UNSUBSCRIBE":
+			c.unsubscribe(args[1:])
+		case name == "PING" && len(c.subscribed) > 0:
+			c.reply(func(w *bufio.Writer) { writeBulks(w, "pong", "") })
+		case name == "PUBLISH":
+			n := c.server.publish(args[1], args[2])
+			c.reply(func(w *bufio.Writer) { writeInt(w, int64(n)) })
+		default:
+			c.server.mu.Lock()
+			reply := c.server.run(args)
+			c.server.mu.Unlock()
+			c.reply(reply)
+		}
+	}
+}
+
+func (c *conn) reply(write func(*bufio.Writer)) {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	write(c.w)
+	c.w.Flush()
+}
+
+func (c *conn) subscribe(channels []string) {
+	s := c.server
+	s.mu.Lock()
+	if c.subscribed == nil {
+		c.subscribed = make(map[string]bool)
+	}
+	counts := make([]int, len(channels))
+	for i, channel := range channels {
+		c.subscribed[channel] = true
+		if s.subs[channel] == nil {
+			s.subs[channel] = make(map[*conn]bool)
+		}
+		s.subs[channel][c] = true
+		counts[i] = len(c.subscribed)
+	}
+	s.mu.Unlock()
+
+	c.reply(func(w *bufio.Writer) {
+		for i, channel := range channels {
+			fmt.Fprint(w, "*3\r\n")
+			writeBulk(w, "subscribe")
+			writeBulk(w, channel)
+			writeInt(w, int64(counts[i]))
+		}
+	})
+}
+
+func (c *conn) unsubscribe(channels []string) {
+	s := c.server
+	s.mu.Lock()
+	if len(channels) == 0 {
+		for channel := range c.subscribed {
+			channels = append(channels, channel)
+		}
+	}
+	for _, channel := range channels {
+		delete(c.subscribed, channel)
+		delete(s.subs[channel], c)
+	}
+	left := len(c.subscribed)
+	s.mu.Unlock()
+
+	c.reply(func(w *bufio.Writer) {
+		for _, channel := range channels {
+			fmt.Fprint(w, "*3\r\n")
+			writeBulk(w, "unsubscribe")
+			writeBulk(w, channel)
+			writeInt(w, int64(left))
+		}
+	})
+}
+
+func (c *conn) close() {
+	c.server.mu.Lock()
+	for channel := range c.subscribed {
+		delete(c.server.subs[channel], c)
+	}
+	c.server.mu.Unlock()
+	c.nc.Close()
+}
+
+func (s *Server) publish(channel, payload string) int {
+	s.mu.Lock()
+	receivers := make([]*conn, 0, len(s.subs[channel]))
+	for c := range s.subs[channel] {
+		receivers = append(receivers, c)
+	}
+	s.mu.Unlock()
+
+	for _, c := range receivers {
+		c.reply(func(w *bufio.Writer) { writeBulks(w, "message", channel, payload) })
+	}
+	return len(receivers)
+}
+
+// run executes a command that works on keys. The caller holds the lock.
+func (s *Server) run(args []string) func(*bufio.Writer) {
+	name := strings.ToUpper(args[0])
+	switch name {
+	case "PING":
+		return func(w *bufio.Writer) { writeStatus(w, "PONG") }
+
+	case "GET":
+		s.expire(args[1])
+		value, ok := s.strings[args[1]]
+		if !ok {
+			return writeNil
+		}
+		return func(w *bufio.Writer) { writeBulk(w, value) }
+
+	case "SET":
+		s.del(args[1])
+		s.strings[args[1]] = args[2]
+		for i := 3; i+1 < len(args); i += 2 {
+			n, _ := strconv.ParseInt(args[i+1], 10, 64)
+			switch strings.ToUpper(args[i]) {
+			case "EX":
+				s.expires[args[1]] = s.now().Add(time.Duration(n) * time.Second)
+			case "PX":
+				s.expires[args[1]] = s.now().Add(time.Duration(n) * time.Millisecond)
+			}
+		}
+		return func(w *bufio.Writer) { writeStatus(w, "OK") }
+
+	case "MGET":
+		values := make([]*string, len(args)-1)
+		for i, key := range args[1:] {
+			s.expire(key)
+			if value, ok := s.strings[key]; ok {
+				values[i] = &value
+			}
+		}
+		return func(w *bufio.Writer) {
+			fmt.Fprintf(w, "*%d\r\n", len(values))
+			for _, value := range values {
+				if value == nil {
+					writeNil(w)
+				} else {
+					writeBulk(w, *value)
+				}
+			}
+		}
+
+	case "DEL":
+		var n int64
+		for _, key := range args[1:] {
+			s.expire(key)
+			if s.del(key) {
+				n++
+			}
+		}
+		return func(w *bufio.Writer) { writeInt(w, n) }
+
+	case "EXPIRE", "PEXPIRE":
+		if !s.exists(args[1]) {
+			return func(w *bufio.Writer) { writeInt(w, 0) }
+		}
+		n, _ := strconv.ParseInt(args[2], 10, 64)
+		unit := time.Second
+		if name == "PEXPIRE" {
+			unit = time.Millisecond
+		}
+		s.expires[args[1]] = s.now().Add(time.Duration(n) * unit)
+		return func(w *bufio.Writer) { writeInt(w, 1) }
+
+	case "ZADD":
+		s.expire(args[1])
+		set := s.zsets[args[1]]
+		if set == nil {
+			set = make(map[string]float64)
+			s.zsets[args[1]] = set
+		}
+		var added int64
+		for i := 2; i+1 < len(args); i += 2 {
+			score, err := strconv.ParseFloat(args[i], 64)
+			if err != nil {
+				return writeErr("ERR value is not a valid float")
+			}
+			if _, ok := set[args[i+1]]; !ok {
+				added++
+			}
+			set[args[i+1]] = score
+		}
+		return func(w *bufio.Writer) { writeInt(w, added) }
+
+	case "ZREM":
+		s.expire(args[1])
+		var n int64
+		for _, member := range args[2:] {
+			if _, ok := s.zsets[args[1]][member]; ok {
+				delete(s.zsets[args[1]], member)
+				n++
+			}
+		}
+		s.dropEmpty(args[1])
+		return func(w *bufio.Writer) { writeInt(w, n) }
+
+	case "ZREMRANGEBYSCORE":
+		s.expire(args[1])
+		min, max := parseScore(args[2]), parseScore(args[3])
+		var n int64
+		for member, score := range s.zsets[args[1]] {
+			if score >= min && score <= max {
+				delete(s.zsets[args[1]], member)
+				n++
+			}
+		}
+		s.dropEmpty(args[1])
+		return func(w *bufio.Writer) { writeInt(w, n) }
+
+	case "ZRANGE":
+		s.expire(args[1])
+		members := s.sortedMembers(args[1])
+		start, _ := strconv.Atoi(args[2])
+		stop, _ := strconv.Atoi(args[3])
+		if stop < 0 {
+			stop += len(members)
+		}
+		if start < 0 {
+			start += len(members)
+		}
+		if start > stop || start >= len(members) {
+			members = nil
+		} else {
+			members = members[start:min(stop+1, len(members))]
+		}
+		return func(w *bufio.Writer) { writeBulks(w, members...) }
+
+	case "ZSCORE":
+		s.expire(args[1])
+		score, ok := s.zsets[args[1]][args[2]]
+		if !ok {
+			return writeNil
+		}
+		return func(w *bufio.Writer) { writeBulk(w, strconv.FormatFloat(score, 'f', -1, 64)) }
+	}
+
+	return writeErr("ERR unknown command '" + args[0] + "'")
+}
+
+func (s *Server) dropEmpty(key string) {
+	if set, ok := s.zsets[key]; ok && len(set) == 0 {
+		s.del(key)
+	}
+}
+
+func (s *Server) sortedMembers(key string) []string {
+	set := s.zsets[key]
+	members := make([]string, 0, len(set))
+	for member := range set {
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if set[members[i]] != set[members[j]] {
+			return set[members[i]] < set[members[j]]
+		}
+		return members[i] < members[j]
+	})
+	return members
+}
+
+func parseScore(raw string) float64 {
+	switch raw {
+	case "-inf":
+		return math.Inf(-1)
+	case "+inf", "inf":
+		return math.Inf(1)
+	}
+	score, _ := strconv.ParseFloat(raw, 64)
+	return score
+}
+
+// readCommand reads a command sent as an array of bulk strings.
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return strings.Fields(line), nil
+	}
+
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("redistest: bad command %q", line)
+	}
+	args := make([]string, n)
+	for i := range args {
+		header, err := readLine(r)
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimPrefix(header, "$"))
+		if err != nil {
+			return nil, fmt.Errorf("redistest: bad argument %q", header)
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+	return args, nil
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+func writeStatus(w *bufio.Writer, status string) { fmt.Fprintf(w, "+%s\r\n", status) }
+func writeInt(w *bufio.Writer, n int64)          { fmt.Fprintf(w, ":%d\r\n", n) }
+func writeBulk(w *bufio.Writer, s string)        { fmt.Fprintf(w, "$%d\r\n%s\r\n", len(s), s) }
+func writeNil(w *bufio.Writer)                   { fmt.Fprint(w, "$-1\r\n") }
+
+func writeBulks(w *bufio.Writer, values ...string) {
+	fmt.Fprintf(w, "*%d\r\n", len(values))
+	for _, value := range values {
+		writeBulk(w, value)
+	}
+}
+
+func writeErr(message string) func(*bufio.Writer) {
+	return func(w *bufio.Writer) { fmt.Fprintf(w, "-%s\r\n", message) }
+}
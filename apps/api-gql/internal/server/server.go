@@ -7,6 +7,7 @@ import (
 	"github.com/TrySquadDF/formify/api-gql/internal/auth"
 	"github.com/TrySquadDF/formify/api-gql/internal/server/gincontext"
 	"github.com/TrySquadDF/formify/api-gql/internal/server/middleware"
+	"github.com/TrySquadDF/formify/lib/config"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/fx"
@@ -18,8 +19,12 @@ type Opts struct {
 
 	Sessions           *auth.Auth
 	Middlewares        *middleware.Middleware
+	Config             config.Config
 }
 
+// AllowedOrigins are the frontend origins allowed to call the API from a browser
+var AllowedOrigins = []string{"http://localhost:3000", "https://localhost:3000"}
+
 type Server struct {
 	*gin.Engine
 }
@@ -30,12 +35,17 @@ func New(opts Opts) *Server {
 		s,
 	}
 
+	// ClientIP reads X-Forwarded-For only from the configured proxies
+	if err := s.SetTrustedProxies(opts.Config.TrustedProxies); err != nil {
+		panic(err)
+	}
+
 	s.Use(gin.Logger())
 
 	s.Use(
 		cors.New(
 			cors.Config{
-				AllowOrigins:     []string{"http://localhost:3000", "https://localhost:3000"}, 
+				AllowOrigins:     AllowedOrigins,
 				AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 				AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
 				ExposeHeaders:    []string{"Content-Length"},
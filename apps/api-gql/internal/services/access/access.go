@@ -0,0 +1,175 @@
+package access
+
+import (
+	"context"
+	"errors"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAccessDenied = errors.New("access denied")
+	ErrFormNotFound = errors.New("form not found")
+)
+
+// Permission is something a user may do with a form.
+type Permission int
+
+const (
+	// ViewForm allows opening a private form and seeing its questions
+	ViewForm Permission = iota
+	// ViewResponses allows reading responses, their review history and analytics
+	ViewResponses
+	// EditForm allows changing the form and its questions, reviewing and trashing
+	// responses, closing the form and managing its webhooks
+	EditForm
+	// ManageForm allows managing members, moving the form to the trash and purging it
+	ManageForm
+)
+
+// minRole is the lowest role granted each permission.
+var minRole = map[Permission]gomodel.FormRole{
+	ViewForm:      gomodel.FormRoleViewer,
+	ViewResponses: gomodel.FormRoleAnalyst,
+	EditForm:      gomodel.FormRoleEditor,
+	ManageForm:    gomodel.FormRoleOwner,
+}
+
+var roleRanks = map[gomodel.FormRole]int{
+	gomodel.FormRoleViewer:  1,
+	gomodel.FormRoleAnalyst: 2,
+	gomodel.FormRoleEditor:  3,
+	gomodel.FormRoleOwner:   4,
+}
+
+// ValidRole reports whether role is one of the known form roles.
+func ValidRole(role gomodel.FormRole) bool {
+	_, ok := roleRanks[role]
+	return ok
+}
+
+// Allows reports whether the role grants the permission. The empty role grants nothing.
+func Allows(role gomodel.FormRole, permission Permission) bool {
+	rank, ok := roleRanks[role]
+	return ok && rank >= roleRanks[minRole[permission]]
+}
+
+type Opts struct {
+	fx.In
+
+	Database *gorm.DB
+}
+
+type Service struct {
+	database *gorm.DB
+}
+
+func New(opts Opts) *Service {
+	return &Service{
+		database: opts.Database,
+	}
+}
+
+// Role returns the role of the user in the form, trashed forms included, or the
+// empty role when the user has none. Admins of the workspace of the form act as its
+// owner and the other workspace members can at least view it. The author of a
+// workspace form owns it only while they are a member of the workspace.
+func (s *Service) Role(ctx context.Context, formID, userID string) (gomodel.FormRole, error) {
+	var form gomodel.Form
+	if err := s.database.WithContext(ctx).Unscoped().Select("id", "owner_id", "workspaceId").
+		First(&form, "id = ?", formID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrFormNotFound
+		}
+		return "", err
+	}
+
+	if form.WorkspaceID == nil && form.OwnerID == userID {
+		return gomodel.FormRoleOwner, nil
+	}
+
+	var role gomodel.FormRole
+	if form.WorkspaceID != nil {
+		workspaceRole, err := s.WorkspaceRole(ctx, *form.WorkspaceID, userID)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case workspaceRole == gomodel.WorkspaceRoleAdmin:
+			return gomodel.FormRoleOwner, nil
+		case workspaceRole == gomodel.WorkspaceRoleMember && form.OwnerID == userID:
+			return gomodel.FormRoleOwner, nil
+		case workspaceRole == gomodel.WorkspaceRoleMember:
+			role = gomodel.FormRoleViewer
+		}
+	}
+
+	var member gomodel.FormMember
+	err := s.database.WithContext(ctx).
+		Where("form_id = ? AND user_id = ?", formID, userID).
+		Take(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return role, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if roleRanks[member.Role] > roleRanks[role] {
+		role = member.Role
+	}
+	return role, nil
+}
+
+// ViewableForms narrows a query on forms to those the user holds ViewForm on: personal
+// forms of the user, forms of the workspaces the user is a member of and forms shared
+// with the user. It follows the same rules as Role.
+func (s *Service) ViewableForms(query *gorm.DB, userID string) *gorm.DB {
+	workspaces := s.database.Model(&gomodel.WorkspaceMember{}).Select("workspace_id").Where("user_id = ?", userID)
+	shared := s.database.Model(&gomodel.FormMember{}).Select("form_id").Where("user_id = ?", userID)
+
+	return query.Where(`(forms.owner_id = ? AND forms."workspaceId" IS NULL) OR forms."workspaceId" IN (?) OR forms.id IN (?)`,
+		userID, workspaces, shared)
+}
+
+// Authorize fails with ErrAccessDenied unless the user holds the permission on the form.
+func (s *Service) Authorize(ctx context.Context, formID, userID string, permission Permission) (gomodel.FormRole, error) {
+	role, err := s.Role(ctx, formID, userID)
+	if err != nil {
+		return "", err
+	}
+	if !Allows(role, permission) {
+		return "", ErrAccessDenied
+	}
+	return role, nil
+}
+
+// WorkspaceRole returns the role of the user in the workspace or the empty role when
+// the user is not a member.
+func (s *Service) WorkspaceRole(ctx context.Context, workspaceID, userID string) (gomodel.WorkspaceRole, error) {
+	var member gomodel.WorkspaceMember
+	err := s.database.WithContext(ctx).
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Take(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return member.Role, nil
+}
+
+// AuthorizeWorkspace fails with ErrAccessDenied unless the user is a member of the
+// workspace, or an admin of it when admin is set.
+func (s *Service) AuthorizeWorkspace(ctx context.Context, workspaceID, userID string, admin bool) (gomodel.WorkspaceRole, error) {
+	role, err := s.WorkspaceRole(ctx, workspaceID, userID)
+	if err != nil {
+		return "", err
+	}
+	if role == "" || (admin && role != gomodel.WorkspaceRoleAdmin) {
+		return "", ErrAccessDenied
+	}
+	return role, nil
+}
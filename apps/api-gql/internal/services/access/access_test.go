@@ -0,0 +1,42 @@
+package access
+
+import (
+	"testing"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+func TestAllows(t *testing.T) {
+	// Each role, in rank order, and the permissions it is expected to hold
+	tests := []struct {
+		role gomodel.FormRole
+		want map[Permission]bool
+	}{
+		{"", map[Permission]bool{}},
+		{"ADMIN", map[Permission]bool{}},
+		{gomodel.FormRoleViewer, map[Permission]bool{ViewForm: true}},
+		{gomodel.FormRoleAnalyst, map[Permission]bool{ViewForm: true, ViewResponses: true}},
+		{gomodel.FormRoleEditor, map[Permission]bool{ViewForm: true, ViewResponses: true, EditForm: true}},
+		{gomodel.FormRoleOwner, map[Permission]bool{ViewForm: true, ViewResponses: true, EditForm: true, ManageForm: true}},
+	}
+	for _, tt := range tests {
+		for _, permission := range []Permission{ViewForm, ViewResponses, EditForm, ManageForm} {
+			if got := Allows(tt.role, permission); got != tt.want[permission] {
+				t.Errorf("Allows(%q, %d) = %v, want %v", tt.role, permission, got, tt.want[permission])
+			}
+		}
+	}
+}
+
+func TestValidRole(t *testing.T) {
+	for _, role := range []gomodel.FormRole{gomodel.FormRoleViewer, gomodel.FormRoleAnalyst, gomodel.FormRoleEditor, gomodel.FormRoleOwner} {
+		if !ValidRole(role) {
+			t.Errorf("ValidRole(%q) = false", role)
+		}
+	}
+	for _, role := range []gomodel.FormRole{"", "owner", "ADMIN"} {
+		if ValidRole(role) {
+			t.Errorf("ValidRole(%q) = true", role)
+		}
+	}
+}
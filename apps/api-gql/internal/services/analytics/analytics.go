@@ -0,0 +1,22 @@
+package analytics
+
+import (
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type Opts struct {
+	fx.In
+
+	Database *gorm.DB
+}
+
+type Service struct {
+	database *gorm.DB
+}
+
+func New(opts Opts) *Service {
+	return &Service{
+		database: opts.Database,
+	}
+}
@@ -0,0 +1,477 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultBucketCount = 5
+	// maxBucketCount bounds the categories a NUMBER question is split into
+	maxBucketCount = 100
+)
+
+var (
+	ErrUnsupportedQuestion = errors.New("question type is not supported in cross tabulation")
+	ErrQuestionNotInForm   = errors.New("question does not belong to the form")
+	ErrSameQuestion        = errors.New("row and column questions must differ")
+	ErrEmptyFilter         = errors.New("filter must specify at least one condition")
+	ErrInvalidBucketCount  = fmt.Errorf("bucket count must be between 1 and %d", maxBucketCount)
+)
+
+// Buckets describes how a NUMBER question is split into equal-width intervals.
+// Missing bounds are taken from the stored answers.
+type Buckets struct {
+	Min   *float64
+	Max   *float64
+	Count int
+}
+
+// valid reports whether the bucket count is within bounds. No buckets means the default.
+func (b *Buckets) valid() bool {
+	return b == nil || (b.Count >= 1 && b.Count <= maxBucketCount)
+}
+
+// Filter keeps only responses whose answer to QuestionID matches every set condition.
+type Filter struct {
+	QuestionID string
+	OptionIDs  []string
+	BoolValue  *bool
+	NumberMin  *float64
+	NumberMax  *float64
+	TextValue  *string
+}
+
+type CrossTabParams struct {
+	FormID           string
+	RowQuestionID    string
+	ColumnQuestionID string
+	RowBuckets       *Buckets
+	ColumnBuckets    *Buckets
+	Filters          []Filter
+}
+
+type Category struct {
+	Key     string
+	Label   string
+	Total   int64
+	Percent float64
+}
+
+type Cell struct {
+	RowKey        string
+	ColumnKey     string
+	Count         int64
+	Expected      float64
+	RowPercent    float64
+	ColumnPercent float64
+	TotalPercent  float64
+}
+
+type CrossTab struct {
+	FormID           string
+	RowQuestion      gomodel.Question
+	ColumnQuestion   gomodel.Question
+	Rows             []Category
+	Columns          []Category
+	Cells            []Cell
+	Total            int64
+	ChiSquare        *float64
+	DegreesOfFreedom int
+	PValue           *float64
+}
+
+// dimension is one axis of the table: the SQL selecting (response_id, key)
+// pairs for a question and the full list of categories it can produce.
+type dimension struct {
+	question   gomodel.Question
+	categories []Category
+	sql        string
+	args       []interface{}
+}
+
+type crossTabRow struct {
+	RowKey        string
+	ColKey        string
+	Count         int64
+	RowTotal      int64
+	ColTotal      int64
+	Total         int64
+	Expected      float64
+	RowPercent    float64
+	ColumnPercent float64
+	TotalPercent  float64
+	ChiSquare     float64
+}
+
+// CrossTab builds a contingency table of two questions of the form. Counting,
+// percentages and the chi-square statistic are computed by the database; only
+// the p-value is derived here from the statistic.
+//
+// For MULTIPLE_CHOICE questions every selected option is counted, so a single
+// response may contribute to several cells.
+func (s *Service) CrossTab(ctx context.Context, params CrossTabParams) (*CrossTab, error) {
+	if params.RowQuestionID == params.ColumnQuestionID {
+		return nil, ErrSameQuestion
+	}
+	if !params.RowBuckets.valid() || !params.ColumnBuckets.valid() {
+		return nil, ErrInvalidBucketCount
+	}
+
+	var questions []gomodel.Question
+	if err := s.database.WithContext(ctx).
+		Preload("Options", func(db *gorm.DB) *gorm.DB { return db.Order(`"order"`) }).
+		Where("form_id = ?", params.FormID).
+		Find(&questions).Error; err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]gomodel.Question, len(questions))
+	for _, q := range questions {
+		byID[q.ID] = q
+	}
+
+	rowQuestion, ok := byID[params.RowQuestionID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrQuestionNotInForm, params.RowQuestionID)
+	}
+	columnQuestion, ok := byID[params.ColumnQuestionID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrQuestionNotInForm, params.ColumnQuestionID)
+	}
+
+	baseSQL, baseArgs, err := s.baseQuery(params, byID)
+	if err != nil {
+		return nil, err
+	}
+
+	rowDim, err := s.buildDimension(ctx, params.FormID, rowQuestion, params.RowBuckets)
+	if err != nil {
+		return nil, err
+	}
+	colDim, err := s.buildDimension(ctx, params.FormID, columnQuestion, params.ColumnBuckets)
+	if err != nil {
+		return nil, err
+	}
+
+	query := crossTabQuery(baseSQL, rowDim.sql, colDim.sql)
+
+	args := make([]interface{}, 0, len(baseArgs)+len(rowDim.args)+len(colDim.args)+2)
+	args = append(args, baseArgs...)
+	args = append(args, rowDim.args...)
+	args = append(args, colDim.args...)
+	args = append(args, categoryKeys(rowDim.categories), categoryKeys(colDim.categories))
+
+	var rows []crossTabRow
+	if err := s.database.WithContext(ctx).Raw(query, args...).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return assembleCrossTab(params.FormID, rowDim, colDim, rows), nil
+}
+
+// crossTabQuery joins both dimensions of the responses selected by base and
+// fills the full grid of categories so that empty cells take part in totals.
+func crossTabQuery(base, rowDim, colDim string) string {
+	return `
+WITH base AS (` + base + `),
+row_dim AS (` + rowDim + `),
+col_dim AS (` + colDim + `),
+row_keys AS (SELECT unnest(?::text[]) AS key),
+col_keys AS (SELECT unnest(?::text[]) AS key),
+observed AS (
+	SELECT rd.key AS row_key, cd.key AS col_key, count(*) AS n
+	FROM row_dim rd
+	JOIN col_dim cd ON cd.response_id = rd.response_id
+	GROUP BY rd.key, cd.key
+),
+grid AS (
+	SELECT rk.key AS row_key, ck.key AS col_key, COALESCE(o.n, 0)::float8 AS n
+	FROM row_keys rk
+	CROSS JOIN col_keys ck
+	LEFT JOIN observed o ON o.row_key = rk.key AND o.col_key = ck.key
+),
+totals AS (
+	SELECT row_key, col_key, n,
+		sum(n) OVER (PARTITION BY row_key) AS row_total,
+		sum(n) OVER (PARTITION BY col_key) AS col_total,
+		sum(n) OVER () AS total
+	FROM grid
+),
+expected AS (
+	SELECT *, CASE WHEN total > 0 THEN row_total * col_total / total ELSE 0 END AS expected
+	FROM totals
+)
+SELECT
+	row_key,
+	col_key,
+	n::bigint AS count,
+	row_total::bigint AS row_total,
+	col_total::bigint AS col_total,
+	total::bigint AS total,
+	expected,
+	CASE WHEN row_total > 0 THEN n / row_total * 100 ELSE 0 END AS row_percent,
+	CASE WHEN col_total > 0 THEN n / col_total * 100 ELSE 0 END AS column_percent,
+	CASE WHEN total > 0 THEN n / total * 100 ELSE 0 END AS total_percent,
+	sum(CASE WHEN expected > 0 THEN (n - expected) ^ 2 / expected ELSE 0 END) OVER () AS chi_square
+FROM expected`
+}
+
+// baseQuery selects the ids of the form responses that pass all filters.
+func (s *Service) baseQuery(params CrossTabParams, questions map[string]gomodel.Question) (string, []interface{}, error) {
+	var sb strings.Builder
+	args := []interface{}{params.FormID}
+
+	sb.WriteString("SELECT fr.id FROM form_responses fr WHERE fr.form_id = ? AND fr.deleted_at IS NULL")
+
+	for i, f := range params.Filters {
+		if _, ok := questions[f.QuestionID]; !ok {
+			return "", nil, fmt.Errorf("filter %d: %w: %s", i, ErrQuestionNotInForm, f.QuestionID)
+		}
+
+		conditions := make([]string, 0, 4)
+		condArgs := make([]interface{}, 0, 5)
+
+		if len(f.OptionIDs) > 0 {
+			conditions = append(conditions,
+				"EXISTS (SELECT 1 FROM answer_options fao WHERE fao.answer_id = fa.id AND fao.option_id::text = ANY(?::text[]))")
+			condArgs = append(condArgs, pq.StringArray(f.OptionIDs))
+		}
+		if f.BoolValue != nil {
+			conditions = append(conditions, "fa.bool_value = ?")
+			condArgs = append(condArgs, *f.BoolValue)
+		}
+		if f.NumberMin != nil {
+			conditions = append(conditions, "fa.number_value >= ?")
+			condArgs = append(condArgs, *f.NumberMin)
+		}
+		if f.NumberMax != nil {
+			conditions = append(conditions, "fa.number_value <= ?")
+			condArgs = append(condArgs, *f.NumberMax)
+		}
+		if f.TextValue != nil {
+			conditions = append(conditions, "fa.text_value = ?")
+			condArgs = append(condArgs, *f.TextValue)
+		}
+
+		if len(conditions) == 0 {
+			return "", nil, fmt.Errorf("filter %d: %w", i, ErrEmptyFilter)
+		}
+
+		sb.WriteString(" AND fr.id IN (SELECT fa.response_id FROM answers fa WHERE fa.question_id = ? AND ")
+		sb.WriteString(strings.Join(conditions, " AND "))
+		sb.WriteString(")")
+
+		args = append(args, f.QuestionID)
+		args = append(args, condArgs...)
+	}
+
+	return sb.String(), args, nil
+}
+
+func (s *Service) buildDimension(ctx context.Context, formID string, q gomodel.Question, buckets *Buckets) (*dimension, error) {
+	dim := &dimension{question: q}
+
+	switch q.Type {
+	case gomodel.QuestionTypeSingleChoice, gomodel.QuestionTypeMultipleChoice:
+		for _, o := range q.Options {
+			dim.categories = append(dim.categories, Category{Key: o.ID, Label: o.Text})
+		}
+		// answer_options may hold the same pair twice, hence DISTINCT
+		dim.sql = `SELECT DISTINCT a.response_id, ao.option_id::text AS key
+	FROM answers a
+	JOIN answer_options ao ON ao.answer_id = a.id
+	WHERE a.question_id = ? AND a.response_id IN (SELECT id FROM base)`
+		dim.args = []interface{}{q.ID}
+
+	case gomodel.QuestionTypeBoolean:
+		dim.categories = []Category{
+			{Key: "true", Label: "Yes"},
+			{Key: "false", Label: "No"},
+		}
+		dim.sql = `SELECT a.response_id, CASE WHEN a.bool_value THEN 'true' ELSE 'false' END AS key
+	FROM answers a
+	WHERE a.question_id = ? AND a.bool_value IS NOT NULL AND a.response_id IN (SELECT id FROM base)`
+		dim.args = []interface{}{q.ID}
+
+	case gomodel.QuestionTypeNumber:
+		lo, hi, count, err := s.resolveBuckets(ctx, formID, q.ID, buckets)
+		if err != nil {
+			return nil, err
+		}
+
+		width := (hi - lo) / float64(count)
+		for i := 1; i <= count; i++ {
+			from := lo + width*float64(i-1)
+			to := lo + width*float64(i)
+			dim.categories = append(dim.categories, Category{
+				Key:   strconv.Itoa(i),
+				Label: fmt.Sprintf("[%s, %s)", formatBound(from), formatBound(to)),
+			})
+		}
+		if count > 0 {
+			last := &dim.categories[count-1]
+			last.Label = strings.TrimSuffix(last.Label, ")") + "]"
+		}
+
+		// values outside the range are clamped into the first/last bucket
+		dim.sql = `SELECT a.response_id, GREATEST(LEAST(width_bucket(a.number_value, ?, ?, ?), ?), 1)::text AS key
+	FROM answers a
+	WHERE a.question_id = ? AND a.number_value IS NOT NULL AND a.response_id IN (SELECT id FROM base)`
+		dim.args = []interface{}{lo, hi, count, count, q.ID}
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedQuestion, q.Type)
+	}
+
+	return dim, nil
+}
+
+func (s *Service) resolveBuckets(ctx context.Context, formID, questionID string, buckets *Buckets) (float64, float64, int, error) {
+	count := defaultBucketCount
+	var lo, hi *float64
+
+	if buckets != nil {
+		count = buckets.Count
+		lo, hi = buckets.Min, buckets.Max
+	}
+
+	if lo == nil || hi == nil {
+		var bounds struct {
+			Min *float64
+			Max *float64
+		}
+		if err := s.database.WithContext(ctx).Raw(`
+SELECT min(a.number_value) AS min, max(a.number_value) AS max
+FROM answers a
+JOIN form_responses fr ON fr.id = a.response_id
+WHERE a.question_id = ? AND fr.form_id = ? AND fr.deleted_at IS NULL`, questionID, formID).Scan(&bounds).Error; err != nil {
+			return 0, 0, 0, err
+		}
+
+		if lo == nil {
+			lo = bounds.Min
+		}
+		if hi == nil {
+			hi = bounds.Max
+		}
+	}
+
+	var min, max float64
+	if lo != nil {
+		min = *lo
+	}
+	if hi != nil {
+		max = *hi
+	}
+
+	// width_bucket rejects an empty range
+	if max <= min {
+		max = min + 1
+		count = 1
+	}
+
+	return min, max, count, nil
+}
+
+func assembleCrossTab(formID string, rowDim, colDim *dimension, rows []crossTabRow) *CrossTab {
+	result := &CrossTab{
+		FormID:         formID,
+		RowQuestion:    rowDim.question,
+		ColumnQuestion: colDim.question,
+		Rows:           append([]Category(nil), rowDim.categories...),
+		Columns:        append([]Category(nil), colDim.categories...),
+	}
+
+	rowIndex := indexCategories(result.Rows)
+	colIndex := indexCategories(result.Columns)
+
+	cells := make(map[[2]int]Cell, len(rows))
+	var chiSquare float64
+	for _, r := range rows {
+		ri, rok := rowIndex[r.RowKey]
+		ci, cok := colIndex[r.ColKey]
+		if !rok || !cok {
+			continue
+		}
+
+		result.Total = r.Total
+		result.Rows[ri].Total = r.RowTotal
+		result.Columns[ci].Total = r.ColTotal
+		chiSquare = r.ChiSquare
+
+		cells[[2]int{ri, ci}] = Cell{
+			RowKey:        r.RowKey,
+			ColumnKey:     r.ColKey,
+			Count:         r.Count,
+			Expected:      r.Expected,
+			RowPercent:    r.RowPercent,
+			ColumnPercent: r.ColumnPercent,
+			TotalPercent:  r.TotalPercent,
+		}
+	}
+
+	result.Cells = make([]Cell, 0, len(cells))
+	for ri := range result.Rows {
+		for ci := range result.Columns {
+			if cell, ok := cells[[2]int{ri, ci}]; ok {
+				result.Cells = append(result.Cells, cell)
+			}
+		}
+	}
+
+	var nonEmptyRows, nonEmptyCols int
+	for i := range result.Rows {
+		if result.Total > 0 {
+			result.Rows[i].Percent = float64(result.Rows[i].Total) / float64(result.Total) * 100
+		}
+		if result.Rows[i].Total > 0 {
+			nonEmptyRows++
+		}
+	}
+	for i := range result.Columns {
+		if result.Total > 0 {
+			result.Columns[i].Percent = float64(result.Columns[i].Total) / float64(result.Total) * 100
+		}
+		if result.Columns[i].Total > 0 {
+			nonEmptyCols++
+		}
+	}
+
+	// empty rows and columns carry no information and are left out of the test
+	if nonEmptyRows > 1 && nonEmptyCols > 1 {
+		result.DegreesOfFreedom = (nonEmptyRows - 1) * (nonEmptyCols - 1)
+		pValue := chiSquareSurvival(chiSquare, result.DegreesOfFreedom)
+		result.ChiSquare = &chiSquare
+		result.PValue = &pValue
+	}
+
+	return result
+}
+
+func indexCategories(categories []Category) map[string]int {
+	index := make(map[string]int, len(categories))
+	for i, c := range categories {
+		index[c.Key] = i
+	}
+	return index
+}
+
+func categoryKeys(categories []Category) pq.StringArray {
+	keys := make(pq.StringArray, len(categories))
+	for i, c := range categories {
+		keys[i] = c.Key
+	}
+	return keys
+}
+
+func formatBound(v float64) string {
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
@@ -0,0 +1,96 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestBucketsValid(t *testing.T) {
+	tests := []struct {
+		buckets *Buckets
+		want    bool
+	}{
+		{nil, true},
+		{&Buckets{Count: 1}, true},
+		{&Buckets{Count: maxBucketCount}, true},
+		{&Buckets{Count: 0}, false},
+		{&Buckets{Count: -3}, false},
+		{&Buckets{Count: maxBucketCount + 1}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.buckets.valid(); got != tt.want {
+			t.Errorf("valid(%+v) = %v, want %v", tt.buckets, got, tt.want)
+		}
+	}
+}
+
+// Bucket counts are checked before the database is reached, so the service has none
+func TestCrossTabRejectsBucketCount(t *testing.T) {
+	_, err := (&Service{}).CrossTab(context.Background(), CrossTabParams{
+		RowQuestionID:    "a",
+		ColumnQuestionID: "b",
+		ColumnBuckets:    &Buckets{Count: 1_000_000},
+	})
+	if !errors.Is(err, ErrInvalidBucketCount) {
+		t.Errorf("CrossTab() error = %v, want ErrInvalidBucketCount", err)
+	}
+}
+
+func TestAssembleCrossTab(t *testing.T) {
+	rowDim := &dimension{categories: []Category{{Key: "yes"}, {Key: "no"}, {Key: "maybe"}}}
+	colDim := &dimension{categories: []Category{{Key: "a"}, {Key: "b"}}}
+	// 2x2 table with an empty row; the database repeats the totals and the statistic on every row
+	rows := []crossTabRow{
+		{RowKey: "no", ColKey: "b", Count: 30, RowTotal: 40, ColTotal: 50, Total: 100, ChiSquare: 16.6667},
+		{RowKey: "yes", ColKey: "a", Count: 40, RowTotal: 60, ColTotal: 50, Total: 100, ChiSquare: 16.6667},
+		{RowKey: "yes", ColKey: "b", Count: 20, RowTotal: 60, ColTotal: 50, Total: 100, ChiSquare: 16.6667},
+		{RowKey: "no", ColKey: "a", Count: 10, RowTotal: 40, ColTotal: 50, Total: 100, ChiSquare: 16.6667},
+		{RowKey: "gone", ColKey: "a", Count: 5, RowTotal: 5, ColTotal: 50, Total: 100, ChiSquare: 16.6667},
+	}
+
+	table := assembleCrossTab("form", rowDim, colDim, rows)
+
+	if table.Total != 100 {
+		t.Errorf("Total = %d, want 100", table.Total)
+	}
+	wantCells := [][2]string{{"yes", "a"}, {"yes", "b"}, {"no", "a"}, {"no", "b"}}
+	if len(table.Cells) != len(wantCells) {
+		t.Fatalf("cells = %+v, want %d", table.Cells, len(wantCells))
+	}
+	for i, want := range wantCells {
+		if table.Cells[i].RowKey != want[0] || table.Cells[i].ColumnKey != want[1] {
+			t.Errorf("cell %d = %s/%s, want %s/%s", i, table.Cells[i].RowKey, table.Cells[i].ColumnKey, want[0], want[1])
+		}
+	}
+	if table.Rows[0].Percent != 60 || table.Rows[2].Total != 0 || table.Columns[1].Percent != 50 {
+		t.Errorf("rows = %+v, columns = %+v", table.Rows, table.Columns)
+	}
+
+	// The empty row is left out of the test: a 2x2 table has one degree of freedom
+	if table.DegreesOfFreedom != 1 || table.ChiSquare == nil || table.PValue == nil {
+		t.Fatalf("DegreesOfFreedom = %d, ChiSquare = %v, PValue = %v", table.DegreesOfFreedom, table.ChiSquare, table.PValue)
+	}
+	if *table.PValue < 4.4e-5 || *table.PValue > 4.5e-5 {
+		t.Errorf("PValue = %v, want about 4.46e-5", *table.PValue)
+	}
+}
+
+func TestAssembleCrossTabWithoutTest(t *testing.T) {
+	rowDim := &dimension{categories: []Category{{Key: "yes"}, {Key: "no"}}}
+	colDim := &dimension{categories: []Category{{Key: "a"}, {Key: "b"}}}
+	rows := []crossTabRow{
+		{RowKey: "yes", ColKey: "a", Count: 3, RowTotal: 5, ColTotal: 3, Total: 5},
+		{RowKey: "yes", ColKey: "b", Count: 2, RowTotal: 5, ColTotal: 2, Total: 5},
+	}
+
+	table := assembleCrossTab("form", rowDim, colDim, rows)
+	if table.ChiSquare != nil || table.PValue != nil || table.DegreesOfFreedom != 0 {
+		t.Errorf("a table with one non-empty row got a test: %v %v %d", table.ChiSquare, table.PValue, table.DegreesOfFreedom)
+	}
+
+	empty := assembleCrossTab("form", rowDim, colDim, nil)
+	if empty.Total != 0 || len(empty.Cells) != 0 || empty.Rows[0].Percent != 0 || empty.PValue != nil {
+		t.Errorf("empty table = %+v", empty)
+	}
+}
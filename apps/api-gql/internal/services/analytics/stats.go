@@ -0,0 +1,73 @@
+package analytics
+
+import "math"
+
+const (
+	gammaMaxIterations = 500
+	gammaEpsilon       = 1e-14
+)
+
+// chiSquareSurvival returns P(X >= x) for a chi-square distribution with df degrees of freedom.
+func chiSquareSurvival(x float64, df int) float64 {
+	if df <= 0 || x <= 0 {
+		return 1
+	}
+	return regularizedGammaQ(float64(df)/2, x/2)
+}
+
+// regularizedGammaQ computes the regularized upper incomplete gamma function Q(a, x).
+// A series expansion is used for x < a+1 and a continued fraction otherwise.
+func regularizedGammaQ(a, x float64) float64 {
+	if x < a+1 {
+		return 1 - gammaSeries(a, x)
+	}
+	return gammaContinuedFraction(a, x)
+}
+
+func gammaSeries(a, x float64) float64 {
+	lgamma, _ := math.Lgamma(a)
+
+	ap := a
+	sum := 1 / a
+	del := sum
+	for i := 0; i < gammaMaxIterations; i++ {
+		ap++
+		del *= x / ap
+		sum += del
+		if math.Abs(del) < math.Abs(sum)*gammaEpsilon {
+			break
+		}
+	}
+
+	return sum * math.Exp(-x+a*math.Log(x)-lgamma)
+}
+
+func gammaContinuedFraction(a, x float64) float64 {
+	const tiny = 1e-300
+	lgamma, _ := math.Lgamma(a)
+
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i <= gammaMaxIterations; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < gammaEpsilon {
+			break
+		}
+	}
+
+	return math.Exp(-x+a*math.Log(x)-lgamma) * h
+}
@@ -0,0 +1,44 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestChiSquareSurvival(t *testing.T) {
+	tests := []struct {
+		x    float64
+		df   int
+		want float64
+	}{
+		// Critical values of the chi-square table
+		{3.841459, 1, 0.05},
+		{6.634897, 1, 0.01},
+		{5.991465, 2, 0.05},
+		{7.814728, 3, 0.05},
+		{18.307038, 10, 0.05},
+		{0.454936, 1, 0.5},
+		// Far in the tail, where the continued fraction is used: exp(-25) * 26
+		{50, 4, 3.610865404890645e-10},
+		{0, 3, 1},
+		{-1, 3, 1},
+		{4, 0, 1},
+	}
+	for _, tt := range tests {
+		got := chiSquareSurvival(tt.x, tt.df)
+		if math.Abs(got-tt.want) > 1e-5*tt.want {
+			t.Errorf("chiSquareSurvival(%v, %d) = %.10g, want %.10g", tt.x, tt.df, got, tt.want)
+		}
+	}
+}
+
+// With two degrees of freedom the survival function is exp(-x/2), on both sides of a+1
+// where the series gives way to the continued fraction.
+func TestChiSquareSurvivalTwoDegrees(t *testing.T) {
+	for _, x := range []float64{0.1, 1, 1.9, 2, 2.1, 5, 20, 60} {
+		got, want := chiSquareSurvival(x, 2), math.Exp(-x/2)
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("chiSquareSurvival(%v, 2) = %v, want %v", x, got, want)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Package apikeys issues and checks the API keys integrations authenticate with. A key is
+// shown to its owner once, when it is created; only its SHA-256 is stored, next to a
+// prefix that identifies it in lists.
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TrySquadDF/formify/crypto"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/lib/pq"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+const (
+	// keyMarker starts every key, so that people and secret scanners recognize them
+	keyMarker = "fmf_"
+	// secretBytes is the amount of randomness in a key
+	secretBytes = 32
+	// prefixLength is how much of the key is kept in clear to identify it
+	prefixLength = len(keyMarker) + 8
+	// lastUsedInterval limits how often the last use of a key is written
+	lastUsedInterval = time.Minute
+)
+
+var (
+	ErrKeyNotFound  = errors.New("api key not found")
+	ErrInvalidKey   = errors.New("invalid api key")
+	ErrKeyExpired   = errors.New("api key has expired")
+	ErrNameRequired = errors.New("api key name is required")
+	ErrNoScopes     = errors.New("api key must have at least one scope")
+	ErrUnknownScope = errors.New("unknown api key scope")
+	ErrExpiryInPast = errors.New("api key expiry must be in the future")
+)
+
+// Scopes lists every scope a key can be given
+var Scopes = []gomodel.ApiKeyScope{
+	gomodel.ApiKeyScopeFormsRead,
+	gomodel.ApiKeyScopeFormsWrite,
+	gomodel.ApiKeyScopeResponsesRead,
+	gomodel.ApiKeyScopeResponsesWrite,
+}
+
+type Opts struct {
+	fx.In
+
+	Database *gorm.DB
+}
+
+type Service struct {
+	database *gorm.DB
+}
+
+func New(opts Opts) *Service {
+	return &Service{database: opts.Database}
+}
+
+type KeyParams struct {
+	Name   string
+	Scopes []gomodel.ApiKeyScope
+	// ExpiresAt is nil for a key that does not expire
+	ExpiresAt *time.Time
+}
+
+// Create issues a key to the user. The key is returned in clear only here.
+func (s *Service) Create(ctx context.Context, userID string, params KeyParams) (*gomodel.ApiKey, string, error) {
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		return nil, "", ErrNameRequired
+	}
+	scopes, err := normalizeScopes(params.Scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	if params.ExpiresAt != nil && !params.ExpiresAt.After(time.Now()) {
+		return nil, "", ErrExpiryInPast
+	}
+
+	secret, err := crypto.NewToken(secretBytes)
+	if err != nil {
+		return nil, "", err
+	}
+	key := keyMarker + secret
+
+	apiKey := gomodel.ApiKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    key[:prefixLength],
+		KeyHash:   crypto.HashToken(key),
+		Scopes:    scopes,
+		ExpiresAt: params.ExpiresAt,
+	}
+	if err := s.database.WithContext(ctx).Create(&apiKey).Error; err != nil {
+		return nil, "", err
+	}
+
+	return &apiKey, key, nil
+}
+
+// List returns the keys of a user that are not revoked, newest first. Expired keys are
+// listed until they are revoked.
+func (s *Service) List(ctx context.Context, userID string) ([]gomodel.ApiKey, error) {
+	var keys []gomodel.ApiKey
+	err := s.database.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("created_at DESC").
+		Find(&keys).Error
+	return keys, err
+}
+
+// Revoke disables a key of the user at once.
+func (s *Service) Revoke(ctx context.Context, userID, id string) error {
+	result := s.database.WithContext(ctx).
+		Model(&gomodel.ApiKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrKeyNotFound
+	}
+	return nil
+}
+
+// Authenticate returns the key record of a key that is valid now and notes its use.
+func (s *Service) Authenticate(ctx context.Context, key string) (*gomodel.ApiKey, error) {
+	if !strings.HasPrefix(key, keyMarker) {
+		return nil, ErrInvalidKey
+	}
+
+	var apiKey gomodel.ApiKey
+	err := s.database.WithContext(ctx).
+		Where("key_hash = ? AND revoked_at IS NULL", crypto.HashToken(key)).
+		First(&apiKey).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidKey
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if apiKey.ExpiresAt != nil && !apiKey.ExpiresAt.After(now) {
+		return nil, ErrKeyExpired
+	}
+
+	// A busy integration would otherwise write on every request
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) > lastUsedInterval {
+		if err := s.database.WithContext(ctx).
+			Model(&gomodel.ApiKey{}).
+			Where("id = ?", apiKey.ID).
+			Update("last_used_at", now).Error; err != nil {
+			return nil, err
+		}
+		apiKey.LastUsedAt = &now
+	}
+
+	return &apiKey, nil
+}
+
+// normalizeScopes checks the scopes and drops duplicates.
+func normalizeScopes(scopes []gomodel.ApiKeyScope) (pq.StringArray, error) {
+	if len(scopes) == 0 {
+		return nil, ErrNoScopes
+	}
+
+	result := make(pq.StringArray, 0, len(scopes))
+	seen := make(map[gomodel.ApiKeyScope]bool, len(scopes))
+	for _, scope := range scopes {
+		if !slices.Contains(Scopes, scope) {
+			return nil, ErrUnknownScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, string(scope))
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,68 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+func TestNormalizeScopes(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []gomodel.ApiKeyScope
+		want   []string
+		err    error
+	}{
+		{name: "none", err: ErrNoScopes},
+		{name: "unknown", scopes: []gomodel.ApiKeyScope{gomodel.ApiKeyScopeFormsRead, "forms:admin"}, err: ErrUnknownScope},
+		{
+			name:   "duplicates",
+			scopes: []gomodel.ApiKeyScope{gomodel.ApiKeyScopeResponsesRead, gomodel.ApiKeyScopeFormsRead, gomodel.ApiKeyScopeResponsesRead},
+			want:   []string{"responses:read", "forms:read"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeScopes(tt.scopes)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("normalizeScopes() error = %v, want %v", err, tt.err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("normalizeScopes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// The checks below fail before the database is reached, so the service has none
+func TestCreateRejects(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name   string
+		params KeyParams
+		err    error
+	}{
+		{"blank name", KeyParams{Name: "  ", Scopes: Scopes}, ErrNameRequired},
+		{"no scopes", KeyParams{Name: "ci"}, ErrNoScopes},
+		{"expired", KeyParams{Name: "ci", Scopes: Scopes, ExpiresAt: &past}, ErrExpiryInPast},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := (&Service{}).Create(context.Background(), "user", tt.params); !errors.Is(err, tt.err) {
+				t.Errorf("Create() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestAuthenticateRejectsForeignKeys(t *testing.T) {
+	for _, key := range []string{"", "secret", "ghp_0123456789"} {
+		if _, err := (&Service{}).Authenticate(context.Background(), key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Authenticate(%q) error = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
@@ -0,0 +1,493 @@
+package csvimport
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/responses"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// batchSize is how many rows are sent to the database per statement
+const batchSize = 500
+
+var (
+	ErrNoHeader       = errors.New("the CSV file has no header row")
+	ErrQuestionMapped = errors.New("a question can only be mapped to one column")
+)
+
+// timestampHeaders name the columns export files keep the submission time in, lowercase
+var timestampHeaders = []string{"timestamp", "отметка времени", "submit date (utc)", "submitted at", "created at"}
+
+// dateLayouts are the date formats understood in cells, tried in order
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"1/2/2006 15:04:05",
+	"1/2/2006 15:04",
+	"1/2/2006",
+	"2.1.2006 15:04:05",
+	"2.1.2006 15:04",
+	"2.1.2006",
+}
+
+type Opts struct {
+	fx.In
+
+	Database *gorm.DB
+}
+
+type Service struct {
+	database *gorm.DB
+}
+
+func New(opts Opts) *Service {
+	return &Service{
+		database: opts.Database,
+	}
+}
+
+// File is a parsed CSV file: a header row and the data rows under it.
+type File struct {
+	Header []string
+	Rows   [][]string
+}
+
+// RowError is a problem with one row; the row is not imported. Row counts from 1 for
+// the header, like spreadsheets do.
+type RowError struct {
+	Row     int
+	Column  string
+	Message string
+}
+
+// Column maps a column, named by its header, to a question of the form.
+type Column struct {
+	Header     string
+	QuestionID string
+}
+
+type Params struct {
+	// Columns replaces matching by header text when set; columns left out are not imported
+	Columns []Column
+	// TimestampColumn names the column with the submission time; well-known headers such
+	// as "Timestamp" are recognized when it is empty
+	TimestampColumn string
+	// DryRun checks every row and reports what would be imported without storing anything
+	DryRun bool
+}
+
+type Report struct {
+	// Imported counts the stored responses, or those that would be stored in a dry run
+	Imported int
+	Errors   []RowError
+	// UnmatchedColumns are the headers no question was found for
+	UnmatchedColumns []string
+}
+
+// Read parses a CSV file. Spreadsheets often prepend a byte order mark, which is dropped.
+func Read(data []byte) (*File, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV file: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, ErrNoHeader
+	}
+	return &File{Header: records[0], Rows: records[1:]}, nil
+}
+
+// columns says which question each column answers and where the submission time is.
+type columns struct {
+	questions map[int]*gomodel.Question
+	timestamp int
+}
+
+// matchColumns pairs columns with questions of the same text. When several questions
+// share a text, they are taken in order.
+func matchColumns(header []string, form *gomodel.Form) (columns, []string) {
+	result := columns{questions: make(map[int]*gomodel.Question), timestamp: -1}
+	used := make(map[string]bool)
+	var unmatched []string
+
+	for i, name := range header {
+		key := normalize(name)
+		if key == "" {
+			continue
+		}
+		if result.timestamp < 0 && isTimestampHeader(key) {
+			result.timestamp = i
+			continue
+		}
+
+		var found *gomodel.Question
+		for j := range form.Questions {
+			q := &form.Questions[j]
+			if !used[q.ID] && normalize(q.Text) == key {
+				found = q
+				break
+			}
+		}
+		if found == nil {
+			unmatched = append(unmatched, name)
+			continue
+		}
+		used[found.ID] = true
+		result.questions[i] = found
+	}
+	return result, unmatched
+}
+
+// mapColumns applies an explicit mapping of columns to questions.
+func mapColumns(header []string, form *gomodel.Form, params Params) (columns, []string, error) {
+	result := columns{questions: make(map[int]*gomodel.Question), timestamp: -1}
+
+	questions := make(map[string]*gomodel.Question, len(form.Questions))
+	for i := range form.Questions {
+		questions[form.Questions[i].ID] = &form.Questions[i]
+	}
+
+	used := make(map[string]bool)
+	for _, c := range params.Columns {
+		index := findHeader(header, c.Header)
+		if index < 0 {
+			return result, nil, fmt.Errorf("column %q not found in the CSV file", c.Header)
+		}
+		question, ok := questions[c.QuestionID]
+		if !ok {
+			return result, nil, fmt.Errorf("question not found: %s", c.QuestionID)
+		}
+		if used[question.ID] {
+			return result, nil, ErrQuestionMapped
+		}
+		used[question.ID] = true
+		result.questions[index] = question
+	}
+
+	var unmatched []string
+	for i, name := range header {
+		if _, ok := result.questions[i]; ok || normalize(name) == "" {
+			continue
+		}
+		if result.timestamp < 0 && isTimestampHeader(normalize(name)) {
+			result.timestamp = i
+			continue
+		}
+		unmatched = append(unmatched, name)
+	}
+	return result, unmatched, nil
+}
+
+// findHeader returns the index of the first column with the header, or -1.
+func findHeader(header []string, name string) int {
+	key := normalize(name)
+	for i, h := range header {
+		if normalize(h) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Import stores the rows of the file as responses to the form, matching columns to
+// questions by their text unless params map them explicitly. Responses keep the submission time of the file when it has
+// one. Rows with problems are skipped and reported; required questions are not
+// enforced, since old responses may predate them. The valid rows are stored in one
+// transaction: an error stores none of them. Webhooks and notifications are not sent
+// for imported responses. The form must have its Questions.Options loaded.
+func (s *Service) Import(ctx context.Context, form *gomodel.Form, file *File, params Params) (*Report, error) {
+	var cols columns
+	var unmatched []string
+	if params.Columns != nil {
+		var err error
+		if cols, unmatched, err = mapColumns(file.Header, form, params); err != nil {
+			return nil, err
+		}
+	} else {
+		cols, unmatched = matchColumns(file.Header, form)
+	}
+
+	if params.TimestampColumn != "" {
+		if cols.timestamp = findHeader(file.Header, params.TimestampColumn); cols.timestamp < 0 {
+			return nil, fmt.Errorf("column %q not found in the CSV file", params.TimestampColumn)
+		}
+		delete(cols.questions, cols.timestamp)
+		unmatched = without(unmatched, file.Header[cols.timestamp])
+	}
+
+	report := &Report{UnmatchedColumns: unmatched, Errors: make([]RowError, 0)}
+
+	batch := make([]gomodel.FormResponse, 0, len(file.Rows))
+	for i, row := range file.Rows {
+		response, errs := convertRow(form, file.Header, cols, row, i+2)
+		if len(errs) > 0 {
+			report.Errors = append(report.Errors, errs...)
+			continue
+		}
+		if response == nil {
+			continue
+		}
+		batch = append(batch, *response)
+	}
+
+	// Every row is stored or none is, so that a failed import can simply be retried
+	if !params.DryRun && len(batch) > 0 {
+		if err := s.store(ctx, batch); err != nil {
+			return nil, err
+		}
+	}
+	report.Imported = len(batch)
+	return report, nil
+}
+
+// convertRow turns a row into an unsaved response, or nil for an empty row.
+func convertRow(form *gomodel.Form, header []string, cols columns, row []string, number int) (*gomodel.FormResponse, []RowError) {
+	var errs []RowError
+	fail := func(column int, format string, args ...interface{}) {
+		name := ""
+		if column >= 0 && column < len(header) {
+			name = header[column]
+		}
+		errs = append(errs, RowError{Row: number, Column: name, Message: fmt.Sprintf(format, args...)})
+	}
+
+	response := &gomodel.FormResponse{
+		ID:        uuid.New().String(),
+		FormID:    form.ID,
+		CreatedAt: time.Now(),
+		Status:    gomodel.ResponseStatusNew,
+	}
+	if cols.timestamp >= 0 && cols.timestamp < len(row) && strings.TrimSpace(row[cols.timestamp]) != "" {
+		createdAt, err := parseDate(row[cols.timestamp])
+		if err != nil {
+			fail(cols.timestamp, "cannot read the time %q", row[cols.timestamp])
+		} else {
+			response.CreatedAt = createdAt
+		}
+	}
+
+	inputs := make([]responses.AnswerInput, 0, len(cols.questions))
+	for i := range row {
+		question, ok := cols.questions[i]
+		if !ok {
+			continue
+		}
+		value := strings.TrimSpace(row[i])
+		if value == "" {
+			continue
+		}
+
+		input, err := convertValue(question, value)
+		if err != nil {
+			fail(i, "%v", err)
+			continue
+		}
+		inputs = append(inputs, input)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+
+	answers, err := responses.ValidateAnswers(form, response.ID, inputs, true)
+	if err != nil {
+		fail(-1, "%v", err)
+		return nil, errs
+	}
+	response.Answers = answers
+	return response, nil
+}
+
+// convertValue reads a cell as an answer to the question.
+func convertValue(question *gomodel.Question, value string) (responses.AnswerInput, error) {
+	input := responses.AnswerInput{QuestionID: question.ID}
+
+	switch question.Type {
+	case gomodel.QuestionTypeBoolean:
+		b, err := parseBool(value)
+		if err != nil {
+			return input, err
+		}
+		input.BoolValue = &b
+
+	case gomodel.QuestionTypeNumber:
+		number := value
+		if !strings.Contains(number, ".") {
+			number = strings.Replace(number, ",", ".", 1)
+		}
+		n, err := strconv.ParseFloat(strings.ReplaceAll(number, " ", ""), 64)
+		if err != nil {
+			return input, fmt.Errorf("%q is not a number", value)
+		}
+		input.NumberValue = &n
+
+	case gomodel.QuestionTypeDate:
+		date, err := parseDate(value)
+		if err != nil {
+			return input, fmt.Errorf("cannot read the date %q", value)
+		}
+		formatted := date.Format(time.RFC3339)
+		input.DateValue = &formatted
+
+	case gomodel.QuestionTypeSingleChoice, gomodel.QuestionTypeMultipleChoice:
+		optionIDs, err := matchOptions(question, value)
+		if err != nil {
+			return input, err
+		}
+		input.OptionIDs = optionIDs
+
+	default:
+		input.TextValue = &value
+	}
+	return input, nil
+}
+
+// matchOptions finds the options named in a cell. A multiple choice cell lists its
+// options separated by commas or semicolons, as spreadsheet exports do; option texts
+// may contain separators themselves, so the longest text that names an option wins.
+func matchOptions(question *gomodel.Question, value string) ([]string, error) {
+	if option := findOption(question, value); option != nil {
+		return []string{option.ID}, nil
+	}
+	if question.Type == gomodel.QuestionTypeSingleChoice {
+		return nil, fmt.Errorf("no option %q", value)
+	}
+
+	// Pieces of the cell can end at a separator or at the end of the cell
+	ends := []int{}
+	for i, r := range value {
+		if r == ',' || r == ';' {
+			ends = append(ends, i)
+		}
+	}
+	ends = append(ends, len(value))
+
+	var ids []string
+	for start, first := 0, 0; first < len(ends); {
+		matched := false
+		for last := len(ends) - 1; last >= first; last-- {
+			piece := strings.TrimSpace(value[start:ends[last]])
+			if option := findOption(question, piece); option != nil {
+				ids = append(ids, option.ID)
+				start, first, matched = ends[last]+1, last+1, true
+				break
+			}
+		}
+		if !matched {
+			piece := strings.TrimSpace(value[start:ends[first]])
+			if piece != "" {
+				return nil, fmt.Errorf("no option %q", piece)
+			}
+			start, first = ends[first]+1, first+1
+		}
+	}
+	return ids, nil
+}
+
+// store inserts the responses with their answers and selected options in one transaction.
+func (s *Service) store(ctx context.Context, batch []gomodel.FormResponse) error {
+	var answers []gomodel.Answer
+	var selected []gomodel.AnswerOption
+	for _, r := range batch {
+		for _, a := range r.Answers {
+			answers = append(answers, a)
+			for _, o := range a.SelectedOptions {
+				selected = append(selected, gomodel.AnswerOption{
+					ID:       uuid.New().String(),
+					AnswerID: a.ID,
+					OptionID: o.ID,
+				})
+			}
+		}
+	}
+
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).CreateInBatches(batch, batchSize).Error; err != nil {
+			return err
+		}
+		if len(answers) > 0 {
+			if err := tx.Omit(clause.Associations).CreateInBatches(answers, batchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(selected) > 0 {
+			if err := tx.CreateInBatches(selected, batchSize).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func findOption(question *gomodel.Question, text string) *gomodel.Option {
+	key := normalize(text)
+	for _, o := range question.Options {
+		if normalize(o.Text) == key {
+			return o
+		}
+	}
+	return nil
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "true", "yes", "y", "1", "on", "да", "д":
+		return true, nil
+	case "false", "no", "n", "0", "off", "нет", "н":
+		return false, nil
+	}
+	return false, fmt.Errorf("%q is not a yes or no answer", value)
+}
+
+func parseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot read the date %q", value)
+}
+
+func without(list []string, value string) []string {
+	result := list[:0]
+	for _, v := range list {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func isTimestampHeader(key string) bool {
+	for _, h := range timestampHeaders {
+		if key == h {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize makes header and question texts comparable: case and runs of spaces are ignored.
+func normalize(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
@@ -0,0 +1,189 @@
+package csvimport
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+func testForm() *gomodel.Form {
+	return &gomodel.Form{
+		ID: "form",
+		Questions: []gomodel.Question{
+			{ID: "name", Text: "Your  name", Type: gomodel.QuestionTypeShortText},
+			{ID: "age", Text: "Age", Type: gomodel.QuestionTypeNumber},
+			{ID: "subscribe", Text: "Subscribe?", Type: gomodel.QuestionTypeBoolean},
+			{ID: "colors", Text: "Colors", Type: gomodel.QuestionTypeMultipleChoice, Options: []*gomodel.Option{
+				{ID: "red", Text: "Red"},
+				{ID: "blue", Text: "Blue, dark"},
+				{ID: "green", Text: "Green"},
+			}},
+			{ID: "comment", Text: "Comment", Type: gomodel.QuestionTypeParagraph},
+			{ID: "comment2", Text: "Comment", Type: gomodel.QuestionTypeParagraph},
+		},
+	}
+}
+
+func TestRead(t *testing.T) {
+	file, err := Read([]byte("\xef\xbb\xbfTimestamp,Age\n2024-01-02,30\n2024-01-03\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(file.Header, []string{"Timestamp", "Age"}) {
+		t.Errorf("Header = %q; the byte order mark must be dropped", file.Header)
+	}
+	if len(file.Rows) != 2 {
+		t.Errorf("len(Rows) = %d, want 2, short rows included", len(file.Rows))
+	}
+
+	if _, err := Read(nil); !errors.Is(err, ErrNoHeader) {
+		t.Errorf("Read(empty) error = %v, want ErrNoHeader", err)
+	}
+	if _, err := Read([]byte("a,\"b\n")); err == nil {
+		t.Error("Read() of a broken quote succeeded")
+	}
+}
+
+func TestMatchColumns(t *testing.T) {
+	header := []string{"Отметка времени", "your name", "Comment", "Comment", "Unknown", ""}
+	cols, unmatched := matchColumns(header, testForm())
+
+	if cols.timestamp != 0 {
+		t.Errorf("timestamp = %d, want 0", cols.timestamp)
+	}
+	want := map[int]string{1: "name", 2: "comment", 3: "comment2"}
+	if len(cols.questions) != len(want) {
+		t.Errorf("matched %d columns, want %d", len(cols.questions), len(want))
+	}
+	for i, id := range want {
+		if q := cols.questions[i]; q == nil || q.ID != id {
+			t.Errorf("column %d matched %v, want %s", i, q, id)
+		}
+	}
+	if !slices.Equal(unmatched, []string{"Unknown"}) {
+		t.Errorf("unmatched = %q, want [Unknown]", unmatched)
+	}
+}
+
+func TestMapColumns(t *testing.T) {
+	form := testForm()
+	header := []string{"Q1", "Q2", "Submitted at"}
+
+	cols, unmatched, err := mapColumns(header, form, Params{Columns: []Column{{Header: "q2", QuestionID: "age"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q := cols.questions[1]; q == nil || q.ID != "age" || len(cols.questions) != 1 {
+		t.Errorf("questions = %v, want only column 1 mapped to age", cols.questions)
+	}
+	if cols.timestamp != 2 || !slices.Equal(unmatched, []string{"Q1"}) {
+		t.Errorf("timestamp = %d, unmatched = %q", cols.timestamp, unmatched)
+	}
+
+	twice := Params{Columns: []Column{{Header: "Q1", QuestionID: "age"}, {Header: "Q2", QuestionID: "age"}}}
+	if _, _, err := mapColumns(header, form, twice); !errors.Is(err, ErrQuestionMapped) {
+		t.Errorf("mapping a question twice: error = %v, want ErrQuestionMapped", err)
+	}
+	for _, c := range []Column{{Header: "Q3", QuestionID: "age"}, {Header: "Q1", QuestionID: "missing"}} {
+		if _, _, err := mapColumns(header, form, Params{Columns: []Column{c}}); err == nil {
+			t.Errorf("mapColumns(%+v) succeeded", c)
+		}
+	}
+}
+
+func TestConvertValue(t *testing.T) {
+	form := testForm()
+	question := func(id string) *gomodel.Question {
+		for i := range form.Questions {
+			if form.Questions[i].ID == id {
+				return &form.Questions[i]
+			}
+		}
+		t.Fatalf("no question %s", id)
+		return nil
+	}
+
+	tests := []struct {
+		question string
+		value    string
+		check    func(t *testing.T, options []string, number *float64, b *bool)
+		wantErr  bool
+	}{
+		{question: "age", value: "1 234,5", check: func(t *testing.T, _ []string, n *float64, _ *bool) {
+			if n == nil || *n != 1234.5 {
+				t.Errorf("number = %v, want 1234.5", n)
+			}
+		}},
+		{question: "age", value: "many", wantErr: true},
+		{question: "subscribe", value: "Да", check: func(t *testing.T, _ []string, _ *float64, b *bool) {
+			if b == nil || !*b {
+				t.Errorf("bool = %v, want true", b)
+			}
+		}},
+		{question: "subscribe", value: "maybe", wantErr: true},
+		// "Blue, dark" contains a separator and still names one option
+		{question: "colors", value: "red; Blue, dark,green", check: func(t *testing.T, options []string, _ *float64, _ *bool) {
+			if !slices.Equal(options, []string{"red", "blue", "green"}) {
+				t.Errorf("options = %q, want [red blue green]", options)
+			}
+		}},
+		{question: "colors", value: "Red, Purple", wantErr: true},
+	}
+	for _, tt := range tests {
+		input, err := convertValue(question(tt.question), tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("convertValue(%s, %q) error = %v, want error %v", tt.question, tt.value, err, tt.wantErr)
+			continue
+		}
+		if tt.check != nil {
+			tt.check(t, input.OptionIDs, input.NumberValue, input.BoolValue)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	want := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+	for _, value := range []string{"2024-03-05T14:30:00Z", "2024-03-05 14:30", "3/5/2024 14:30", "5.3.2024 14:30:00"} {
+		got, err := parseDate(value)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("parseDate(%q) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+	if _, err := parseDate("yesterday"); err == nil {
+		t.Error(`parseDate("yesterday") succeeded`)
+	}
+}
+
+// A dry run checks every row without reaching the database, so the service has none
+func TestImportDryRun(t *testing.T) {
+	file, err := Read([]byte("Timestamp,Your name,Age,Extra\n" +
+		"2024-01-02 10:00,Ann,30,x\n" +
+		",,,\n" +
+		"2024-01-03 10:00,Bob,thirty,y\n" +
+		"soon,Eve,40,z\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := (&Service{}).Import(context.Background(), testForm(), file, Params{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Imported != 1 {
+		t.Errorf("Imported = %d, want 1", report.Imported)
+	}
+	if !slices.Equal(report.UnmatchedColumns, []string{"Extra"}) {
+		t.Errorf("UnmatchedColumns = %q, want [Extra]", report.UnmatchedColumns)
+	}
+	want := []RowError{
+		{Row: 4, Column: "Age", Message: `"thirty" is not a number`},
+		{Row: 5, Column: "Timestamp", Message: `cannot read the time "soon"`},
+	}
+	if !slices.Equal(report.Errors, want) {
+		t.Errorf("Errors = %+v, want %+v", report.Errors, want)
+	}
+}
@@ -0,0 +1,168 @@
+package folders
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/forms"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrFolderNotFound = errors.New("folder not found")
+	ErrEmptyName      = errors.New("folder name must not be empty")
+	ErrFolderCycle    = errors.New("folder cannot be moved into itself")
+	ErrWrongScope     = errors.New("folder belongs to another user or workspace")
+)
+
+// Scope is the owner of a folder: a user for the personal workspace or a team workspace.
+// Exactly one of the fields is set.
+type Scope struct {
+	OwnerID     *string
+	WorkspaceID *string
+}
+
+type Opts struct {
+	fx.In
+
+	Database *gorm.DB
+}
+
+type Service struct {
+	database *gorm.DB
+}
+
+func New(opts Opts) *Service {
+	return &Service{
+		database: opts.Database,
+	}
+}
+
+// Create makes a folder in the scope, or inside parent when it is given. A subfolder
+// always belongs to the scope of its parent.
+func (s *Service) Create(ctx context.Context, scope Scope, parent *gomodel.Folder, name string) (*gomodel.Folder, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+
+	folder := &gomodel.Folder{
+		Name:        name,
+		OwnerID:     scope.OwnerID,
+		WorkspaceID: scope.WorkspaceID,
+	}
+	if parent != nil {
+		folder.ParentID = &parent.ID
+		folder.OwnerID = parent.OwnerID
+		folder.WorkspaceID = parent.WorkspaceID
+	}
+
+	if err := s.database.WithContext(ctx).Create(folder).Error; err != nil {
+		return nil, err
+	}
+	return folder, nil
+}
+
+// Find loads a folder by ID.
+func (s *Service) Find(ctx context.Context, id string) (*gomodel.Folder, error) {
+	var folder gomodel.Folder
+	if err := s.database.WithContext(ctx).First(&folder, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFolderNotFound
+		}
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// List returns every folder of the scope by name; clients build the tree from ParentID.
+func (s *Service) List(ctx context.Context, scope Scope) ([]gomodel.Folder, error) {
+	query := s.database.WithContext(ctx).Model(&gomodel.Folder{})
+	if scope.WorkspaceID != nil {
+		query = query.Where("workspace_id = ?", *scope.WorkspaceID)
+	} else {
+		query = query.Where("owner_id = ? AND workspace_id IS NULL", scope.OwnerID)
+	}
+
+	var folders []gomodel.Folder
+	err := query.Order("name").Find(&folders).Error
+	return folders, err
+}
+
+// Rename changes the name of the folder.
+func (s *Service) Rename(ctx context.Context, folder *gomodel.Folder, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyName
+	}
+	return s.database.WithContext(ctx).Model(folder).Update("name", name).Error
+}
+
+// Move puts the folder inside parent, or at the top level when parent is nil.
+func (s *Service) Move(ctx context.Context, folder *gomodel.Folder, parent *gomodel.Folder) error {
+	if parent == nil {
+		return s.database.WithContext(ctx).Model(folder).Update("parent_id", nil).Error
+	}
+
+	if !folder.SameScope(parent) {
+		return ErrWrongScope
+	}
+
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Walk up from the new parent; meeting the folder itself means a cycle
+		for id := &parent.ID; id != nil; {
+			if *id == folder.ID {
+				return ErrFolderCycle
+			}
+			var ancestor gomodel.Folder
+			if err := tx.Select("id", "parent_id").First(&ancestor, "id = ?", *id).Error; err != nil {
+				return err
+			}
+			id = ancestor.ParentID
+		}
+
+		return tx.Model(folder).Update("parent_id", parent.ID).Error
+	})
+}
+
+// Delete removes the folder. Its forms and subfolders move up to its parent.
+func (s *Service) Delete(ctx context.Context, folder *gomodel.Folder) error {
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&gomodel.Form{}).
+			Where(`"folderId" = ?`, folder.ID).
+			Update("folderId", folder.ParentID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&gomodel.Folder{}).
+			Where("parent_id = ?", folder.ID).
+			Update("parent_id", folder.ParentID).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(folder).Error
+	})
+}
+
+// MoveForm puts the form into the folder, or out of any folder when folder is nil.
+func (s *Service) MoveForm(ctx context.Context, form *gomodel.Form, folder *gomodel.Folder) error {
+	var folderID *string
+	if folder != nil {
+		if !folder.Holds(form) {
+			return ErrWrongScope
+		}
+		folderID = &folder.ID
+	}
+
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(form).Update("folderId", folderID).Error; err != nil {
+			return err
+		}
+		version, err := forms.BumpVersion(tx, form.ID, nil)
+		form.Version = version
+		return err
+	})
+}
@@ -0,0 +1,434 @@
+// Package formdoc defines the portable document a form is exported to and imported from,
+// so form definitions can be kept in git and moved between instances.
+//
+// A document is JSON or YAML with the same fields in both. Version 1 looks like this:
+//
+//	version: 1
+//	key: customer-feedback           # optional; the stable key forms are matched by in formify plan/apply
+//	title: Customer feedback
+//	description: A few questions about your last order
+//	tags: [feedback]
+//	settings:
+//	  access: BY_LINK                # PRIVATE, BY_LINK or PUBLIC; PRIVATE when omitted
+//	  allowResponseEditing: true
+//	  responseEditDeadline: 2026-12-31T23:59:59Z
+//	  responseNotifications: DAILY   # NONE, EACH, HOURLY or DAILY; NONE when omitted
+//	  reviewStatuses: [ESCALATED]
+//	questions:
+//	  - id: 0b6b1c1e-...              # optional; reported back on import to map old IDs to new ones
+//	    key: rating                   # optional; names the question in the REST API
+//	    text: How did we do?
+//	    type: SINGLE_CHOICE
+//	    required: true
+//	    options:
+//	      - id: 5d2f...
+//	        text: Great
+//	      - text: Not so great
+//	  - text: Where should we send a copy of your answers?
+//	    type: EMAIL
+//	    respondentEmail: true
+//
+// Questions and options keep the order they are listed in. Validation of answers is
+// expressed by the question type, required and respondentEmail; forms have no branching
+// logic yet, so version 1 carries none. Unknown fields are rejected, so a document written
+// for a newer version fails loudly instead of losing data.
+package formdoc
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/formschema"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/labels"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// CurrentVersion is the version of the format written by Export and the only one Parse reads.
+const CurrentVersion = 1
+
+// maxTitleLength and maxKeyLength match the width of their columns.
+const (
+	maxTitleLength = 255
+	maxKeyLength   = 255
+)
+
+// keyPattern keeps keys usable as file names and in URLs.
+var keyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+type Document struct {
+	Version     int        `json:"version" yaml:"version"`
+	Key         string     `json:"key,omitempty" yaml:"key,omitempty"`
+	Title       string     `json:"title" yaml:"title"`
+	Description string     `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        []string   `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Settings    Settings   `json:"settings" yaml:"settings"`
+	Questions   []Question `json:"questions" yaml:"questions"`
+}
+
+type Settings struct {
+	Access               gomodel.FormAccess `json:"access,omitempty" yaml:"access,omitempty"`
+	AllowResponseEditing bool               `json:"allowResponseEditing,omitempty" yaml:"allowResponseEditing,omitempty"`
+	// RFC3339
+	ResponseEditDeadline  string                        `json:"responseEditDeadline,omitempty" yaml:"responseEditDeadline,omitempty"`
+	ResponseNotifications gomodel.ResponseNotifications `json:"responseNotifications,omitempty" yaml:"responseNotifications,omitempty"`
+	ReviewStatuses        []string                      `json:"reviewStatuses,omitempty" yaml:"reviewStatuses,omitempty"`
+}
+
+type Question struct {
+	// ID of the question on the instance the document came from
+	ID              string               `json:"id,omitempty" yaml:"id,omitempty"`
+	Key             string               `json:"key,omitempty" yaml:"key,omitempty"`
+	Text            string               `json:"text" yaml:"text"`
+	Type            gomodel.QuestionType `json:"type" yaml:"type"`
+	Required        bool                 `json:"required,omitempty" yaml:"required,omitempty"`
+	RespondentEmail bool                 `json:"respondentEmail,omitempty" yaml:"respondentEmail,omitempty"`
+	Options         []Option             `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+type Option struct {
+	ID   string `json:"id,omitempty" yaml:"id,omitempty"`
+	Text string `json:"text" yaml:"text"`
+}
+
+// Issue is a single problem found in a document. Path points at the offending field,
+// for example "questions[2].options[0].text".
+type Issue struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists every problem found in a document.
+type ValidationError struct {
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	first := e.Issues[0]
+	message := first.Message
+	if first.Path != "" {
+		message = first.Path + ": " + message
+	}
+	if len(e.Issues) > 1 {
+		message += fmt.Sprintf(" (and %d more problems)", len(e.Issues)-1)
+	}
+	return "invalid form document: " + message
+}
+
+// Extensions lets GraphQL clients show every problem next to the field it concerns.
+func (e *ValidationError) Extensions() map[string]interface{} {
+	return map[string]interface{}{
+		"code":   "INVALID_FORM_DOCUMENT",
+		"issues": e.Issues,
+	}
+}
+
+// Export turns a form into a document, with questions and options in their order.
+// The form must have its Questions.Options loaded.
+func Export(form *gomodel.Form) *Document {
+	doc := &Document{
+		Version:     CurrentVersion,
+		Title:       form.Title,
+		Description: form.Description,
+		Tags:        []string(form.Tags),
+		Settings: Settings{
+			Access:                form.Access,
+			AllowResponseEditing:  form.AllowResponseEditing,
+			ResponseNotifications: form.ResponseNotifications,
+			ReviewStatuses:        []string(form.ReviewStatuses),
+		},
+		Questions: make([]Question, len(form.Questions)),
+	}
+	if form.ExternalKey != nil {
+		doc.Key = *form.ExternalKey
+	}
+	if form.ResponseEditDeadline != nil {
+		doc.Settings.ResponseEditDeadline = form.ResponseEditDeadline.UTC().Format(time.RFC3339)
+	}
+
+	questions := append([]gomodel.Question(nil), form.Questions...)
+	sort.SliceStable(questions, func(i, j int) bool { return questions[i].Order < questions[j].Order })
+	for i, q := range questions {
+		question := Question{
+			ID:              q.ID,
+			Text:            q.Text,
+			Type:            q.Type,
+			Required:        q.Required,
+			RespondentEmail: q.RespondentEmail,
+		}
+		if q.Key != nil {
+			question.Key = *q.Key
+		}
+		if isChoice(q.Type) {
+			options := append([]*gomodel.Option(nil), q.Options...)
+			sort.SliceStable(options, func(i, j int) bool { return options[i].Order < options[j].Order })
+			question.Options = make([]Option, len(options))
+			for j, o := range options {
+				question.Options[j] = Option{ID: o.ID, Text: o.Text}
+			}
+		}
+		doc.Questions[i] = question
+	}
+	return doc
+}
+
+// Validate checks the document and reports every problem at once.
+func (d *Document) Validate() error {
+	var issues []Issue
+	add := func(path, format string, args ...interface{}) {
+		issues = append(issues, Issue{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch {
+	case d.Version == 0:
+		add("version", "is required")
+	case d.Version != CurrentVersion:
+		add("version", "unsupported version %d, expected %d", d.Version, CurrentVersion)
+	}
+
+	if d.Key != "" {
+		if len(d.Key) > maxKeyLength {
+			add("key", "must be at most %d characters long", maxKeyLength)
+		} else if !keyPattern.MatchString(d.Key) {
+			add("key", "may only contain lowercase letters, digits, '.', '_' and '-', and must start with a letter or digit")
+		}
+	}
+
+	if strings.TrimSpace(d.Title) == "" {
+		add("title", "must not be empty")
+	} else if len(d.Title) > maxTitleLength {
+		add("title", "must be at most %d characters long", maxTitleLength)
+	}
+
+	switch d.Settings.Access {
+	case "", gomodel.FormAccessPrivate, gomodel.FormAccessByLink, gomodel.FormAccessPublic:
+	default:
+		add("settings.access", "unknown access %q", d.Settings.Access)
+	}
+	switch d.Settings.ResponseNotifications {
+	case "", gomodel.ResponseNotificationsNone, gomodel.ResponseNotificationsEach,
+		gomodel.ResponseNotificationsHourly, gomodel.ResponseNotificationsDaily:
+	default:
+		add("settings.responseNotifications", "unknown notification mode %q", d.Settings.ResponseNotifications)
+	}
+	if d.Settings.ResponseEditDeadline != "" {
+		if _, err := time.Parse(time.RFC3339, d.Settings.ResponseEditDeadline); err != nil {
+			add("settings.responseEditDeadline", "must be an RFC3339 time, got %q", d.Settings.ResponseEditDeadline)
+		}
+	}
+
+	questionIDs := make(map[string]string)
+	questionKeys := make(map[string]string)
+	optionIDs := make(map[string]string)
+	respondentEmail := ""
+	for i, q := range d.Questions {
+		path := fmt.Sprintf("questions[%d]", i)
+
+		if q.ID != "" {
+			if other, ok := questionIDs[q.ID]; ok {
+				add(path+".id", "duplicates the id of %s", other)
+			} else {
+				questionIDs[q.ID] = path
+			}
+		}
+		if q.Key != "" {
+			if !formschema.ValidQuestionKey(q.Key) {
+				add(path+".key", "may only contain latin letters, digits and '_', and must not start with a digit")
+			} else if other, ok := questionKeys[q.Key]; ok {
+				add(path+".key", "duplicates the key of %s", other)
+			} else {
+				questionKeys[q.Key] = path
+			}
+		}
+		if strings.TrimSpace(q.Text) == "" {
+			add(path+".text", "must not be empty")
+		}
+		if q.Type == "" {
+			add(path+".type", "is required")
+		} else if !validType(q.Type) {
+			add(path+".type", "unknown question type %q", q.Type)
+		}
+
+		if q.RespondentEmail {
+			switch {
+			case q.Type != gomodel.QuestionTypeEmail:
+				add(path+".respondentEmail", "only an EMAIL question can hold the respondent address")
+			case respondentEmail != "":
+				add(path+".respondentEmail", "only one question can hold the respondent address, %s already does", respondentEmail)
+			default:
+				respondentEmail = path
+			}
+		}
+
+		if len(q.Options) > 0 && !isChoice(q.Type) {
+			add(path+".options", "only SINGLE_CHOICE and MULTIPLE_CHOICE questions have options")
+			continue
+		}
+		for j, o := range q.Options {
+			optionPath := fmt.Sprintf("%s.options[%d]", path, j)
+			if o.ID != "" {
+				if other, ok := optionIDs[o.ID]; ok {
+					add(optionPath+".id", "duplicates the id of %s", other)
+				} else {
+					optionIDs[o.ID] = optionPath
+				}
+			}
+			if strings.TrimSpace(o.Text) == "" {
+				add(optionPath+".text", "must not be empty")
+			}
+		}
+	}
+
+	if len(issues) > 0 {
+		return &ValidationError{Issues: issues}
+	}
+	return nil
+}
+
+// Form turns a valid document into an unsaved form to copy from, like a built-in template.
+func (d *Document) Form() *gomodel.Form {
+	form := &gomodel.Form{
+		Title:                 strings.TrimSpace(d.Title),
+		Description:           d.Description,
+		Tags:                  labels.Normalize(d.Tags),
+		Access:                d.Settings.Access,
+		AllowResponseEditing:  d.Settings.AllowResponseEditing,
+		ResponseNotifications: d.Settings.ResponseNotifications,
+		ReviewStatuses:        labels.Normalize(d.Settings.ReviewStatuses),
+		Questions:             make([]gomodel.Question, len(d.Questions)),
+	}
+	if d.Key != "" {
+		key := d.Key
+		form.ExternalKey = &key
+	}
+	if d.Settings.ResponseEditDeadline != "" {
+		deadline, _ := time.Parse(time.RFC3339, d.Settings.ResponseEditDeadline)
+		form.ResponseEditDeadline = &deadline
+	}
+
+	for i, q := range d.Questions {
+		question := gomodel.Question{
+			Text:            q.Text,
+			Type:            q.Type,
+			Required:        q.Required,
+			Order:           int32(i + 1),
+			RespondentEmail: q.RespondentEmail,
+			Options:         make([]*gomodel.Option, len(q.Options)),
+		}
+		if q.Key != "" {
+			key := q.Key
+			question.Key = &key
+		}
+		for j, o := range q.Options {
+			question.Options[j] = &gomodel.Option{Text: o.Text, Order: int32(j + 1)}
+		}
+		form.Questions[i] = question
+	}
+	return form
+}
+
+// Normalized returns a copy of a valid document without IDs and with defaults spelled
+// out, so two documents describing the same form compare equal.
+func (d *Document) Normalized() *Document {
+	n := &Document{
+		Version:     d.Version,
+		Key:         d.Key,
+		Title:       strings.TrimSpace(d.Title),
+		Description: d.Description,
+		Tags:        nilIfEmpty(labels.Normalize(d.Tags)),
+		Settings: Settings{
+			Access:                d.Settings.Access,
+			AllowResponseEditing:  d.Settings.AllowResponseEditing,
+			ResponseNotifications: d.Settings.ResponseNotifications,
+			ReviewStatuses:        nilIfEmpty(labels.Normalize(d.Settings.ReviewStatuses)),
+		},
+		Questions: make([]Question, len(d.Questions)),
+	}
+	if n.Settings.Access == "" {
+		n.Settings.Access = gomodel.FormAccessPrivate
+	}
+	if n.Settings.ResponseNotifications == "" {
+		n.Settings.ResponseNotifications = gomodel.ResponseNotificationsNone
+	}
+	if d.Settings.ResponseEditDeadline != "" {
+		deadline, _ := time.Parse(time.RFC3339, d.Settings.ResponseEditDeadline)
+		n.Settings.ResponseEditDeadline = deadline.UTC().Format(time.RFC3339)
+	}
+
+	for i, q := range d.Questions {
+		question := Question{
+			Key:             q.Key,
+			Text:            q.Text,
+			Type:            q.Type,
+			Required:        q.Required,
+			RespondentEmail: q.RespondentEmail,
+		}
+		for _, o := range q.Options {
+			question.Options = append(question.Options, Option{Text: o.Text})
+		}
+		n.Questions[i] = question
+	}
+	return n
+}
+
+// Equal reports whether two valid documents describe the same form, IDs and the form
+// key aside: the key names the form rather than describing it, and a document sent to
+// an existing form need not repeat it.
+func Equal(a, b *Document) bool {
+	na, nb := a.Normalized(), b.Normalized()
+	na.Key, nb.Key = "", ""
+	return reflect.DeepEqual(na, nb)
+}
+
+// SameQuestions reports whether two valid documents have the same questions and options.
+func SameQuestions(a, b *Document) bool {
+	return reflect.DeepEqual(a.Normalized().Questions, b.Normalized().Questions)
+}
+
+// Mapping pairs an ID from a document with the ID its question or option got on import.
+type Mapping struct {
+	SourceID string
+	ID       string
+}
+
+// IDMap pairs the IDs given in the document with those of the form imported from it.
+// The form must be the copy of d.Form(), with questions and options in document order.
+func (d *Document) IDMap(form *gomodel.Form) (questions, options []Mapping) {
+	questions = make([]Mapping, 0, len(d.Questions))
+	options = make([]Mapping, 0)
+	for i, q := range d.Questions {
+		if q.ID != "" {
+			questions = append(questions, Mapping{SourceID: q.ID, ID: form.Questions[i].ID})
+		}
+		for j, o := range q.Options {
+			if o.ID != "" {
+				options = append(options, Mapping{SourceID: o.ID, ID: form.Questions[i].Options[j].ID})
+			}
+		}
+	}
+	return questions, options
+}
+
+func validType(t gomodel.QuestionType) bool {
+	switch t {
+	case gomodel.QuestionTypeShortText, gomodel.QuestionTypeParagraph, gomodel.QuestionTypeBoolean,
+		gomodel.QuestionTypeNumber, gomodel.QuestionTypePhone, gomodel.QuestionTypeDate,
+		gomodel.QuestionTypeEmail, gomodel.QuestionTypeSingleChoice, gomodel.QuestionTypeMultipleChoice:
+		return true
+	}
+	return false
+}
+
+func isChoice(t gomodel.QuestionType) bool {
+	return t == gomodel.QuestionTypeSingleChoice || t == gomodel.QuestionTypeMultipleChoice
+}
+
+func nilIfEmpty(labels []string) []string {
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
@@ -0,0 +1,44 @@
+package formdoc
+
+import "testing"
+
+func mustParse(t *testing.T, data string) *Document {
+	t.Helper()
+	doc, err := Parse([]byte(data), FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return doc
+}
+
+func TestEqualIgnoresKey(t *testing.T) {
+	keyed := mustParse(t, `
+version: 1
+key: feedback
+title: Feedback
+questions:
+  - text: How did we do?
+    type: SHORT_TEXT
+`)
+	unkeyed := mustParse(t, `
+version: 1
+title: Feedback
+questions:
+  - text: How did we do?
+    type: SHORT_TEXT
+`)
+	if !Equal(keyed, unkeyed) {
+		t.Error("Equal() = false for documents that differ only in the key")
+	}
+
+	renamed := mustParse(t, `
+version: 1
+title: Feedback
+questions:
+  - text: How did we do today?
+    type: SHORT_TEXT
+`)
+	if Equal(keyed, renamed) {
+		t.Error("Equal() = true for documents with different questions")
+	}
+}
@@ -0,0 +1,158 @@
+package formdoc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the encoding of a document.
+type Format string
+
+const (
+	FormatJSON Format = "JSON"
+	FormatYAML Format = "YAML"
+)
+
+// DetectFormat guesses the encoding of a document: JSON documents are objects,
+// anything else is read as YAML.
+func DetectFormat(data []byte) Format {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+// Parse decodes and validates a document. Syntax errors, unknown fields and invalid
+// values are all reported as a ValidationError.
+func Parse(data []byte, format Format) (*Document, error) {
+	if format == "" {
+		format = DetectFormat(data)
+	}
+
+	var doc Document
+	var err error
+	switch format {
+	case FormatJSON:
+		err = decodeJSON(data, &doc)
+	case FormatYAML:
+		err = decodeYAML(data, &doc)
+	default:
+		return nil, fmt.Errorf("unknown document format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// Marshal encodes the document.
+func Marshal(doc *Document, format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return json.MarshalIndent(doc, "", "  ")
+	case FormatYAML:
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(doc); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("unknown document format %q", format)
+}
+
+func decodeJSON(data []byte, doc *Document) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(doc)
+	if err == nil {
+		// A second value after the document is as wrong as a syntax error
+		if decoder.Decode(&struct{}{}) != io.EOF {
+			return &ValidationError{Issues: []Issue{{Message: "unexpected data after the document"}}}
+		}
+		return nil
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return &ValidationError{Issues: []Issue{{Message: fmt.Sprintf("%s: %v", position(data, syntaxErr.Offset), err)}}}
+	case errors.As(err, &typeErr):
+		return &ValidationError{Issues: []Issue{{
+			Path:    fieldPath(typeErr.Field),
+			Message: fmt.Sprintf("%s: expected %s, got %s", position(data, typeErr.Offset), typeErr.Type, typeErr.Value),
+		}}}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return &ValidationError{Issues: []Issue{{Path: field, Message: "unknown field"}}}
+	}
+	return &ValidationError{Issues: []Issue{{Message: err.Error()}}}
+}
+
+func decodeYAML(data []byte, doc *Document) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err := decoder.Decode(doc)
+	if err == nil {
+		return nil
+	}
+	if err == io.EOF {
+		return &ValidationError{Issues: []Issue{{Message: "document is empty"}}}
+	}
+
+	// The messages of yaml.v3 already carry line numbers
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		issues := make([]Issue, len(typeErr.Errors))
+		for i, message := range typeErr.Errors {
+			issues[i] = Issue{Message: message}
+		}
+		return &ValidationError{Issues: issues}
+	}
+	return &ValidationError{Issues: []Issue{{Message: strings.TrimPrefix(err.Error(), "yaml: ")}}}
+}
+
+// fieldPath rewrites a field reference of encoding/json, such as "questions.0.text",
+// in the notation of Issue paths, "questions[0].text".
+func fieldPath(field string) string {
+	parts := strings.Split(field, ".")
+	var b strings.Builder
+	for i, part := range parts {
+		if _, err := strconv.Atoi(part); err == nil && i > 0 {
+			b.WriteString("[" + part + "]")
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+// position turns a byte offset into a line:column reference.
+func position(data []byte, offset int64) string {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+	return fmt.Sprintf("line %d, column %d", line, column)
+}
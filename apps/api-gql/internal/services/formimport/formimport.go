@@ -0,0 +1,128 @@
+// Package formimport converts form definitions exported from other services into forms.
+// Questions that have no counterpart are left out and reported, so the owner can
+// recreate them by hand.
+package formimport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// Source names the service an export file comes from.
+type Source string
+
+const (
+	SourceGoogleForms Source = "GOOGLE_FORMS"
+	SourceTypeform    Source = "TYPEFORM"
+)
+
+var ErrUnknownSource = errors.New("unknown import source")
+
+// Note tells the owner about an item that was left out or imported only approximately.
+type Note struct {
+	// Title and Type of the item in the source
+	Title string
+	Type  string
+	// Skipped is set when the item was not imported at all
+	Skipped bool
+	Message string
+}
+
+// Result is a form converted from an export file. The form is unsaved, like the one of
+// a built-in template, and has its questions in source order.
+type Result struct {
+	Form  *gomodel.Form
+	Notes []Note
+}
+
+// Convert reads an export file of the source.
+func Convert(source Source, data []byte) (*Result, error) {
+	switch source {
+	case SourceGoogleForms:
+		return convertGoogleForm(data)
+	case SourceTypeform:
+		return convertTypeform(data)
+	}
+	return nil, ErrUnknownSource
+}
+
+// builder collects questions and notes while a definition is walked.
+type builder struct {
+	result Result
+}
+
+func newBuilder(title, description string) *builder {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = "Imported form"
+	}
+	return &builder{result: Result{Form: &gomodel.Form{
+		Title:       title,
+		Description: strings.TrimSpace(description),
+		Access:      gomodel.FormAccessPrivate,
+		Questions:   make([]gomodel.Question, 0),
+	}}}
+}
+
+func (b *builder) question(text string, questionType gomodel.QuestionType, required bool, options []string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		text = fmt.Sprintf("Question %d", len(b.result.Form.Questions)+1)
+	}
+
+	question := gomodel.Question{
+		Text:     text,
+		Type:     questionType,
+		Required: required,
+		Order:    int32(len(b.result.Form.Questions) + 1),
+		Options:  make([]*gomodel.Option, 0, len(options)),
+	}
+	for i, o := range options {
+		question.Options = append(question.Options, &gomodel.Option{Text: o, Order: int32(i + 1)})
+	}
+	b.result.Form.Questions = append(b.result.Form.Questions, question)
+}
+
+func (b *builder) skip(title, itemType, format string, args ...interface{}) {
+	b.result.Notes = append(b.result.Notes, Note{Title: title, Type: itemType, Skipped: true, Message: fmt.Sprintf(format, args...)})
+}
+
+func (b *builder) note(title, itemType, format string, args ...interface{}) {
+	b.result.Notes = append(b.result.Notes, Note{Title: title, Type: itemType, Message: fmt.Sprintf(format, args...)})
+}
+
+// maxScalePoints bounds the scales read from export files, which are not trusted
+const maxScalePoints = 11
+
+// scale lists the points of a rating scale as options. Reversed bounds or a scale of
+// more than maxScalePoints points are clamped, with a note.
+func (b *builder) scale(title, itemType string, from, to int) []string {
+	if to < from {
+		b.note(title, itemType, "the scale from %d to %d is reversed, only %d was kept", from, to, from)
+		to = from
+	}
+	// The difference of two ints fits an uint even when it overflows an int
+	if uint(to-from) >= maxScalePoints {
+		b.note(title, itemType, "the scale from %d to %d has too many points, only %d to %d were kept", from, to, from, from+maxScalePoints-1)
+		to = from + maxScalePoints - 1
+	}
+
+	// Counting points keeps the loop from wrapping around at the end of the int range
+	options := make([]string, 0, to-from+1)
+	for i := 0; i <= to-from; i++ {
+		options = append(options, strconv.Itoa(from+i))
+	}
+	return options
+}
+
+func decode(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid export file: %w", err)
+	}
+	return nil
+}
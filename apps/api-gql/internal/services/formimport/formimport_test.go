@@ -0,0 +1,92 @@
+package formimport
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to int
+		want     []string
+		noted    bool
+	}{
+		{name: "plain", from: 1, to: 5, want: []string{"1", "2", "3", "4", "5"}},
+		{name: "single point", from: 3, to: 3, want: []string{"3"}},
+		{name: "reversed", from: 5, to: 1, want: []string{"5"}, noted: true},
+		{name: "too many points", from: 0, to: 1000000, want: []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}, noted: true},
+		{name: "whole int range", from: math.MinInt, to: math.MaxInt, noted: true},
+		{name: "end of int range", from: math.MaxInt - 2, to: math.MaxInt, want: []string{"9223372036854775805", "9223372036854775806", "9223372036854775807"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBuilder("form", "")
+			got := b.scale("question", "scaleQuestion", tt.from, tt.to)
+			if tt.want != nil && !slices.Equal(got, tt.want) {
+				t.Errorf("scale(%d, %d) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+			if len(got) == 0 || len(got) > maxScalePoints {
+				t.Errorf("scale(%d, %d) has %d points", tt.from, tt.to, len(got))
+			}
+			if noted := len(b.result.Notes) > 0; noted != tt.noted {
+				t.Errorf("scale(%d, %d) noted = %v, want %v", tt.from, tt.to, noted, tt.noted)
+			}
+		})
+	}
+}
+
+func TestConvertGoogleFormScales(t *testing.T) {
+	data := []byte(`{
+		"formId": "f",
+		"info": {"title": "Survey"},
+		"items": [
+			{"title": "Scale", "questionItem": {"question": {"scaleQuestion": {"low": 10, "high": -10}}}},
+			{"title": "Rating", "questionItem": {"question": {"ratingQuestion": {"ratingScaleLevel": 1000000000}}}}
+		]
+	}`)
+
+	result, err := Convert(SourceGoogleForms, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Form.Questions) != 2 {
+		t.Fatalf("got %d questions, want 2", len(result.Form.Questions))
+	}
+	if n := len(result.Form.Questions[0].Options); n != 1 {
+		t.Errorf("reversed scale has %d options, want 1", n)
+	}
+	if n := len(result.Form.Questions[1].Options); n != maxScalePoints {
+		t.Errorf("huge rating has %d options, want %d", n, maxScalePoints)
+	}
+}
+
+func TestConvertTypeformScales(t *testing.T) {
+	data := []byte(`{
+		"id": "t",
+		"title": "Survey",
+		"fields": [
+			{"title": "Opinion", "type": "opinion_scale", "properties": {"steps": -4}},
+			{"title": "Rating", "type": "rating", "properties": {"steps": 500}},
+			{"title": "Default", "type": "opinion_scale", "properties": {}}
+		]
+	}`)
+
+	result, err := Convert(SourceTypeform, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int{1, maxScalePoints, 11}
+	for i, question := range result.Form.Questions {
+		if n := len(question.Options); n != want[i] {
+			t.Errorf("question %q has %d options, want %d", question.Text, n, want[i])
+		}
+	}
+}
+
+func TestConvertUnknownSource(t *testing.T) {
+	if _, err := Convert("CSV", []byte(`{}`)); err != ErrUnknownSource {
+		t.Errorf("Convert() error = %v, want ErrUnknownSource", err)
+	}
+}
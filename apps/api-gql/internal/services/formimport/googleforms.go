@@ -0,0 +1,138 @@
+package formimport
+
+import (
+	"errors"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// googleForm is the form resource of the Google Forms API, as returned by forms.get.
+type googleForm struct {
+	FormID string `json:"formId"`
+	Info   struct {
+		Title         string `json:"title"`
+		DocumentTitle string `json:"documentTitle"`
+		Description   string `json:"description"`
+	} `json:"info"`
+	Items []googleItem `json:"items"`
+}
+
+type googleItem struct {
+	ItemID            string              `json:"itemId"`
+	Title             string              `json:"title"`
+	QuestionItem      *googleQuestionItem `json:"questionItem"`
+	QuestionGroupItem *struct{}           `json:"questionGroupItem"`
+	PageBreakItem     *struct{}           `json:"pageBreakItem"`
+	TextItem          *struct{}           `json:"textItem"`
+	ImageItem         *struct{}           `json:"imageItem"`
+	VideoItem         *struct{}           `json:"videoItem"`
+}
+
+type googleQuestionItem struct {
+	Question struct {
+		Required       bool `json:"required"`
+		ChoiceQuestion *struct {
+			Type    string `json:"type"`
+			Options []struct {
+				Value   string `json:"value"`
+				IsOther bool   `json:"isOther"`
+			} `json:"options"`
+		} `json:"choiceQuestion"`
+		TextQuestion *struct {
+			Paragraph bool `json:"paragraph"`
+		} `json:"textQuestion"`
+		ScaleQuestion *struct {
+			Low  int `json:"low"`
+			High int `json:"high"`
+		} `json:"scaleQuestion"`
+		RatingQuestion *struct {
+			RatingScaleLevel int `json:"ratingScaleLevel"`
+		} `json:"ratingQuestion"`
+		DateQuestion *struct {
+			IncludeTime bool `json:"includeTime"`
+		} `json:"dateQuestion"`
+		TimeQuestion       *struct{} `json:"timeQuestion"`
+		FileUploadQuestion *struct{} `json:"fileUploadQuestion"`
+	} `json:"question"`
+}
+
+func convertGoogleForm(data []byte) (*Result, error) {
+	var source googleForm
+	if err := decode(data, &source); err != nil {
+		return nil, err
+	}
+	if source.FormID == "" && source.Items == nil {
+		return nil, errors.New("invalid export file: not a Google Forms form resource")
+	}
+
+	title := source.Info.Title
+	if title == "" {
+		title = source.Info.DocumentTitle
+	}
+	b := newBuilder(title, source.Info.Description)
+
+	for _, item := range source.Items {
+		switch {
+		case item.QuestionItem != nil:
+			b.googleQuestion(item.Title, item.QuestionItem)
+		case item.QuestionGroupItem != nil:
+			b.skip(item.Title, "questionGroupItem", "grid questions have no counterpart")
+		case item.PageBreakItem != nil:
+			b.skip(item.Title, "pageBreakItem", "forms have a single page")
+		case item.TextItem != nil, item.ImageItem != nil, item.VideoItem != nil:
+			b.skip(item.Title, "media", "only questions are imported")
+		}
+	}
+	return &b.result, nil
+}
+
+func (b *builder) googleQuestion(title string, item *googleQuestionItem) {
+	q := item.Question
+	switch {
+	case q.TextQuestion != nil:
+		questionType := gomodel.QuestionTypeShortText
+		if q.TextQuestion.Paragraph {
+			questionType = gomodel.QuestionTypeParagraph
+		}
+		b.question(title, questionType, q.Required, nil)
+
+	case q.ChoiceQuestion != nil:
+		questionType := gomodel.QuestionTypeSingleChoice
+		if q.ChoiceQuestion.Type == "CHECKBOX" {
+			questionType = gomodel.QuestionTypeMultipleChoice
+		}
+		options := make([]string, 0, len(q.ChoiceQuestion.Options))
+		for _, o := range q.ChoiceQuestion.Options {
+			if o.IsOther {
+				b.note(title, q.ChoiceQuestion.Type, "the free-text \"Other\" choice was left out")
+				continue
+			}
+			options = append(options, o.Value)
+		}
+		b.question(title, questionType, q.Required, options)
+
+	case q.ScaleQuestion != nil:
+		b.question(title, gomodel.QuestionTypeSingleChoice, q.Required, b.scale(title, "scaleQuestion", q.ScaleQuestion.Low, q.ScaleQuestion.High))
+		b.note(title, "scaleQuestion", "imported as a single choice between the points of the scale")
+
+	case q.RatingQuestion != nil:
+		b.question(title, gomodel.QuestionTypeSingleChoice, q.Required, b.scale(title, "ratingQuestion", 1, q.RatingQuestion.RatingScaleLevel))
+		b.note(title, "ratingQuestion", "imported as a single choice between the points of the rating")
+
+	case q.DateQuestion != nil:
+		b.question(title, gomodel.QuestionTypeDate, q.Required, nil)
+		if q.DateQuestion.IncludeTime {
+			b.note(title, "dateQuestion", "the time of day is kept with the date")
+		}
+
+	case q.TimeQuestion != nil:
+		b.question(title, gomodel.QuestionTypeShortText, q.Required, nil)
+		b.note(title, "timeQuestion", "imported as a short text question")
+
+	case q.FileUploadQuestion != nil:
+		b.skip(title, "fileUploadQuestion", "file uploads are not supported")
+
+	default:
+		b.skip(title, "question", "unknown question kind")
+	}
+}
@@ -0,0 +1,127 @@
+package formimport
+
+import (
+	"errors"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// typeform is a form definition of the Typeform Create API, as returned by GET /forms/{id}.
+type typeform struct {
+	ID     string          `json:"id"`
+	Title  string          `json:"title"`
+	Fields []typeformField `json:"fields"`
+}
+
+type typeformField struct {
+	Title      string `json:"title"`
+	Type       string `json:"type"`
+	Properties struct {
+		Description            string `json:"description"`
+		AllowMultipleSelection bool   `json:"allow_multiple_selection"`
+		AllowOtherChoice       bool   `json:"allow_other_choice"`
+		Choices                []struct {
+			Label string `json:"label"`
+		} `json:"choices"`
+		Steps      int             `json:"steps"`
+		StartAtOne bool            `json:"start_at_one"`
+		Fields     []typeformField `json:"fields"`
+	} `json:"properties"`
+	Validations struct {
+		Required bool `json:"required"`
+	} `json:"validations"`
+}
+
+func convertTypeform(data []byte) (*Result, error) {
+	var source typeform
+	if err := decode(data, &source); err != nil {
+		return nil, err
+	}
+	if source.ID == "" && source.Fields == nil {
+		return nil, errors.New("invalid export file: not a Typeform form definition")
+	}
+
+	b := newBuilder(source.Title, "")
+	for _, field := range source.Fields {
+		b.typeformField(field)
+	}
+	return &b.result, nil
+}
+
+func (b *builder) typeformField(field typeformField) {
+	title, required := field.Title, field.Validations.Required
+
+	simple := map[string]gomodel.QuestionType{
+		"short_text":   gomodel.QuestionTypeShortText,
+		"long_text":    gomodel.QuestionTypeParagraph,
+		"email":        gomodel.QuestionTypeEmail,
+		"phone_number": gomodel.QuestionTypePhone,
+		"number":       gomodel.QuestionTypeNumber,
+		"date":         gomodel.QuestionTypeDate,
+		"yes_no":       gomodel.QuestionTypeBoolean,
+		"legal":        gomodel.QuestionTypeBoolean,
+		"website":      gomodel.QuestionTypeShortText,
+	}
+	if questionType, ok := simple[field.Type]; ok {
+		b.question(title, questionType, required, nil)
+		if field.Type == "website" {
+			b.note(title, field.Type, "imported as a short text question")
+		}
+		return
+	}
+
+	switch field.Type {
+	case "multiple_choice", "dropdown", "picture_choice":
+		questionType := gomodel.QuestionTypeSingleChoice
+		if field.Properties.AllowMultipleSelection {
+			questionType = gomodel.QuestionTypeMultipleChoice
+		}
+		options := make([]string, len(field.Properties.Choices))
+		for i, c := range field.Properties.Choices {
+			options[i] = c.Label
+		}
+		b.question(title, questionType, required, options)
+		if field.Properties.AllowOtherChoice {
+			b.note(title, field.Type, "the free-text \"Other\" choice was left out")
+		}
+		if field.Type == "picture_choice" {
+			b.note(title, field.Type, "pictures were left out, only the labels of the choices were kept")
+		}
+
+	case "opinion_scale":
+		from := 0
+		if field.Properties.StartAtOne {
+			from = 1
+		}
+		steps := field.Properties.Steps
+		if steps == 0 {
+			steps = 11
+		}
+		b.question(title, gomodel.QuestionTypeSingleChoice, required, b.scale(title, field.Type, from, from+steps-1))
+		b.note(title, field.Type, "imported as a single choice between the points of the scale")
+
+	case "rating":
+		steps := field.Properties.Steps
+		if steps == 0 {
+			steps = 5
+		}
+		b.question(title, gomodel.QuestionTypeSingleChoice, required, b.scale(title, field.Type, 1, steps))
+		b.note(title, field.Type, "imported as a single choice between the points of the rating")
+
+	case "nps":
+		b.question(title, gomodel.QuestionTypeSingleChoice, required, b.scale(title, field.Type, 0, 10))
+		b.note(title, field.Type, "imported as a single choice between 0 and 10")
+
+	case "group", "inline_group":
+		for _, nested := range field.Properties.Fields {
+			b.typeformField(nested)
+		}
+
+	case "statement":
+		b.skip(title, field.Type, "only questions are imported")
+
+	default:
+		// file_upload, payment, matrix, ranking, calendly and the like
+		b.skip(title, field.Type, "this question type has no counterpart")
+	}
+}
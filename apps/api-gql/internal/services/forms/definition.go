@@ -0,0 +1,245 @@
+package forms
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrFormNotFound = errors.New("form not found")
+	ErrKeyTaken     = errors.New("another form already uses this key")
+)
+
+// IsKeyTaken reports whether err is the violation of a unique form key: keys are unique
+// among the forms of a workspace and among the personal forms of an owner.
+func IsKeyTaken(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" &&
+		(pgErr.ConstraintName == "idx_form_workspace_key" || pgErr.ConstraintName == "idx_form_owner_key")
+}
+
+// FindByKey looks up a form by its external key, among the personal forms of the owner
+// when workspaceID is nil or among the forms of the workspace otherwise.
+func (s *Service) FindByKey(ctx context.Context, ownerID string, workspaceID *string, key string) (*gomodel.Form, error) {
+	query := s.database.WithContext(ctx).Preload("Questions.Options").Where(`"externalKey" = ?`, key)
+	if workspaceID != nil {
+		query = query.Where(`"workspaceId" = ?`, *workspaceID)
+	} else {
+		query = query.Where(`owner_id = ? AND "workspaceId" IS NULL`, ownerID)
+	}
+
+	var form gomodel.Form
+	if err := query.First(&form).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFormNotFound
+		}
+		return nil, err
+	}
+	return &form, nil
+}
+
+// ReplaceDefinition gives the form the settings of definition and, with questions set,
+// brings its questions and options in line with those of definition. The form must have
+// its Questions.Options loaded. Questions are matched by key, or by position among the
+// questions without a key, and options by text, then by position; matched rows are
+// updated in place and keep their IDs, so stored answers still point at them. Only
+// questions and options missing from definition are deleted. It returns the new version.
+func (s *Service) ReplaceDefinition(ctx context.Context, form *gomodel.Form, definition *gomodel.Form, questions bool) (int64, error) {
+	var version int64
+	err := s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if version, err = BumpVersion(tx, form.ID, nil); err != nil {
+			return err
+		}
+
+		if err := tx.Model(form).Updates(map[string]interface{}{
+			"title":                 definition.Title,
+			"description":           definition.Description,
+			"tags":                  append(pq.StringArray{}, definition.Tags...),
+			"access":                definition.Access,
+			"reviewStatuses":        append(pq.StringArray{}, definition.ReviewStatuses...),
+			"allowResponseEditing":  definition.AllowResponseEditing,
+			"responseEditDeadline":  definition.ResponseEditDeadline,
+			"responseNotifications": definition.ResponseNotifications,
+		}).Error; err != nil {
+			return err
+		}
+
+		if !questions {
+			return nil
+		}
+		return applyQuestions(tx, planQuestions(form.ID, form.Questions, definition.Questions))
+	})
+	if err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// questionsPlan lists the writes that turn the questions of a form into the wanted ones.
+type questionsPlan struct {
+	// updateQuestions keep their IDs and take the fields of the wanted question
+	updateQuestions []gomodel.Question
+	// createQuestions are new, with their options
+	createQuestions []gomodel.Question
+	deleteQuestions []string
+	updateOptions   []*gomodel.Option
+	// createOptions are new options of kept questions
+	createOptions []*gomodel.Option
+	deleteOptions []string
+}
+
+// planQuestions matches the wanted questions against the current ones of the form.
+// Options of the current questions must be loaded.
+func planQuestions(formID string, current, wanted []gomodel.Question) questionsPlan {
+	byKey := make(map[string]*gomodel.Question)
+	var keyless []*gomodel.Question
+	for i := range current {
+		if current[i].Key != nil {
+			byKey[*current[i].Key] = &current[i]
+		} else {
+			keyless = append(keyless, &current[i])
+		}
+	}
+
+	var plan questionsPlan
+	kept := make(map[string]bool)
+	nextKeyless := 0
+	for _, q := range wanted {
+		var match *gomodel.Question
+		if q.Key != nil {
+			match = byKey[*q.Key]
+		} else if nextKeyless < len(keyless) {
+			match = keyless[nextKeyless]
+			nextKeyless++
+		}
+
+		if match == nil {
+			question := q
+			question.ID = uuid.New().String()
+			question.FormID = formID
+			question.Options = make([]*gomodel.Option, len(q.Options))
+			for j, o := range q.Options {
+				question.Options[j] = &gomodel.Option{ID: uuid.New().String(), QuestionID: question.ID, Text: o.Text, Order: o.Order}
+			}
+			plan.createQuestions = append(plan.createQuestions, question)
+			continue
+		}
+
+		kept[match.ID] = true
+		question := q
+		question.ID = match.ID
+		question.FormID = formID
+		question.Options = nil
+		plan.updateQuestions = append(plan.updateQuestions, question)
+		plan.planOptions(match.ID, match.Options, q.Options)
+	}
+
+	for i := range current {
+		if kept[current[i].ID] {
+			continue
+		}
+		plan.deleteQuestions = append(plan.deleteQuestions, current[i].ID)
+		for _, o := range current[i].Options {
+			plan.deleteOptions = append(plan.deleteOptions, o.ID)
+		}
+	}
+	return plan
+}
+
+// planOptions matches the wanted options of a kept question by text first, so reordered
+// options keep their IDs, then pairs the rest in order, so an option with edited text
+// keeps its ID.
+func (p *questionsPlan) planOptions(questionID string, current, wanted []*gomodel.Option) {
+	current = append([]*gomodel.Option(nil), current...)
+	sort.SliceStable(current, func(i, j int) bool { return current[i].Order < current[j].Order })
+
+	matches := make([]*gomodel.Option, len(wanted))
+	used := make(map[string]bool)
+	for i, o := range wanted {
+		for _, c := range current {
+			if !used[c.ID] && c.Text == o.Text {
+				matches[i], used[c.ID] = c, true
+				break
+			}
+		}
+	}
+	next := 0
+	for i := range wanted {
+		if matches[i] != nil {
+			continue
+		}
+		for next < len(current) && used[current[next].ID] {
+			next++
+		}
+		if next < len(current) {
+			matches[i], used[current[next].ID] = current[next], true
+		}
+	}
+
+	for i, o := range wanted {
+		if matches[i] == nil {
+			p.createOptions = append(p.createOptions, &gomodel.Option{ID: uuid.New().String(), QuestionID: questionID, Text: o.Text, Order: o.Order})
+			continue
+		}
+		if matches[i].Text != o.Text || matches[i].Order != o.Order {
+			p.updateOptions = append(p.updateOptions, &gomodel.Option{ID: matches[i].ID, QuestionID: questionID, Text: o.Text, Order: o.Order})
+		}
+	}
+	for _, c := range current {
+		if !used[c.ID] {
+			p.deleteOptions = append(p.deleteOptions, c.ID)
+		}
+	}
+}
+
+func applyQuestions(tx *gorm.DB, plan questionsPlan) error {
+	if len(plan.deleteOptions) > 0 {
+		if err := tx.Where("id IN ?", plan.deleteOptions).Delete(&gomodel.Option{}).Error; err != nil {
+			return err
+		}
+	}
+	if len(plan.deleteQuestions) > 0 {
+		if err := tx.Where("id IN ?", plan.deleteQuestions).Delete(&gomodel.Question{}).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, q := range plan.updateQuestions {
+		if err := tx.Model(&gomodel.Question{ID: q.ID}).Updates(map[string]interface{}{
+			"key":              q.Key,
+			"text":             q.Text,
+			"type":             q.Type,
+			"required":         q.Required,
+			"order":            q.Order,
+			"respondent_email": q.RespondentEmail,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	for _, o := range plan.updateOptions {
+		if err := tx.Model(&gomodel.Option{ID: o.ID}).Updates(map[string]interface{}{
+			"text":  o.Text,
+			"order": o.Order,
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(plan.createOptions) > 0 {
+		if err := tx.Create(&plan.createOptions).Error; err != nil {
+			return err
+		}
+	}
+	if len(plan.createQuestions) > 0 {
+		return tx.Create(&plan.createQuestions).Error
+	}
+	return nil
+}
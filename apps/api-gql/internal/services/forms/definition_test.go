@@ -0,0 +1,157 @@
+package forms
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestIsKeyTaken(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"workspace key", &pgconn.PgError{Code: "23505", ConstraintName: "idx_form_workspace_key"}, true},
+		{"owner key", fmt.Errorf("create: %w", &pgconn.PgError{Code: "23505", ConstraintName: "idx_form_owner_key"}), true},
+		{"question key", &pgconn.PgError{Code: "23505", ConstraintName: "idx_question_key"}, false},
+		{"other error", &pgconn.PgError{Code: "23503", ConstraintName: "idx_form_owner_key"}, false},
+		{"not postgres", errors.New("boom"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		if got := IsKeyTaken(tt.err); got != tt.want {
+			t.Errorf("%s: IsKeyTaken() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPlanQuestionsKeepsAnswers(t *testing.T) {
+	key := func(k string) *string { return &k }
+	current := []gomodel.Question{
+		{ID: "q-name", Key: key("name"), Text: "Name", Type: gomodel.QuestionTypeShortText, Order: 1},
+		{ID: "q-color", Key: key("color"), Text: "Color", Type: gomodel.QuestionTypeSingleChoice, Order: 2, Options: []*gomodel.Option{
+			{ID: "o-red", QuestionID: "q-color", Text: "Red", Order: 1},
+			{ID: "o-blue", QuestionID: "q-color", Text: "Blue", Order: 2},
+			{ID: "o-green", QuestionID: "q-color", Text: "Green", Order: 3},
+		}},
+		{ID: "q-note", Text: "Note", Type: gomodel.QuestionTypeParagraph, Order: 3},
+		{ID: "q-old", Key: key("old"), Text: "Old", Type: gomodel.QuestionTypeShortText, Order: 4},
+	}
+	wanted := []gomodel.Question{
+		{Key: key("name"), Text: "Full name", Type: gomodel.QuestionTypeShortText, Required: true, Order: 1},
+		{Key: key("color"), Text: "Color", Type: gomodel.QuestionTypeSingleChoice, Order: 2, Options: []*gomodel.Option{
+			{Text: "Blue", Order: 1},
+			{Text: "Crimson", Order: 2},
+			{Text: "Yellow", Order: 3},
+			{Text: "Black", Order: 4},
+		}},
+		{Text: "Notes", Type: gomodel.QuestionTypeParagraph, Order: 3},
+		{Key: key("age"), Text: "Age", Type: gomodel.QuestionTypeNumber, Order: 4},
+	}
+
+	plan := planQuestions("form", current, wanted)
+	questions, options := applyPlan(current, plan)
+
+	// answers stored before the apply: question -> selected options
+	answers := map[string][]string{"q-name": nil, "q-color": {"o-blue", "o-red"}, "q-note": nil}
+	for questionID, selected := range answers {
+		if _, ok := questions[questionID]; !ok {
+			t.Errorf("answer to %s lost its question", questionID)
+		}
+		for _, optionID := range selected {
+			if _, ok := options[optionID]; !ok {
+				t.Errorf("answer to %s lost its option %s", questionID, optionID)
+			}
+		}
+	}
+
+	if q := questions["q-name"]; q.Text != "Full name" || !q.Required {
+		t.Errorf("q-name = %+v, want the edited text and required", q)
+	}
+	if q := questions["q-note"]; q.Text != "Notes" {
+		t.Errorf("q-note text = %q, want the keyless question matched by position", q.Text)
+	}
+	if _, ok := questions["q-old"]; ok {
+		t.Error("q-old is kept, want it deleted")
+	}
+	if o := options["o-blue"]; o.Order != 1 {
+		t.Errorf("o-blue order = %d, want the reordered option matched by text", o.Order)
+	}
+	if o := options["o-red"]; o.Text != "Crimson" {
+		t.Errorf("o-red text = %q, want the renamed option matched by position", o.Text)
+	}
+	if o := options["o-green"]; o.Text != "Yellow" {
+		t.Errorf("o-green text = %q, want the renamed option matched by position", o.Text)
+	}
+	if len(plan.createQuestions) != 1 || *plan.createQuestions[0].Key != "age" || plan.createQuestions[0].FormID != "form" {
+		t.Errorf("createQuestions = %+v, want the question age", plan.createQuestions)
+	}
+	if len(plan.createOptions) != 1 || plan.createOptions[0].Text != "Black" || plan.createOptions[0].QuestionID != "q-color" {
+		t.Errorf("createOptions = %+v, want the option Black of q-color", plan.createOptions)
+	}
+}
+
+func TestPlanQuestionsEditOneText(t *testing.T) {
+	key := func(k string) *string { return &k }
+	current := []gomodel.Question{
+		{ID: "q1", Key: key("a"), Text: "A", Type: gomodel.QuestionTypeSingleChoice, Order: 1, Options: []*gomodel.Option{
+			{ID: "o1", QuestionID: "q1", Text: "Yes", Order: 1},
+			{ID: "o2", QuestionID: "q1", Text: "No", Order: 2},
+		}},
+		{ID: "q2", Key: key("b"), Text: "B", Type: gomodel.QuestionTypeShortText, Order: 2},
+	}
+	wanted := []gomodel.Question{
+		{Key: key("a"), Text: "A?", Type: gomodel.QuestionTypeSingleChoice, Order: 1, Options: []*gomodel.Option{
+			{Text: "Yes", Order: 1},
+			{Text: "No", Order: 2},
+		}},
+		{Key: key("b"), Text: "B", Type: gomodel.QuestionTypeShortText, Order: 2},
+	}
+
+	plan := planQuestions("form", current, wanted)
+	if len(plan.createQuestions)+len(plan.deleteQuestions)+len(plan.createOptions)+len(plan.deleteOptions)+len(plan.updateOptions) != 0 {
+		t.Fatalf("plan = %+v, want only question updates", plan)
+	}
+	if len(plan.updateQuestions) != 2 || plan.updateQuestions[0].ID != "q1" || plan.updateQuestions[0].Text != "A?" {
+		t.Errorf("updateQuestions = %+v, want q1 with the new text", plan.updateQuestions)
+	}
+}
+
+// applyPlan runs the plan against the questions in memory and returns the questions and
+// options left, by ID.
+func applyPlan(current []gomodel.Question, plan questionsPlan) (map[string]gomodel.Question, map[string]gomodel.Option) {
+	questions := make(map[string]gomodel.Question)
+	options := make(map[string]gomodel.Option)
+	for _, q := range current {
+		questions[q.ID] = q
+		for _, o := range q.Options {
+			options[o.ID] = *o
+		}
+	}
+	for _, id := range plan.deleteOptions {
+		delete(options, id)
+	}
+	for _, id := range plan.deleteQuestions {
+		delete(questions, id)
+	}
+	for _, q := range plan.updateQuestions {
+		questions[q.ID] = q
+	}
+	for _, o := range plan.updateOptions {
+		options[o.ID] = *o
+	}
+	for _, o := range plan.createOptions {
+		options[o.ID] = *o
+	}
+	for _, q := range plan.createQuestions {
+		questions[q.ID] = q
+		for _, o := range q.Options {
+			options[o.ID] = *o
+		}
+	}
+	return questions, options
+}
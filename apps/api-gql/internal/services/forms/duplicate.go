@@ -0,0 +1,124 @@
+package forms
+
+import (
+	"context"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// CopyParams says where a copy of a form goes and how it is called.
+type CopyParams struct {
+	OwnerID     string
+	WorkspaceID *string
+	FolderID    *string
+	// Title of the copy; the title of the source when empty
+	Title string
+	// ExternalKey is never taken from the source, so copies do not clash with it
+	ExternalKey *string
+}
+
+// Duplicate deep-copies the form with its questions, options and settings in one
+// transaction. Responses, members, webhooks, the template mark and the closed state
+// are not copied. The source must have its Questions.Options loaded. A key already used
+// in the target space fails with ErrKeyTaken.
+func (s *Service) Duplicate(ctx context.Context, source *gomodel.Form, params CopyParams) (*gomodel.Form, error) {
+	form := copyForm(source, params)
+
+	err := s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(form).Error
+	})
+	if IsKeyTaken(err) {
+		return nil, ErrKeyTaken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return form, nil
+}
+
+// copyForm builds an unsaved copy of the form with fresh IDs.
+func copyForm(source *gomodel.Form, params CopyParams) *gomodel.Form {
+	title := params.Title
+	if title == "" {
+		title = source.Title
+	}
+
+	form := &gomodel.Form{
+		ID:                    uuid.New().String(),
+		OwnerID:               params.OwnerID,
+		WorkspaceID:           params.WorkspaceID,
+		FolderID:              params.FolderID,
+		ExternalKey:           params.ExternalKey,
+		Title:                 title,
+		Description:           source.Description,
+		Access:                source.Access,
+		ReviewStatuses:        append(pq.StringArray{}, source.ReviewStatuses...),
+		AllowResponseEditing:  source.AllowResponseEditing,
+		ResponseEditDeadline:  source.ResponseEditDeadline,
+		ResponseNotifications: source.ResponseNotifications,
+		Tags:                  append(pq.StringArray{}, source.Tags...),
+		Questions:             make([]gomodel.Question, len(source.Questions)),
+	}
+	if form.Access == "" {
+		form.Access = gomodel.FormAccessPrivate
+	}
+	if form.ResponseNotifications == "" {
+		form.ResponseNotifications = gomodel.ResponseNotificationsNone
+	}
+
+	for i, q := range source.Questions {
+		question := gomodel.Question{
+			ID:              uuid.New().String(),
+			FormID:          form.ID,
+			Key:             q.Key,
+			Text:            q.Text,
+			Type:            q.Type,
+			Required:        q.Required,
+			Order:           q.Order,
+			RespondentEmail: q.RespondentEmail,
+			Options:         make([]*gomodel.Option, len(q.Options)),
+		}
+		for j, o := range q.Options {
+			question.Options[j] = &gomodel.Option{
+				ID:         uuid.New().String(),
+				QuestionID: question.ID,
+				Text:       o.Text,
+				Order:      o.Order,
+			}
+		}
+		form.Questions[i] = question
+	}
+
+	return form
+}
+
+// SetTemplate marks the form as a template or takes the mark off.
+func (s *Service) SetTemplate(ctx context.Context, form *gomodel.Form, isTemplate bool) error {
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(form).Update("isTemplate", isTemplate).Error; err != nil {
+			return err
+		}
+		version, err := BumpVersion(tx, form.ID, nil)
+		form.Version = version
+		return err
+	})
+}
+
+// Templates returns the forms marked as templates that the user can see: their own,
+// those of their workspaces and those shared with them.
+func (s *Service) Templates(ctx context.Context, userID string) ([]gomodel.Form, error) {
+	workspaces := s.database.Model(&gomodel.WorkspaceMember{}).Select("workspace_id").Where("user_id = ?", userID)
+	shared := s.database.Model(&gomodel.FormMember{}).Select("form_id").Where("user_id = ?", userID)
+
+	var forms []gomodel.Form
+	err := s.database.WithContext(ctx).
+		Preload("Questions.Options").
+		Where(`"isTemplate" = ?`, true).
+		Where(`owner_id = ? OR "workspaceId" IN (?) OR id IN (?)`, userID, workspaces, shared).
+		Order("title").
+		Find(&forms).Error
+	return forms, err
+}
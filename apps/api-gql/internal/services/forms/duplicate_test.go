@@ -0,0 +1,134 @@
+package forms
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/dbtest"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func sourceForm() *gomodel.Form {
+	key, external := "color", "survey"
+	deadline, closed := time.Now().Add(time.Hour), time.Now()
+	workspace := "w1"
+	return &gomodel.Form{
+		ID:                    "f1",
+		OwnerID:               "u1",
+		WorkspaceID:           &workspace,
+		ExternalKey:           &external,
+		Title:                 "Survey",
+		Description:           "About colors",
+		Access:                gomodel.FormAccessPublic,
+		ReviewStatuses:        []string{"NEW", "DONE"},
+		AllowResponseEditing:  true,
+		ResponseEditDeadline:  &deadline,
+		ResponseNotifications: gomodel.ResponseNotificationsEach,
+		Tags:                  []string{"team"},
+		IsTemplate:            true,
+		ClosedAt:              &closed,
+		Version:               7,
+		Questions: []gomodel.Question{
+			{ID: "q1", FormID: "f1", Key: &key, Text: "Color", Type: gomodel.QuestionTypeSingleChoice, Required: true, Order: 1, Options: []*gomodel.Option{
+				{ID: "o1", QuestionID: "q1", Text: "Red", Order: 1},
+				{ID: "o2", QuestionID: "q1", Text: "Blue", Order: 2},
+			}},
+			{ID: "q2", FormID: "f1", Text: "Email", Type: gomodel.QuestionTypeEmail, Order: 2, RespondentEmail: true},
+		},
+	}
+}
+
+func TestCopyForm(t *testing.T) {
+	source := sourceForm()
+	clone := copyForm(source, CopyParams{OwnerID: "u2"})
+
+	if clone.ID == "" || clone.ID == source.ID || clone.OwnerID != "u2" || clone.WorkspaceID != nil || clone.ExternalKey != nil {
+		t.Errorf("clone = %+v, want a fresh personal form of u2 without the key", clone)
+	}
+	if clone.Title != "Survey" || clone.Description != source.Description || clone.Access != source.Access ||
+		!clone.AllowResponseEditing || clone.ResponseEditDeadline != source.ResponseEditDeadline ||
+		clone.ResponseNotifications != source.ResponseNotifications {
+		t.Errorf("clone = %+v, want the settings of the source", clone)
+	}
+	if clone.IsTemplate || clone.ClosedAt != nil || clone.Version != 0 {
+		t.Errorf("clone = %+v, want an open form that is no template", clone)
+	}
+
+	// The lists are copied, not shared with the source
+	clone.Tags[0], clone.ReviewStatuses[0] = "changed", "changed"
+	if source.Tags[0] != "team" || source.ReviewStatuses[0] != "NEW" {
+		t.Error("clone shares its lists with the source")
+	}
+
+	if len(clone.Questions) != 2 {
+		t.Fatalf("questions = %d, want 2", len(clone.Questions))
+	}
+	ids := map[string]bool{clone.ID: true}
+	for i, q := range clone.Questions {
+		want := source.Questions[i]
+		if ids[q.ID] || q.ID == want.ID || q.FormID != clone.ID {
+			t.Errorf("question %d = %s in %s, want a fresh ID in the clone", i, q.ID, q.FormID)
+		}
+		ids[q.ID] = true
+		if q.Key != want.Key || q.Text != want.Text || q.Type != want.Type || q.Required != want.Required ||
+			q.Order != want.Order || q.RespondentEmail != want.RespondentEmail || len(q.Options) != len(want.Options) {
+			t.Errorf("question %d = %+v, want %+v", i, q, want)
+			continue
+		}
+		for j, o := range q.Options {
+			if ids[o.ID] || o.ID == want.Options[j].ID || o.QuestionID != q.ID || o.Text != want.Options[j].Text || o.Order != want.Options[j].Order {
+				t.Errorf("option %d of question %d = %+v, want a fresh clone of %+v", j, i, o, want.Options[j])
+			}
+			ids[o.ID] = true
+		}
+	}
+}
+
+func TestCopyFormParams(t *testing.T) {
+	source := sourceForm()
+	source.Access, source.ResponseNotifications = "", ""
+	workspace, folder, key := "w2", "d1", "survey-clone"
+
+	clone := copyForm(source, CopyParams{OwnerID: "u2", WorkspaceID: &workspace, FolderID: &folder, Title: "Copy", ExternalKey: &key})
+	if clone.Title != "Copy" || clone.WorkspaceID != &workspace || clone.FolderID != &folder || clone.ExternalKey != &key {
+		t.Errorf("clone = %+v, want it placed as asked", clone)
+	}
+	if clone.Access != gomodel.FormAccessPrivate || clone.ResponseNotifications != gomodel.ResponseNotificationsNone {
+		t.Errorf("clone = %+v, want the default access and notifications", clone)
+	}
+}
+
+func TestDuplicate(t *testing.T) {
+	db, recorder := dbtest.Serve(t)
+	s := &Service{database: db}
+
+	clone, err := s.Duplicate(context.Background(), sourceForm(), CopyParams{OwnerID: "u2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The form, its questions and their options go in together, each under its parent
+	for table, parent := range map[string]string{"forms": clone.ID, "questions": clone.ID, "options": clone.Questions[0].ID} {
+		if len(recorder.Find(`INSERT INTO "`+table+`"`, "'"+parent+"'")) != 1 {
+			t.Errorf("statements = %q, want the clone inserted into %s", recorder.Statements(), table)
+		}
+	}
+	if len(recorder.Find("answers")) != 0 || len(recorder.Find("webhooks")) != 0 {
+		t.Errorf("statements = %q, want neither responses nor webhooks copied", recorder.Statements())
+	}
+}
+
+func TestDuplicateKeyTaken(t *testing.T) {
+	db, _ := dbtest.Serve(t, dbtest.Reply{
+		Match: `INSERT INTO "forms"`,
+		Err:   &pgconn.PgError{Code: "23505", ConstraintName: "idx_form_owner_key"},
+	})
+	s := &Service{database: db}
+	key := "survey"
+
+	if _, err := s.Duplicate(context.Background(), sourceForm(), CopyParams{OwnerID: "u1", ExternalKey: &key}); !errors.Is(err, ErrKeyTaken) {
+		t.Errorf("Duplicate() error = %v, want ErrKeyTaken", err)
+	}
+}
@@ -0,0 +1,311 @@
+package forms
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/labels"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/responses"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/webhooks"
+	"github.com/TrySquadDF/formify/lib/config"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// purgeInterval is how often the trash is checked for expired forms
+const purgeInterval = time.Hour
+
+var (
+	ErrNotInTrash    = errors.New("form is not in the trash")
+	ErrAlreadyClosed = errors.New("form is already closed")
+	ErrNotClosed     = errors.New("form is not closed")
+)
+
+type Opts struct {
+	fx.In
+	LC fx.Lifecycle
+
+	Database *gorm.DB
+	Config   config.Config
+}
+
+type Service struct {
+	database *gorm.DB
+	config   config.Config
+}
+
+func New(opts Opts) *Service {
+	s := &Service{
+		database: opts.Database,
+		config:   opts.Config,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	opts.LC.Append(
+		fx.Hook{
+			OnStart: func(_ context.Context) error {
+				go s.runPurge(ctx)
+				return nil
+			},
+			OnStop: func(_ context.Context) error {
+				cancel()
+				return nil
+			},
+		},
+	)
+
+	return s
+}
+
+// Close stops the form from accepting responses and notifies its form.closed webhooks.
+func (s *Service) Close(ctx context.Context, form *gomodel.Form) error {
+	now := time.Now()
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&gomodel.Form{}).
+			Where(`id = ? AND "closedAt" IS NULL`, form.ID).
+			Update("closedAt", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrAlreadyClosed
+		}
+
+		version, err := BumpVersion(tx, form.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		form.ClosedAt = &now
+		form.Version = version
+		return webhooks.Enqueue(tx, form.ID, gomodel.WebhookEventFormClosed, webhooks.FormData{
+			Title:    form.Title,
+			ClosedAt: now,
+		})
+	})
+}
+
+// Reopen lets a closed form accept responses again.
+func (s *Service) Reopen(ctx context.Context, form *gomodel.Form) error {
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&gomodel.Form{}).
+			Where(`id = ? AND "closedAt" IS NOT NULL`, form.ID).
+			Update("closedAt", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotClosed
+		}
+
+		version, err := BumpVersion(tx, form.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		form.ClosedAt = nil
+		form.Version = version
+		return nil
+	})
+}
+
+// Trash moves a form to the trash. Its questions and responses are kept until the form is purged.
+func (s *Service) Trash(ctx context.Context, id string) error {
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Bumped first: the form is out of reach of BumpVersion once trashed
+		if _, err := BumpVersion(tx, id, nil); err != nil {
+			return err
+		}
+		return tx.Delete(&gomodel.Form{}, "id = ?", id).Error
+	})
+}
+
+// FindTrashed returns a form from the trash.
+func (s *Service) FindTrashed(ctx context.Context, id string) (*gomodel.Form, error) {
+	var form gomodel.Form
+	if err := s.database.WithContext(ctx).Unscoped().
+		Where(`id = ? AND "deletedAt" IS NOT NULL`, id).
+		First(&form).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotInTrash
+		}
+		return nil, err
+	}
+	return &form, nil
+}
+
+// Trashed returns the forms of the owner that are in the trash, most recently deleted first.
+func (s *Service) Trashed(ctx context.Context, ownerID string) ([]gomodel.Form, error) {
+	var forms []gomodel.Form
+	err := s.database.WithContext(ctx).Unscoped().
+		Preload("Questions.Options").
+		Where(`owner_id = ? AND "deletedAt" IS NOT NULL`, ownerID).
+		Order(`"deletedAt" DESC`).
+		Find(&forms).Error
+	return forms, err
+}
+
+// TrashedInWorkspace returns the forms of the workspace that are in the trash, most recently deleted first.
+func (s *Service) TrashedInWorkspace(ctx context.Context, workspaceID string) ([]gomodel.Form, error) {
+	var forms []gomodel.Form
+	err := s.database.WithContext(ctx).Unscoped().
+		Preload("Questions.Options").
+		Where(`"workspaceId" = ? AND "deletedAt" IS NOT NULL`, workspaceID).
+		Order(`"deletedAt" DESC`).
+		Find(&forms).Error
+	return forms, err
+}
+
+// Star pins the form for the user. Starring twice is harmless.
+func (s *Service) Star(ctx context.Context, userID, formID string) error {
+	return s.database.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&gomodel.FormStar{UserID: userID, FormID: formID}).Error
+}
+
+// Unstar takes the pin off the form.
+func (s *Service) Unstar(ctx context.Context, userID, formID string) error {
+	return s.database.WithContext(ctx).
+		Where("user_id = ? AND form_id = ?", userID, formID).
+		Delete(&gomodel.FormStar{}).Error
+}
+
+// StarredIDs returns the IDs of the forms the user pinned, so that a list of forms
+// learns which are starred from a single query.
+func (s *Service) StarredIDs(ctx context.Context, userID string) (map[string]bool, error) {
+	var ids []string
+	if err := s.database.WithContext(ctx).Model(&gomodel.FormStar{}).
+		Where("user_id = ?", userID).
+		Pluck("form_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	starred := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		starred[id] = true
+	}
+	return starred, nil
+}
+
+// SetTags replaces the tags of the form. Tags are trimmed and deduplicated.
+func (s *Service) SetTags(ctx context.Context, form *gomodel.Form, tags []string) error {
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(form).Update("tags", labels.Normalize(tags)).Error; err != nil {
+			return err
+		}
+		version, err := BumpVersion(tx, form.ID, nil)
+		form.Version = version
+		return err
+	})
+}
+
+// Restore takes a form back out of the trash.
+func (s *Service) Restore(ctx context.Context, id string) error {
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().
+			Model(&gomodel.Form{}).
+			Where(`id = ? AND "deletedAt" IS NOT NULL`, id).
+			Update("deletedAt", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotInTrash
+		}
+		_, err := BumpVersion(tx, id, nil)
+		return err
+	})
+}
+
+// Purge permanently removes a form with its questions, options and responses.
+func (s *Service) Purge(ctx context.Context, id string) error {
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return PurgeForms(tx, []string{id})
+	})
+}
+
+// PurgeExpired permanently removes forms that stayed in the trash longer than the purge delay.
+func (s *Service) PurgeExpired(ctx context.Context) (int, error) {
+	var ids []string
+	if err := s.database.WithContext(ctx).Unscoped().
+		Model(&gomodel.Form{}).
+		Where(`"deletedAt" IS NOT NULL AND "deletedAt" < ?`, time.Now().Add(-s.config.FormPurgeDelay)).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	err := s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return PurgeForms(tx, ids)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+// PurgeForms permanently removes forms and everything that belongs to them.
+func PurgeForms(tx *gorm.DB, ids []string) error {
+	for _, id := range ids {
+		if err := responses.PurgeFormResponses(tx, id); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Exec("DELETE FROM webhook_deliveries WHERE webhook_id IN (SELECT id FROM webhooks WHERE form_id IN ?)", ids).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("form_id IN ?", ids).Delete(&gomodel.Webhook{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("form_id IN ?", ids).Delete(&gomodel.FormStar{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("form_id IN ?", ids).Delete(&gomodel.FormInvitation{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("form_id IN ?", ids).Delete(&gomodel.FormMember{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec("DELETE FROM options WHERE question_id IN (SELECT id FROM questions WHERE form_id IN ?)", ids).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("form_id IN ?", ids).Delete(&gomodel.Question{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Unscoped().Where("id IN ?", ids).Delete(&gomodel.Form{}).Error
+}
+
+func (s *Service) runPurge(ctx context.Context) {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := s.PurgeExpired(ctx)
+		if err != nil {
+			log.Printf("Error purging trashed forms: %v", err)
+		} else if n > 0 {
+			log.Printf("Purged %d trashed forms", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package forms
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/dbtest"
+	"github.com/TrySquadDF/formify/lib/config"
+)
+
+func TestTrashBumpsVersionFirst(t *testing.T) {
+	db, recorder := dbtest.Open(t)
+	s := &Service{database: db}
+
+	if err := s.Trash(context.Background(), "f1"); err != nil {
+		t.Fatal(err)
+	}
+
+	statements := recorder.Statements()
+	// The version is bumped while the form is still live, then the form is soft deleted
+	if len(statements) != 3 ||
+		!strings.Contains(statements[0], `"version"=version + 1 WHERE id = 'f1' AND "forms"."deletedAt" IS NULL`) ||
+		!strings.HasPrefix(statements[2], `UPDATE "forms" SET "deletedAt"=`) ||
+		!strings.HasSuffix(statements[2], `WHERE id = 'f1' AND "forms"."deletedAt" IS NULL`) {
+		t.Errorf("statements = %q, want the version bumped and the form soft deleted", statements)
+	}
+}
+
+func TestRestoreTakesOnlyTrashedForms(t *testing.T) {
+	db, recorder := dbtest.Open(t)
+	s := &Service{database: db}
+
+	// Nothing is in the trash of an empty database
+	if err := s.Restore(context.Background(), "f1"); !errors.Is(err, ErrNotInTrash) {
+		t.Fatalf("Restore() error = %v, want ErrNotInTrash", err)
+	}
+
+	statements := recorder.Statements()
+	if len(statements) != 1 || !strings.Contains(statements[0], `SET "deletedAt"=NULL`) ||
+		!strings.HasSuffix(statements[0], `WHERE id = 'f1' AND "deletedAt" IS NOT NULL`) {
+		t.Errorf("statements = %q, want the trashed form restored", statements)
+	}
+}
+
+func TestPurgeFormsCascades(t *testing.T) {
+	db, recorder := dbtest.Open(t)
+
+	if err := PurgeForms(db, []string{"f1", "f2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Responses go form by form, then everything else hangs off the forms and goes
+	// before them, options before their questions
+	wantPrefixes := []string{
+		"DELETE FROM answer_options", "DELETE FROM answers", "DELETE FROM response_revisions",
+		"DELETE FROM response_notes", "DELETE FROM response_activities", "DELETE FROM form_responses WHERE form_id = 'f1'",
+		"DELETE FROM answer_options", "DELETE FROM answers", "DELETE FROM response_revisions",
+		"DELETE FROM response_notes", "DELETE FROM response_activities", "DELETE FROM form_responses WHERE form_id = 'f2'",
+		"DELETE FROM webhook_deliveries WHERE webhook_id IN (SELECT id FROM webhooks WHERE form_id IN ('f1','f2'))",
+		`DELETE FROM "webhooks" WHERE form_id IN ('f1','f2')`,
+		`DELETE FROM "form_stars" WHERE form_id IN ('f1','f2')`,
+		`DELETE FROM "form_invitations" WHERE form_id IN ('f1','f2')`,
+		`DELETE FROM "form_members" WHERE form_id IN ('f1','f2')`,
+		"DELETE FROM options WHERE question_id IN (SELECT id FROM questions WHERE form_id IN ('f1','f2'))",
+		`DELETE FROM "questions" WHERE form_id IN ('f1','f2')`,
+		`DELETE FROM "forms" WHERE id IN ('f1','f2')`,
+	}
+	statements := recorder.Statements()
+	if len(statements) != len(wantPrefixes) {
+		t.Fatalf("statements = %q, want %d", statements, len(wantPrefixes))
+	}
+	for i, prefix := range wantPrefixes {
+		if !strings.HasPrefix(statements[i], prefix) {
+			t.Errorf("statement %d = %q, want %q", i, statements[i], prefix)
+		}
+	}
+	if last := statements[len(statements)-1]; strings.Contains(last, "deletedAt") {
+		t.Errorf("forms purged with %q, want a hard delete", last)
+	}
+}
+
+func TestPurgeExpiredLooksForOldTrash(t *testing.T) {
+	db, recorder := dbtest.Open(t)
+	s := &Service{database: db, config: config.Config{FormPurgeDelay: 720 * time.Hour}}
+
+	before := time.Now().Add(-720 * time.Hour)
+	n, err := s.PurgeExpired(context.Background())
+	if err != nil || n != 0 {
+		t.Fatalf("PurgeExpired() = %d, %v, want nothing purged", n, err)
+	}
+
+	statements := recorder.Statements()
+	if len(statements) != 1 {
+		t.Fatalf("statements = %q, want only the lookup", statements)
+	}
+	_, cutoff, ok := strings.Cut(statements[0], `"deletedAt" IS NOT NULL AND "deletedAt" < '`)
+	if !ok {
+		t.Fatalf("statement = %q, want only trashed forms", statements[0])
+	}
+	at, err := time.ParseInLocation("2006-01-02 15:04:05.999", cutoff[:strings.Index(cutoff, "'")], time.Local)
+	if err != nil || at.Sub(before).Abs() > time.Minute {
+		t.Errorf("lookup takes forms trashed before %q, want %v", cutoff, before)
+	}
+}
@@ -0,0 +1,49 @@
+package forms
+
+import (
+	"context"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/lib/pq"
+)
+
+// ListParams selects the forms List returns: the personal forms of the owner, or the
+// forms of the workspace when WorkspaceID is set. A zero Limit returns every form.
+type ListParams struct {
+	OwnerID     string
+	WorkspaceID *string
+	Tag         *string
+	Limit       int
+	Offset      int
+}
+
+// List returns forms with their questions, most recently updated first, and the number
+// of forms matching the filter regardless of Limit and Offset. The caller checks that
+// the owner is a member of the workspace.
+func (s *Service) List(ctx context.Context, params ListParams) ([]gomodel.Form, int64, error) {
+	query := s.database.WithContext(ctx).Model(&gomodel.Form{})
+	if params.WorkspaceID != nil {
+		query = query.Where(`"workspaceId" = ?`, *params.WorkspaceID)
+	} else {
+		query = query.Where(`owner_id = ? AND "workspaceId" IS NULL`, params.OwnerID)
+	}
+	if params.Tag != nil {
+		query = query.Where("tags @> ?", pq.StringArray{*params.Tag})
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Preload("Questions.Options").Order(`"updatedAt" DESC, id`)
+	if params.Limit > 0 {
+		query = query.Limit(params.Limit).Offset(params.Offset)
+	}
+
+	var list []gomodel.Form
+	if err := query.Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
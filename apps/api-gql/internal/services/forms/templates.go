@@ -0,0 +1,103 @@
+package forms
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"sort"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// Built-in templates are JSON files shipped inside the binary, one template per file.
+//
+//go:embed templates/*.json
+var templateFiles embed.FS
+
+// BuiltinTemplate is a form template shipped with the server.
+type BuiltinTemplate struct {
+	Key         string            `json:"key"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Questions   []BuiltinQuestion `json:"questions"`
+}
+
+type BuiltinQuestion struct {
+	Text            string               `json:"text"`
+	Type            gomodel.QuestionType `json:"type"`
+	Required        bool                 `json:"required"`
+	RespondentEmail bool                 `json:"respondentEmail"`
+	Options         []string             `json:"options"`
+}
+
+var builtinTemplates = mustLoadTemplates()
+
+func mustLoadTemplates() []BuiltinTemplate {
+	paths, err := fs.Glob(templateFiles, "templates/*.json")
+	if err != nil {
+		panic(err)
+	}
+
+	templates := make([]BuiltinTemplate, 0, len(paths))
+	for _, path := range paths {
+		data, err := templateFiles.ReadFile(path)
+		if err != nil {
+			panic(err)
+		}
+		var t BuiltinTemplate
+		if err := json.Unmarshal(data, &t); err != nil {
+			panic(fmt.Sprintf("form template %s: %v", path, err))
+		}
+		templates = append(templates, t)
+	}
+
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Title < templates[j].Title })
+	return templates
+}
+
+// BuiltinTemplates returns the templates shipped with the server, by title.
+func BuiltinTemplates() []BuiltinTemplate {
+	return builtinTemplates
+}
+
+// FindBuiltinTemplate looks a shipped template up by its key.
+func FindBuiltinTemplate(key string) (*BuiltinTemplate, bool) {
+	for i := range builtinTemplates {
+		if builtinTemplates[i].Key == key {
+			return &builtinTemplates[i], true
+		}
+	}
+	return nil, false
+}
+
+// Form turns the template into an unsaved form to copy from.
+func (t *BuiltinTemplate) Form() *gomodel.Form {
+	form := &gomodel.Form{
+		Title:       t.Title,
+		Description: t.Description,
+		Access:      gomodel.FormAccessPrivate,
+		Questions:   make([]gomodel.Question, len(t.Questions)),
+	}
+	for i, q := range t.Questions {
+		question := gomodel.Question{
+			Text:            q.Text,
+			Type:            q.Type,
+			Required:        q.Required,
+			Order:           int32(i + 1),
+			RespondentEmail: q.RespondentEmail,
+			Options:         make([]*gomodel.Option, len(q.Options)),
+		}
+		for j, text := range q.Options {
+			question.Options[j] = &gomodel.Option{Text: text, Order: int32(j + 1)}
+		}
+		form.Questions[i] = question
+	}
+	return form
+}
+
+// CreateFromTemplate creates a form from a built-in template.
+func (s *Service) CreateFromTemplate(ctx context.Context, template *BuiltinTemplate, params CopyParams) (*gomodel.Form, error) {
+	return s.Duplicate(ctx, template.Form(), params)
+}
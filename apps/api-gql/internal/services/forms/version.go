@@ -0,0 +1,55 @@
+package forms
+
+import (
+	"fmt"
+	"time"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"gorm.io/gorm"
+)
+
+// VersionConflictError is returned when a form changed since the version the client edited.
+type VersionConflictError struct {
+	Expected int64
+	Current  int64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("form was changed by someone else: expected version %d, current version %d", e.Expected, e.Current)
+}
+
+// Extensions lets GraphQL clients tell a conflict from other errors and refetch.
+func (e *VersionConflictError) Extensions() map[string]interface{} {
+	return map[string]interface{}{
+		"code":           "VERSION_CONFLICT",
+		"currentVersion": e.Current,
+	}
+}
+
+// BumpVersion increments the version of a form inside the transaction of a change and
+// returns the new version. With expected set, the change is rejected with a
+// VersionConflictError unless the form is still at that version.
+func BumpVersion(tx *gorm.DB, formID string, expected *int64) (int64, error) {
+	query := tx.Model(&gomodel.Form{}).Where("id = ?", formID)
+	if expected != nil {
+		query = query.Where("version = ?", *expected)
+	}
+
+	result := query.UpdateColumns(map[string]interface{}{
+		"version":   gorm.Expr("version + 1"),
+		"updatedAt": time.Now(),
+	})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	var form gomodel.Form
+	if err := tx.Select("version").First(&form, "id = ?", formID).Error; err != nil {
+		return 0, err
+	}
+
+	if result.RowsAffected == 0 && expected != nil {
+		return 0, &VersionConflictError{Expected: *expected, Current: form.Version}
+	}
+	return form.Version, nil
+}
@@ -0,0 +1,61 @@
+package forms
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/dbtest"
+)
+
+func TestBumpVersion(t *testing.T) {
+	expected := int64(4)
+	tests := []struct {
+		name     string
+		expected *int64
+		affected int64
+		current  int64
+		want     int64
+		conflict bool
+	}{
+		{name: "unchecked", affected: 1, current: 8, want: 8},
+		{name: "at the expected version", expected: &expected, affected: 1, current: 5, want: 5},
+		{name: "changed meanwhile", expected: &expected, affected: 0, current: 6, conflict: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, recorder := dbtest.Serve(t,
+				dbtest.Reply{Match: `UPDATE "forms"`, Affected: tt.affected},
+				dbtest.Reply{Match: `SELECT "version" FROM "forms"`, Columns: []string{"version"}, Rows: [][]driver.Value{{tt.current}}},
+			)
+
+			version, err := BumpVersion(db, "f1", tt.expected)
+			var conflict *VersionConflictError
+			if tt.conflict {
+				if !errors.As(err, &conflict) || conflict.Expected != expected || conflict.Current != tt.current {
+					t.Fatalf("BumpVersion() error = %v, want a conflict at version %d", err, tt.current)
+				}
+				return
+			}
+			if err != nil || version != tt.want {
+				t.Fatalf("BumpVersion() = %d, %v, want %d", version, err, tt.want)
+			}
+
+			want := `SET "updatedAt"=`
+			if tt.expected != nil {
+				want = "WHERE id = 'f1' AND version = 4"
+			}
+			if len(recorder.Find(`UPDATE "forms"`, `"version"=version + 1`, want)) != 1 {
+				t.Errorf("statements = %q, want the version bumped", recorder.Statements())
+			}
+		})
+	}
+}
+
+func TestVersionConflictExtensions(t *testing.T) {
+	err := &VersionConflictError{Expected: 4, Current: 6}
+	extensions := err.Extensions()
+	if extensions["code"] != "VERSION_CONFLICT" || extensions["currentVersion"] != int64(6) {
+		t.Errorf("Extensions() = %v", extensions)
+	}
+}
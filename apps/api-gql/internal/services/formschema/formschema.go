@@ -0,0 +1,317 @@
+// Package formschema describes the answers a form accepts as a JSON Schema and checks
+// plain JSON submissions against it, so services can submit without GraphQL.
+//
+// A submission is an object with one property per answered question, named by the key
+// of the question or by its ID:
+//
+//	{"email": "ann@example.com", "rating": "<option id>", "topics": ["<option id>"]}
+package formschema
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/responses"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// Draft is the JSON Schema dialect of generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// maxKeyLength matches the width of the key column.
+const maxKeyLength = 64
+
+// keyPattern keeps question keys usable as identifiers; they can never look like an ID.
+var keyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidQuestionKey reports whether the key may be given to a question.
+func ValidQuestionKey(key string) bool {
+	return len(key) <= maxKeyLength && keyPattern.MatchString(key)
+}
+
+// Schema is the subset of JSON Schema used to describe forms.
+type Schema struct {
+	Schema      string   `json:"$schema,omitempty"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Format      string   `json:"format,omitempty"`
+	Pattern     string   `json:"pattern,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	// EnumNames holds the texts of the options listed in Enum, in the same order
+	EnumNames            []string           `json:"x-enumNames,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	UniqueItems          bool               `json:"uniqueItems,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+}
+
+// Issue is a problem with a submission. Path is a JSON pointer to the offending value.
+type Issue struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists every problem found in a submission.
+type ValidationError struct {
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	message := e.Issues[0].Path + ": " + e.Issues[0].Message
+	if len(e.Issues) > 1 {
+		message += fmt.Sprintf(" (and %d more problems)", len(e.Issues)-1)
+	}
+	return "invalid submission: " + message
+}
+
+// nonBlank is the pattern required text answers must match, as in SubmitFormResponse.
+const nonBlank = `\S`
+
+// Name is the property a question is answered by in a schema: its key, or its ID.
+func Name(q *gomodel.Question) string {
+	if q.Key != nil && *q.Key != "" {
+		return *q.Key
+	}
+	return q.ID
+}
+
+// Generate describes the answers the form accepts. The form must have its
+// Questions.Options loaded.
+func Generate(form *gomodel.Form) *Schema {
+	closed := false
+	schema := &Schema{
+		Schema:               Draft,
+		Title:                form.Title,
+		Description:          form.Description,
+		Type:                 "object",
+		Properties:           make(map[string]*Schema, len(form.Questions)),
+		Required:             make([]string, 0),
+		AdditionalProperties: &closed,
+	}
+
+	for _, q := range ordered(form) {
+		name := Name(q)
+		schema.Properties[name] = questionSchema(q)
+		if q.Required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
+func questionSchema(q *gomodel.Question) *Schema {
+	s := &Schema{Title: q.Text}
+	switch q.Type {
+	case gomodel.QuestionTypeBoolean:
+		s.Type = "boolean"
+	case gomodel.QuestionTypeNumber:
+		s.Type = "number"
+	case gomodel.QuestionTypeDate:
+		s.Type = "string"
+		s.Format = "date-time"
+	case gomodel.QuestionTypeSingleChoice:
+		s.Type = "string"
+		s.Enum, s.EnumNames = options(q)
+	case gomodel.QuestionTypeMultipleChoice:
+		item := &Schema{Type: "string"}
+		item.Enum, item.EnumNames = options(q)
+		s.Type = "array"
+		s.Items = item
+		s.UniqueItems = true
+		if q.Required {
+			one := 1
+			s.MinItems = &one
+		}
+	default:
+		s.Type = "string"
+		if q.Type == gomodel.QuestionTypeEmail {
+			s.Format = "email"
+		}
+		if q.Required {
+			s.Pattern = nonBlank
+		}
+	}
+	return s
+}
+
+// Validate checks a decoded JSON submission against the schema of the form and turns it
+// into answers for responses.Service.Submit. Every problem is reported at once.
+func Validate(form *gomodel.Form, submission map[string]interface{}) ([]responses.AnswerInput, error) {
+	byName := make(map[string]*gomodel.Question, 2*len(form.Questions))
+	for i := range form.Questions {
+		q := &form.Questions[i]
+		byName[q.ID] = q
+		byName[Name(q)] = q
+	}
+
+	var issues []Issue
+	add := func(name, format string, args ...interface{}) {
+		issues = append(issues, Issue{Path: pointer(name), Message: fmt.Sprintf(format, args...)})
+	}
+
+	names := make([]string, 0, len(submission))
+	for name := range submission {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	answered := make(map[string]string, len(submission))
+	inputs := make([]responses.AnswerInput, 0, len(submission))
+	for _, name := range names {
+		q, ok := byName[name]
+		if !ok {
+			add(name, "no such question")
+			continue
+		}
+		if other, ok := answered[q.ID]; ok {
+			add(name, "answers the same question as %s", pointer(other))
+			continue
+		}
+		answered[q.ID] = name
+
+		input, err := convert(q, submission[name])
+		if err != nil {
+			add(name, "%v", err)
+			continue
+		}
+		inputs = append(inputs, input)
+	}
+
+	for _, q := range ordered(form) {
+		if _, ok := answered[q.ID]; q.Required && !ok {
+			add(Name(q), "is required")
+		}
+	}
+
+	if len(issues) > 0 {
+		return nil, &ValidationError{Issues: issues}
+	}
+	return inputs, nil
+}
+
+// convert checks a value against the schema of its question.
+func convert(q *gomodel.Question, value interface{}) (responses.AnswerInput, error) {
+	input := responses.AnswerInput{QuestionID: q.ID}
+
+	switch q.Type {
+	case gomodel.QuestionTypeBoolean:
+		b, ok := value.(bool)
+		if !ok {
+			return input, fmt.Errorf("must be a boolean")
+		}
+		input.BoolValue = &b
+
+	case gomodel.QuestionTypeNumber:
+		n, ok := value.(float64)
+		if !ok {
+			return input, fmt.Errorf("must be a number")
+		}
+		input.NumberValue = &n
+
+	case gomodel.QuestionTypeDate:
+		s, ok := value.(string)
+		if !ok {
+			return input, fmt.Errorf("must be a string")
+		}
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			return input, fmt.Errorf("must be a date-time, such as 2024-05-01T10:00:00Z")
+		}
+		input.DateValue = &s
+
+	case gomodel.QuestionTypeSingleChoice:
+		s, ok := value.(string)
+		if !ok {
+			return input, fmt.Errorf("must be a string")
+		}
+		if !hasOption(q, s) {
+			return input, fmt.Errorf("must be one of the option IDs of the question")
+		}
+		input.OptionIDs = []string{s}
+
+	case gomodel.QuestionTypeMultipleChoice:
+		list, ok := value.([]interface{})
+		if !ok {
+			return input, fmt.Errorf("must be an array")
+		}
+		if q.Required && len(list) == 0 {
+			return input, fmt.Errorf("must select at least 1 option")
+		}
+		seen := make(map[string]bool, len(list))
+		for i, item := range list {
+			s, ok := item.(string)
+			if !ok || !hasOption(q, s) {
+				return input, fmt.Errorf("item %d must be one of the option IDs of the question", i)
+			}
+			if seen[s] {
+				return input, fmt.Errorf("item %d repeats an option", i)
+			}
+			seen[s] = true
+			input.OptionIDs = append(input.OptionIDs, s)
+		}
+
+	default:
+		s, ok := value.(string)
+		if !ok {
+			return input, fmt.Errorf("must be a string")
+		}
+		if q.Required && strings.TrimSpace(s) == "" {
+			return input, fmt.Errorf("must not be blank")
+		}
+		if q.Type == gomodel.QuestionTypeEmail && strings.TrimSpace(s) != "" && !validEmail(s) {
+			return input, fmt.Errorf("must be an email address")
+		}
+		input.TextValue = &s
+	}
+	return input, nil
+}
+
+// validEmail accepts a bare address, the way the schema's email format describes it,
+// with no display name or angle brackets.
+func validEmail(s string) bool {
+	s = strings.TrimSpace(s)
+	address, err := mail.ParseAddress(s)
+	return err == nil && address.Address == s
+}
+
+func options(q *gomodel.Question) ([]string, []string) {
+	sorted := append([]*gomodel.Option(nil), q.Options...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Order < sorted[j].Order })
+
+	ids := make([]string, len(sorted))
+	texts := make([]string, len(sorted))
+	for i, o := range sorted {
+		ids[i], texts[i] = o.ID, o.Text
+	}
+	return ids, texts
+}
+
+func hasOption(q *gomodel.Question, id string) bool {
+	for _, o := range q.Options {
+		if o.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func ordered(form *gomodel.Form) []*gomodel.Question {
+	questions := make([]*gomodel.Question, len(form.Questions))
+	for i := range form.Questions {
+		questions[i] = &form.Questions[i]
+	}
+	sort.SliceStable(questions, func(i, j int) bool { return questions[i].Order < questions[j].Order })
+	return questions
+}
+
+// pointer escapes a property name as a JSON pointer.
+func pointer(name string) string {
+	return "/" + strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
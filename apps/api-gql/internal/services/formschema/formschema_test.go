@@ -0,0 +1,208 @@
+package formschema
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+func testForm() *gomodel.Form {
+	key := func(k string) *string { return &k }
+	return &gomodel.Form{Title: "Survey", Questions: []gomodel.Question{
+		{ID: "q-name", Key: key("name"), Text: "Name", Type: gomodel.QuestionTypeShortText, Required: true, Order: 1},
+		{ID: "q-bio", Text: "Bio", Type: gomodel.QuestionTypeParagraph, Order: 2},
+		{ID: "q-agree", Key: key("agree"), Text: "Agree?", Type: gomodel.QuestionTypeBoolean, Order: 3},
+		{ID: "q-age", Key: key("age"), Text: "Age", Type: gomodel.QuestionTypeNumber, Order: 4},
+		{ID: "q-phone", Key: key("phone"), Text: "Phone", Type: gomodel.QuestionTypePhone, Order: 5},
+		{ID: "q-born", Key: key("born"), Text: "Born", Type: gomodel.QuestionTypeDate, Order: 6},
+		{ID: "q-email", Key: key("email"), Text: "Email", Type: gomodel.QuestionTypeEmail, Order: 7},
+		{ID: "q-color", Key: key("color"), Text: "Color", Type: gomodel.QuestionTypeSingleChoice, Order: 8, Options: []*gomodel.Option{
+			{ID: "o-blue", Text: "Blue", Order: 2},
+			{ID: "o-red", Text: "Red", Order: 1},
+		}},
+		{ID: "q-topics", Key: key("topics"), Text: "Topics", Type: gomodel.QuestionTypeMultipleChoice, Required: true, Order: 9, Options: []*gomodel.Option{
+			{ID: "o-go", Text: "Go", Order: 1},
+			{ID: "o-sql", Text: "SQL", Order: 2},
+		}},
+	}}
+}
+
+func TestGenerate(t *testing.T) {
+	schema := Generate(testForm())
+
+	if schema.Schema != Draft || schema.Type != "object" || schema.Title != "Survey" {
+		t.Errorf("schema header = %q %q %q", schema.Schema, schema.Type, schema.Title)
+	}
+	if schema.AdditionalProperties == nil || *schema.AdditionalProperties {
+		t.Error("additional properties are allowed")
+	}
+	if want := []string{"name", "topics"}; !reflect.DeepEqual(schema.Required, want) {
+		t.Errorf("required = %v, want %v", schema.Required, want)
+	}
+
+	tests := []struct {
+		name, typ, format, pattern string
+	}{
+		{"name", "string", "", nonBlank},
+		{"q-bio", "string", "", ""},
+		{"agree", "boolean", "", ""},
+		{"age", "number", "", ""},
+		{"phone", "string", "", ""},
+		{"born", "string", "date-time", ""},
+		{"email", "string", "email", ""},
+		{"color", "string", "", ""},
+		{"topics", "array", "", ""},
+	}
+	for _, tt := range tests {
+		property, ok := schema.Properties[tt.name]
+		if !ok {
+			t.Errorf("no property %s", tt.name)
+			continue
+		}
+		if property.Type != tt.typ || property.Format != tt.format || property.Pattern != tt.pattern {
+			t.Errorf("%s = type %q format %q pattern %q, want %q %q %q",
+				tt.name, property.Type, property.Format, property.Pattern, tt.typ, tt.format, tt.pattern)
+		}
+	}
+
+	color := schema.Properties["color"]
+	if !reflect.DeepEqual(color.Enum, []string{"o-red", "o-blue"}) || !reflect.DeepEqual(color.EnumNames, []string{"Red", "Blue"}) {
+		t.Errorf("color enum = %v %v, want the options in order", color.Enum, color.EnumNames)
+	}
+	topics := schema.Properties["topics"]
+	if topics.Items == nil || !reflect.DeepEqual(topics.Items.Enum, []string{"o-go", "o-sql"}) || !topics.UniqueItems ||
+		topics.MinItems == nil || *topics.MinItems != 1 {
+		t.Errorf("topics = %+v, want unique option IDs with at least one item", topics)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{"name": "Ann", "topics": []interface{}{"o-go"}}
+	}
+	with := func(name string, value interface{}) map[string]interface{} {
+		submission := valid()
+		submission[name] = value
+		return submission
+	}
+
+	tests := []struct {
+		name       string
+		submission map[string]interface{}
+		// wantIssues are the paths and message fragments of the expected issues
+		wantIssues map[string]string
+	}{
+		{name: "required only", submission: valid()},
+		{name: "text", submission: with("q-bio", "About me")},
+		{name: "blank optional text", submission: with("q-bio", "  ")},
+		{name: "text not a string", submission: with("q-bio", 1.0), wantIssues: map[string]string{"/q-bio": "must be a string"}},
+		{name: "blank required text", submission: with("name", " "), wantIssues: map[string]string{"/name": "must not be blank"}},
+		{name: "boolean", submission: with("agree", true)},
+		{name: "boolean as string", submission: with("agree", "yes"), wantIssues: map[string]string{"/agree": "must be a boolean"}},
+		{name: "number", submission: with("age", 42.0)},
+		{name: "number as string", submission: with("age", "42"), wantIssues: map[string]string{"/age": "must be a number"}},
+		{name: "phone", submission: with("phone", "+1 555 0100")},
+		{name: "date", submission: with("born", "2024-05-01T10:00:00Z")},
+		{name: "bad date", submission: with("born", "May 1"), wantIssues: map[string]string{"/born": "must be a date-time"}},
+		{name: "email", submission: with("email", "ann@example.com")},
+		{name: "blank optional email", submission: with("email", "")},
+		{name: "not an email", submission: with("email", "ann at example"), wantIssues: map[string]string{"/email": "must be an email address"}},
+		{name: "email with a name", submission: with("email", "Ann <ann@example.com>"), wantIssues: map[string]string{"/email": "must be an email address"}},
+		{name: "single choice", submission: with("color", "o-red")},
+		{name: "foreign option", submission: with("color", "o-go"), wantIssues: map[string]string{"/color": "option IDs"}},
+		{name: "multiple choice", submission: with("topics", []interface{}{"o-go", "o-sql"})},
+		{name: "empty required choice", submission: with("topics", []interface{}{}), wantIssues: map[string]string{"/topics": "at least 1"}},
+		{name: "repeated option", submission: with("topics", []interface{}{"o-go", "o-go"}), wantIssues: map[string]string{"/topics": "repeats"}},
+		{name: "choice not an array", submission: with("topics", "o-go"), wantIssues: map[string]string{"/topics": "must be an array"}},
+		{name: "answer by ID", submission: map[string]interface{}{"q-name": "Ann", "q-topics": []interface{}{"o-sql"}}},
+		{name: "unknown key", submission: with("nickname", "A"), wantIssues: map[string]string{"/nickname": "no such question"}},
+		{name: "key and ID together", submission: with("q-name", "Ann"), wantIssues: map[string]string{"/q-name": "same question as /name"}},
+		{
+			name:       "required missing",
+			submission: map[string]interface{}{"age": 3.0},
+			wantIssues: map[string]string{"/name": "is required", "/topics": "is required"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputs, err := Validate(testForm(), tt.submission)
+			if len(tt.wantIssues) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				if len(inputs) != len(tt.submission) {
+					t.Errorf("Validate() = %d answers, want %d", len(inputs), len(tt.submission))
+				}
+				return
+			}
+
+			var invalid *ValidationError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("Validate() error = %v, want a ValidationError", err)
+			}
+			if len(invalid.Issues) != len(tt.wantIssues) {
+				t.Errorf("issues = %+v, want %d", invalid.Issues, len(tt.wantIssues))
+			}
+			for _, issue := range invalid.Issues {
+				if want, ok := tt.wantIssues[issue.Path]; !ok || !strings.Contains(issue.Message, want) {
+					t.Errorf("issue %s: %q, want %q", issue.Path, issue.Message, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateConvertsAnswers(t *testing.T) {
+	inputs, err := Validate(testForm(), map[string]interface{}{
+		"name":   "Ann",
+		"agree":  true,
+		"age":    42.0,
+		"topics": []interface{}{"o-sql", "o-go"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byQuestion := make(map[string]int)
+	for i, input := range inputs {
+		byQuestion[input.QuestionID] = i
+	}
+	if in := inputs[byQuestion["q-name"]]; in.TextValue == nil || *in.TextValue != "Ann" {
+		t.Errorf("name = %+v", in)
+	}
+	if in := inputs[byQuestion["q-agree"]]; in.BoolValue == nil || !*in.BoolValue {
+		t.Errorf("agree = %+v", in)
+	}
+	if in := inputs[byQuestion["q-age"]]; in.NumberValue == nil || *in.NumberValue != 42 {
+		t.Errorf("age = %+v", in)
+	}
+	if in := inputs[byQuestion["q-topics"]]; !reflect.DeepEqual(in.OptionIDs, []string{"o-sql", "o-go"}) {
+		t.Errorf("topics = %+v", in)
+	}
+}
+
+func TestValidQuestionKey(t *testing.T) {
+	for key, want := range map[string]bool{
+		"email":                 true,
+		"_private":              true,
+		"first_name2":           true,
+		"2nd":                   false,
+		"first-name":            false,
+		"":                      false,
+		strings.Repeat("k", 64): true,
+		strings.Repeat("k", 65): false,
+	} {
+		if got := ValidQuestionKey(key); got != want {
+			t.Errorf("ValidQuestionKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestPointer(t *testing.T) {
+	if got := pointer("a/b~c"); got != "/a~1b~0c" {
+		t.Errorf("pointer() = %q", got)
+	}
+}
@@ -0,0 +1,251 @@
+// Package identities keeps the external logins of users: an account can be signed into
+// with its password and with any number of providers. A provider account is linked to
+// one user at most; when a new provider account shares its verified email with an
+// existing user, the link waits until the user proves they own that account by signing
+// in to it.
+package identities
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/entity"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/users"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/TrySquadDF/formify/lib/oauth"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PendingLinkSessionKey keeps a PendingLink in the session until it is confirmed
+const PendingLinkSessionKey = "oauth.pendingLink"
+
+// PendingLinkTTL is how long the user has to confirm a link by signing in
+const PendingLinkTTL = 30 * time.Minute
+
+var (
+	ErrIdentityNotFound   = errors.New("login method not found")
+	ErrIdentityInUse      = errors.New("this login is already linked to another account")
+	ErrLastLoginMethod    = errors.New("cannot unlink the only way to sign in")
+	ErrEmailTaken         = errors.New("an account with this email already exists; sign in to it and link this login from the settings")
+	ErrPendingLinkExpired = errors.New("login link has expired")
+	ErrEmailNotVerified   = errors.New("email is not verified by the login provider")
+)
+
+// PendingLink is a provider account waiting to be linked to the user who has its email
+type PendingLink struct {
+	UserID    string
+	Provider  string
+	Subject   string
+	Email     string
+	CreatedAt time.Time
+}
+
+func init() {
+	// Sessions keep values as gob
+	gob.Register(PendingLink{})
+}
+
+type Opts struct {
+	fx.In
+
+	Database    *gorm.DB
+	UserService *users.Service
+}
+
+type Service struct {
+	database    *gorm.DB
+	userService *users.Service
+}
+
+func New(opts Opts) *Service {
+	return &Service{
+		database:    opts.Database,
+		userService: opts.UserService,
+	}
+}
+
+// SignIn returns the user who logged in through the provider, creating one for a new
+// email. When the email belongs to a user the provider account is not linked to yet,
+// it returns a PendingLink to confirm instead. An unverified email fails, with
+// ErrEmailTaken or ErrEmailNotVerified, since anyone could have typed it: an account
+// claims its email, and invitations are accepted by email.
+func (s *Service) SignIn(ctx context.Context, provider string, info *oauth.UserInfo) (*gomodel.Users, *PendingLink, error) {
+	identity, err := s.find(ctx, provider, info.Subject)
+	if err != nil {
+		return nil, nil, err
+	}
+	if identity != nil {
+		user, err := s.findUser(ctx, identity.UserID)
+		return user, nil, err
+	}
+
+	existing, err := s.userService.FindUserByEmail(ctx, info.Email)
+	if err != nil {
+		return nil, nil, err
+	}
+	if existing != nil {
+		if !info.EmailVerified {
+			return nil, nil, ErrEmailTaken
+		}
+		return nil, &PendingLink{
+			UserID:    existing.ID,
+			Provider:  provider,
+			Subject:   info.Subject,
+			Email:     info.Email,
+			CreatedAt: time.Now(),
+		}, nil
+	}
+
+	if !info.EmailVerified {
+		return nil, nil, ErrEmailNotVerified
+	}
+
+	newUser := entity.Users{
+		Email:       info.Email,
+		DisplayName: info.Name,
+		Picture:     info.Picture,
+	}
+	if provider == oauth.Google {
+		newUser.GoogleID = info.Subject
+	}
+	user, err := s.userService.CreateUser(ctx, newUser)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := s.link(ctx, user.ID, provider, info.Subject, info.Email); err != nil {
+		return nil, nil, err
+	}
+	return user, nil, nil
+}
+
+// Confirm links a pending provider account once its user has signed in
+func (s *Service) Confirm(ctx context.Context, userID string, pending PendingLink) (*gomodel.Identity, error) {
+	if pending.UserID != userID {
+		return nil, ErrIdentityNotFound
+	}
+	if time.Since(pending.CreatedAt) > PendingLinkTTL {
+		return nil, ErrPendingLinkExpired
+	}
+	return s.link(ctx, userID, pending.Provider, pending.Subject, pending.Email)
+}
+
+// Link adds a provider account to the user as a way to sign in
+func (s *Service) Link(ctx context.Context, userID, provider string, info *oauth.UserInfo) (*gomodel.Identity, error) {
+	return s.link(ctx, userID, provider, info.Subject, info.Email)
+}
+
+// List returns the logins of the user, oldest first
+func (s *Service) List(ctx context.Context, userID string) ([]gomodel.Identity, error) {
+	var identities []gomodel.Identity
+	err := s.database.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&identities).Error
+	return identities, err
+}
+
+// Unlink removes a login of the user, unless the user could no longer sign in
+func (s *Service) Unlink(ctx context.Context, userID, id string) error {
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user gomodel.Users
+		// The lock keeps two unlinks from removing the last two logins together
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", userID).First(&user).Error; err != nil {
+			return err
+		}
+
+		var identity gomodel.Identity
+		err := tx.Where("id = ? AND user_id = ?", id, userID).First(&identity).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrIdentityNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&gomodel.Identity{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 1 && user.PasswordHash == "" {
+			return ErrLastLoginMethod
+		}
+
+		if err := tx.Delete(&identity).Error; err != nil {
+			return err
+		}
+		if identity.Provider == oauth.Google && user.GoogleID == identity.Subject {
+			return tx.Model(&user).Update("googleId", "").Error
+		}
+		return nil
+	})
+}
+
+func (s *Service) link(ctx context.Context, userID, provider, subject, email string) (*gomodel.Identity, error) {
+	existing, err := s.find(ctx, provider, subject)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if existing.UserID != userID {
+			return nil, ErrIdentityInUse
+		}
+		return existing, nil
+	}
+
+	identity := gomodel.Identity{
+		UserID:   userID,
+		Provider: provider,
+		Subject:  subject,
+		Email:    email,
+	}
+	err = s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&identity).Error; err != nil {
+			return err
+		}
+		// Google logins are still shown on the user
+		if provider == oauth.Google {
+			return tx.Model(&gomodel.Users{}).
+				Where(`id = ? AND ("googleId" = '' OR "googleId" IS NULL)`, userID).
+				Update("googleId", subject).Error
+		}
+		return nil
+	})
+	// Another request linked the same provider account in the meantime
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return nil, ErrIdentityInUse
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (s *Service) find(ctx context.Context, provider, subject string) (*gomodel.Identity, error) {
+	var identity gomodel.Identity
+	err := s.database.WithContext(ctx).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&identity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (s *Service) findUser(ctx context.Context, id string) (*gomodel.Users, error) {
+	var user gomodel.Users
+	if err := s.database.WithContext(ctx).Where("id = ?", id).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,71 @@
+package identities
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"testing"
+	"time"
+)
+
+// Confirm refuses a link before it reaches the database, so the service has none
+func TestConfirmRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		pending PendingLink
+		err     error
+	}{
+		{
+			// Someone else signed in before the link was confirmed
+			name:    "other user",
+			userID:  "mallory",
+			pending: PendingLink{UserID: "ann", Provider: "github", Subject: "42", CreatedAt: time.Now()},
+			err:     ErrIdentityNotFound,
+		},
+		{
+			name:    "expired",
+			userID:  "ann",
+			pending: PendingLink{UserID: "ann", Provider: "github", Subject: "42", CreatedAt: time.Now().Add(-PendingLinkTTL - time.Second)},
+			err:     ErrPendingLinkExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := (&Service{}).Confirm(context.Background(), tt.userID, tt.pending); !errors.Is(err, tt.err) {
+				t.Errorf("Confirm() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+// A pending link waits in the session, which keeps values as gob behind an interface
+func TestPendingLinkSurvivesSession(t *testing.T) {
+	in := map[string]interface{}{PendingLinkSessionKey: PendingLink{
+		UserID:    "ann",
+		Provider:  "github",
+		Subject:   "42",
+		Email:     "ann@example.com",
+		CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]interface{}
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok := out[PendingLinkSessionKey].(PendingLink)
+	if !ok {
+		t.Fatalf("decoded %T, want PendingLink", out[PendingLinkSessionKey])
+	}
+	want := in[PendingLinkSessionKey].(PendingLink)
+	if got.UserID != want.UserID || got.Provider != want.Provider || got.Subject != want.Subject ||
+		got.Email != want.Email || !got.CreatedAt.Equal(want.CreatedAt) {
+		t.Errorf("decoded %+v, want %+v", got, want)
+	}
+}
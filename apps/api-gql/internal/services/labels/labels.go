@@ -0,0 +1,27 @@
+// Package labels cleans up the free-form labels of forms and responses: tags and
+// review statuses.
+package labels
+
+import (
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Normalize trims tags or statuses and drops empty and repeated ones, keeping the original order.
+func Normalize(tags []string) pq.StringArray {
+	result := make(pq.StringArray, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, t := range tags {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		result = append(result, t)
+	}
+	return result
+}
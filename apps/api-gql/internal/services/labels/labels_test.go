@@ -0,0 +1,16 @@
+package labels
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	got := Normalize([]string{" urgent", "", "vip ", "urgent", "  ", "Urgent"})
+	if want := []string{"urgent", "vip", "Urgent"}; !slices.Equal(got, want) {
+		t.Errorf("Normalize() = %q, want %q", got, want)
+	}
+	if got := Normalize(nil); got == nil || len(got) != 0 {
+		t.Errorf("Normalize(nil) = %#v, want an empty array", got)
+	}
+}
@@ -0,0 +1,181 @@
+package mailer
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/TrySquadDF/formify/lib/config"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// pollInterval is how often the queue is checked for due messages
+	pollInterval = 10 * time.Second
+	// sendBatchSize limits how many messages are claimed at once
+	sendBatchSize = 20
+	// claimMargin is added to the lease for the database work around the sends
+	claimMargin = time.Minute
+	// retryBaseDelay is doubled after every failed attempt up to retryMaxDelay
+	retryBaseDelay = time.Minute
+	retryMaxDelay  = 2 * time.Hour
+)
+
+type Opts struct {
+	fx.In
+	LC fx.Lifecycle
+
+	Database *gorm.DB
+	Config   config.Config
+}
+
+type Service struct {
+	database *gorm.DB
+	config   config.Config
+	sender   Sender
+}
+
+func New(opts Opts) *Service {
+	s := &Service{
+		database: opts.Database,
+		config:   opts.Config,
+		sender:   NewSMTPSender(opts.Config),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	opts.LC.Append(
+		fx.Hook{
+			OnStart: func(_ context.Context) error {
+				go s.runQueue(ctx)
+				return nil
+			},
+			OnStop: func(_ context.Context) error {
+				cancel()
+				return nil
+			},
+		},
+	)
+
+	return s
+}
+
+// Enqueue renders a template and queues the message. Called inside a transaction,
+// the message is only sent if the transaction commits.
+func Enqueue(tx *gorm.DB, to, template string, data any) error {
+	rendered, err := Render(template, data)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&gomodel.EmailMessage{
+		To:            to,
+		Template:      template,
+		Subject:       rendered.Subject,
+		TextBody:      rendered.Text,
+		HTMLBody:      rendered.HTML,
+		Status:        gomodel.EmailPending,
+		NextAttemptAt: time.Now(),
+	}).Error
+}
+
+// SendDue sends the queued messages whose next attempt is due and returns how many were tried.
+func (s *Service) SendDue(ctx context.Context) (int, error) {
+	messages, err := s.claimDue(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range messages {
+		if err := s.send(ctx, &messages[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(messages), nil
+}
+
+func (s *Service) claimDue(ctx context.Context) ([]gomodel.EmailMessage, error) {
+	var messages []gomodel.EmailMessage
+	err := s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", gomodel.EmailPending, now).
+			Order("next_attempt_at").
+			Limit(sendBatchSize).
+			Find(&messages).Error; err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(messages))
+		for i, m := range messages {
+			ids[i] = m.ID
+		}
+		return tx.Model(&gomodel.EmailMessage{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(s.claimLease())).Error
+	})
+	return messages, err
+}
+
+// claimLease keeps claimed messages away from other instances while they are sent.
+// They are sent one after another, so the lease outlasts a whole batch of timeouts.
+func (s *Service) claimLease() time.Duration {
+	return sendBatchSize*s.config.SmtpTimeout + claimMargin
+}
+
+// send makes one attempt to deliver a message and records the outcome.
+func (s *Service) send(ctx context.Context, message *gomodel.EmailMessage) error {
+	attempts := message.Attempts + 1
+	updates := map[string]interface{}{
+		"attempts": attempts,
+	}
+
+	now := time.Now()
+	if err := s.sender.Send(message); err != nil {
+		updates["error"] = err.Error()
+		if int(attempts) >= s.config.MailMaxAttempts {
+			updates["status"] = gomodel.EmailFailed
+		} else {
+			updates["next_attempt_at"] = now.Add(retryDelay(attempts))
+		}
+	} else {
+		updates["status"] = gomodel.EmailSent
+		updates["sent_at"] = now
+		updates["error"] = nil
+	}
+
+	return s.database.WithContext(ctx).Model(&gomodel.EmailMessage{}).Where("id = ?", message.ID).Updates(updates).Error
+}
+
+func retryDelay(attempts int32) time.Duration {
+	delay := retryBaseDelay
+	for i := int32(1); i < attempts && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
+
+func (s *Service) runQueue(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.SendDue(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Error sending queued email: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
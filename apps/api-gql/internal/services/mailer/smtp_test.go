@@ -0,0 +1,51 @@
+package mailer
+
+import (
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/TrySquadDF/formify/lib/config"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+func TestSMTPSenderTimesOut(t *testing.T) {
+	// The server accepts the connection and never greets
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	sender := NewSMTPSender(config.Config{
+		SmtpHost:    host,
+		SmtpPort:    portNumber,
+		MailFrom:    "Formify <no-reply@formify.test>",
+		SmtpTimeout: 200 * time.Millisecond,
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- sender.Send(&gomodel.EmailMessage{To: "ann@example.com", Subject: "Hi", TextBody: "Hi"})
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Send() to a silent server succeeded")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Send() did not time out")
+	}
+}
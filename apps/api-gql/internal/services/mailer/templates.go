@@ -0,0 +1,106 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// Every template file defines the "<name>/subject", "<name>/text" and "<name>/html" blocks.
+const (
+	TemplateResponseNotification = "response_notification"
+	TemplateResponseDigest       = "response_digest"
+	TemplateResponseConfirmation = "response_confirmation"
+	TemplateFormInvitation       = "form_invitation"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+var (
+	textTemplates = texttemplate.Must(texttemplate.ParseFS(templateFiles, "templates/*.tmpl"))
+	htmlTemplates = htmltemplate.Must(htmltemplate.ParseFS(templateFiles, "templates/*.tmpl"))
+)
+
+// AnswerLine is a question with the answer given to it, formatted for reading.
+type AnswerLine struct {
+	Question string
+	Value    string
+}
+
+// ResponseNotificationData is the data of TemplateResponseNotification.
+type ResponseNotificationData struct {
+	FormTitle    string
+	ResponsesURL string
+	SubmittedAt  time.Time
+	Answers      []AnswerLine
+}
+
+// ResponseDigestData is the data of TemplateResponseDigest.
+type ResponseDigestData struct {
+	FormTitle    string
+	ResponsesURL string
+	// Period is "hour" or "day"
+	Period    string
+	Count     int
+	Responses []DigestEntry
+	// More is how many responses were left out of the list
+	More int
+}
+
+type DigestEntry struct {
+	SubmittedAt time.Time
+	Preview     string
+}
+
+// ResponseConfirmationData is the data of TemplateResponseConfirmation.
+type ResponseConfirmationData struct {
+	FormTitle   string
+	SubmittedAt time.Time
+	Answers     []AnswerLine
+}
+
+// FormInvitationData is the data of TemplateFormInvitation.
+type FormInvitationData struct {
+	FormTitle   string
+	InviterName string
+	// Role is one of the FormRole values
+	Role string
+	// InvitationURL carries the token needed to accept the invitation
+	InvitationURL string
+	ExpiresAt     time.Time
+}
+
+type Rendered struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Render executes the blocks of a template with the data.
+func Render(name string, data any) (*Rendered, error) {
+	if htmlTemplates.Lookup(name+"/html") == nil {
+		return nil, fmt.Errorf("unknown email template: %s", name)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := textTemplates.ExecuteTemplate(&subject, name+"/subject", data); err != nil {
+		return nil, err
+	}
+	if err := textTemplates.ExecuteTemplate(&text, name+"/text", data); err != nil {
+		return nil, err
+	}
+	if err := htmlTemplates.ExecuteTemplate(&html, name+"/html", data); err != nil {
+		return nil, err
+	}
+
+	return &Rendered{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
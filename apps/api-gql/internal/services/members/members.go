@@ -0,0 +1,371 @@
+package members
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/mailer"
+	"github.com/TrySquadDF/formify/crypto"
+	"github.com/TrySquadDF/formify/lib/config"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// invitationTokenBytes is the amount of randomness in an invitation token
+const invitationTokenBytes = 32
+
+var (
+	ErrInvalidRole         = errors.New("invalid member role")
+	ErrInvalidEmail        = errors.New("invalid email address")
+	ErrAlreadyMember       = errors.New("user is already a member of the form")
+	ErrMemberNotFound      = errors.New("member not found")
+	ErrInvitationNotFound  = errors.New("invitation not found")
+	ErrInvitationExpired   = errors.New("invitation has expired")
+	ErrInvitationResponded = errors.New("invitation is no longer pending")
+)
+
+type Opts struct {
+	fx.In
+
+	Database *gorm.DB
+	Config   config.Config
+}
+
+type Service struct {
+	database *gorm.DB
+	config   config.Config
+}
+
+func New(opts Opts) *Service {
+	return &Service{
+		database: opts.Database,
+		config:   opts.Config,
+	}
+}
+
+// Members returns the members of the form with their users, oldest first.
+// The owner is not a member; it is the owner_id of the form.
+func (s *Service) Members(ctx context.Context, formID string) ([]gomodel.FormMember, error) {
+	var members []gomodel.FormMember
+	err := s.database.WithContext(ctx).
+		Preload("User").
+		Where("form_id = ?", formID).
+		Order("created_at").
+		Find(&members).Error
+	return members, err
+}
+
+// Invite creates an invitation for the email and mails it with the token needed to
+// accept it. A pending invitation to the same address is replaced.
+func (s *Service) Invite(ctx context.Context, form *gomodel.Form, inviterID, email string, role gomodel.FormRole) (*gomodel.FormInvitation, error) {
+	if !memberRole(role) {
+		return nil, ErrInvalidRole
+	}
+
+	address, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return nil, ErrInvalidEmail
+	}
+	email = strings.ToLower(address.Address)
+
+	var inviter gomodel.Users
+	if err := s.database.WithContext(ctx).First(&inviter, "id = ?", inviterID).Error; err != nil {
+		return nil, err
+	}
+
+	if err := s.checkNotMember(ctx, form, email); err != nil {
+		return nil, err
+	}
+
+	token, err := crypto.NewToken(invitationTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	tokenHash := crypto.HashToken(token)
+
+	invitation := &gomodel.FormInvitation{
+		FormID:      form.ID,
+		Email:       email,
+		Role:        role,
+		InvitedByID: inviterID,
+		Status:      gomodel.FormInvitationPending,
+		ExpiresAt:   time.Now().Add(s.config.InvitationTTL),
+		TokenHash:   &tokenHash,
+	}
+
+	err = s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&gomodel.FormInvitation{}).
+			Where("form_id = ? AND email = ? AND status = ?", form.ID, email, gomodel.FormInvitationPending).
+			Update("status", gomodel.FormInvitationRevoked).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(invitation).Error; err != nil {
+			return err
+		}
+
+		return mailer.Enqueue(tx, email, mailer.TemplateFormInvitation, mailer.FormInvitationData{
+			FormTitle:     form.Title,
+			InviterName:   inviter.DisplayName,
+			Role:          string(role),
+			InvitationURL: s.invitationURL(invitation.ID, token),
+			ExpiresAt:     invitation.ExpiresAt,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// checkNotMember fails when the user with the email already owns or is a member of the form.
+func (s *Service) checkNotMember(ctx context.Context, form *gomodel.Form, email string) error {
+	var user gomodel.Users
+	err := s.database.WithContext(ctx).Where("LOWER(email) = ?", email).Take(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if user.ID == form.OwnerID {
+		return ErrAlreadyMember
+	}
+
+	var count int64
+	if err := s.database.WithContext(ctx).Model(&gomodel.FormMember{}).
+		Where("form_id = ? AND user_id = ?", form.ID, user.ID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrAlreadyMember
+	}
+	return nil
+}
+
+// Invitations returns the pending invitations of the form, newest first.
+func (s *Service) Invitations(ctx context.Context, formID string) ([]gomodel.FormInvitation, error) {
+	var invitations []gomodel.FormInvitation
+	err := s.database.WithContext(ctx).
+		Preload("InvitedBy").
+		Where("form_id = ? AND status = ?", formID, gomodel.FormInvitationPending).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+// InvitationsFor returns the pending, unexpired invitations sent to the email.
+func (s *Service) InvitationsFor(ctx context.Context, email string) ([]gomodel.FormInvitation, error) {
+	var invitations []gomodel.FormInvitation
+	err := s.database.WithContext(ctx).
+		Preload("Form").
+		Preload("InvitedBy").
+		Joins("JOIN forms ON forms.id = form_invitations.form_id AND forms.\"deletedAt\" IS NULL").
+		Where("form_invitations.email = ? AND form_invitations.status = ? AND form_invitations.expires_at > ?",
+			strings.ToLower(email), gomodel.FormInvitationPending, time.Now()).
+		Order("form_invitations.created_at DESC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+// FindInvitation loads an invitation by ID.
+func (s *Service) FindInvitation(ctx context.Context, id string) (*gomodel.FormInvitation, error) {
+	var invitation gomodel.FormInvitation
+	if err := s.database.WithContext(ctx).First(&invitation, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// Accept makes the user a member of the form with the invited role. It takes the
+// token from the invitation email: users.email is not verified, so a matching
+// address does not prove the user reads the mailbox the invitation was sent to.
+func (s *Service) Accept(ctx context.Context, invitationID, token string, user *gomodel.Users) (*gomodel.FormMember, error) {
+	var member *gomodel.FormMember
+	err := s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		invitation, err := respond(tx, gomodel.FormInvitationAccepted,
+			"id = ? AND token_hash = ?", invitationID, crypto.HashToken(token))
+		if err != nil {
+			return err
+		}
+
+		var form gomodel.Form
+		if err := tx.First(&form, "id = ?", invitation.FormID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvitationNotFound
+			}
+			return err
+		}
+		if form.OwnerID == user.ID {
+			return ErrAlreadyMember
+		}
+
+		member = &gomodel.FormMember{
+			FormID: invitation.FormID,
+			UserID: user.ID,
+			Role:   invitation.Role,
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "form_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"role"}),
+		}).Create(member).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// Decline turns down an invitation sent to the address of the user.
+func (s *Service) Decline(ctx context.Context, invitationID string, user *gomodel.Users) error {
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := respond(tx, gomodel.FormInvitationDeclined,
+			"id = ? AND email = ?", invitationID, strings.ToLower(user.Email))
+		return err
+	})
+}
+
+// respond locks the pending invitation matching the condition and moves it to the status.
+// An invitation that does not match, such as someone else's, looks the same as a missing one.
+func respond(tx *gorm.DB, status gomodel.FormInvitationStatus, cond string, args ...interface{}) (*gomodel.FormInvitation, error) {
+	var invitation gomodel.FormInvitation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(cond, args...).
+		First(&invitation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, err
+	}
+
+	if invitation.Status != gomodel.FormInvitationPending {
+		return nil, ErrInvitationResponded
+	}
+	if time.Now().After(invitation.ExpiresAt) {
+		return nil, ErrInvitationExpired
+	}
+
+	now := time.Now()
+	if err := tx.Model(&invitation).Updates(map[string]interface{}{
+		"status":       status,
+		"responded_at": now,
+	}).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// RevokeInvitation withdraws a pending invitation.
+func (s *Service) RevokeInvitation(ctx context.Context, invitation *gomodel.FormInvitation) error {
+	result := s.database.WithContext(ctx).Model(invitation).
+		Where("status = ?", gomodel.FormInvitationPending).
+		Update("status", gomodel.FormInvitationRevoked)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvitationResponded
+	}
+	return nil
+}
+
+// ChangeRole gives a member another role. Ownership changes only through TransferOwnership.
+func (s *Service) ChangeRole(ctx context.Context, formID, userID string, role gomodel.FormRole) (*gomodel.FormMember, error) {
+	if !memberRole(role) {
+		return nil, ErrInvalidRole
+	}
+
+	result := s.database.WithContext(ctx).Model(&gomodel.FormMember{}).
+		Where("form_id = ? AND user_id = ?", formID, userID).
+		Update("role", role)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrMemberNotFound
+	}
+
+	var member gomodel.FormMember
+	if err := s.database.WithContext(ctx).Preload("User").
+		First(&member, "form_id = ? AND user_id = ?", formID, userID).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// Remove takes the user out of the form members.
+func (s *Service) Remove(ctx context.Context, formID, userID string) error {
+	result := s.database.WithContext(ctx).
+		Where("form_id = ? AND user_id = ?", formID, userID).
+		Delete(&gomodel.FormMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrMemberNotFound
+	}
+	return nil
+}
+
+// TransferOwnership makes a member the owner of the form. The previous owner stays
+// on as an editor.
+func (s *Service) TransferOwnership(ctx context.Context, formID, newOwnerID string) error {
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var form gomodel.Form
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&form, "id = ?", formID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return access.ErrFormNotFound
+			}
+			return err
+		}
+
+		result := tx.Where("form_id = ? AND user_id = ?", formID, newOwnerID).Delete(&gomodel.FormMember{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrMemberNotFound
+		}
+
+		previousOwnerID := form.OwnerID
+		if err := tx.Model(&form).Update("owner_id", newOwnerID).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&gomodel.FormMember{
+			FormID: formID,
+			UserID: previousOwnerID,
+			Role:   gomodel.FormRoleEditor,
+		}).Error
+	})
+}
+
+// memberRole reports whether the role can be given to a member; OWNER cannot.
+func memberRole(role gomodel.FormRole) bool {
+	return access.ValidRole(role) && role != gomodel.FormRoleOwner
+}
+
+// invitationURL links to the invitations on the profile page, carrying what is needed to accept one.
+func (s *Service) invitationURL(invitationID, token string) string {
+	query := url.Values{"invitation": {invitationID}, "token": {token}}.Encode()
+	u, err := url.Parse(s.config.SiteBaseUrl)
+	if err != nil {
+		return s.config.SiteBaseUrl + "/profile?" + query
+	}
+	u = u.JoinPath("profile")
+	u.RawQuery = query
+	return u.String()
+}
@@ -0,0 +1,39 @@
+package members
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/TrySquadDF/formify/lib/config"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+func TestInvitationURL(t *testing.T) {
+	s := &Service{config: config.Config{SiteBaseUrl: "https://formify.test/app/"}}
+
+	u, err := url.Parse(s.invitationURL("inv-1", "a+b/c="))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Host != "formify.test" || u.Path != "/app/profile" {
+		t.Errorf("invitationURL() = %s, want the profile page of the site", u)
+	}
+	// The token must come back intact to accept the invitation
+	if q := u.Query(); q.Get("invitation") != "inv-1" || q.Get("token") != "a+b/c=" {
+		t.Errorf("invitationURL() query = %v", q)
+	}
+}
+
+func TestMemberRole(t *testing.T) {
+	for role, want := range map[gomodel.FormRole]bool{
+		gomodel.FormRoleViewer:  true,
+		gomodel.FormRoleAnalyst: true,
+		gomodel.FormRoleEditor:  true,
+		gomodel.FormRoleOwner:   false,
+		"ADMIN":                 false,
+	} {
+		if got := memberRole(role); got != want {
+			t.Errorf("memberRole(%q) = %v, want %v", role, got, want)
+		}
+	}
+}
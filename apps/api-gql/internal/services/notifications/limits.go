@@ -0,0 +1,59 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	// confirmationWindow is the period the confirmation limits are counted over
+	confirmationWindow = time.Hour
+	// confirmationsPerForm and confirmationsPerIP bound the copies of answers sent to
+	// respondents, so that a form cannot be used to mail arbitrary addresses in bulk
+	confirmationsPerForm = 200
+	confirmationsPerIP   = 10
+)
+
+// AllowConfirmation counts a copy of the answers to be sent to a respondent and reports
+// whether it is within the limits of the form and of the address the response came
+// from. An empty ip is only counted against the form. When the counters cannot be
+// reached the copy is not sent.
+func (s *Service) AllowConfirmation(ctx context.Context, formID, ip string) bool {
+	if ip != "" {
+		ok, err := s.countConfirmation(ctx, "ip:"+ip, confirmationsPerIP)
+		if err != nil {
+			log.Printf("Error counting respondent confirmations: %v", err)
+			return false
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	ok, err := s.countConfirmation(ctx, "form:"+formID, confirmationsPerForm)
+	if err != nil {
+		log.Printf("Error counting respondent confirmations: %v", err)
+		return false
+	}
+	return ok
+}
+
+// countConfirmation adds one to the counter of the current window and reports whether
+// it stays within limit.
+func (s *Service) countConfirmation(ctx context.Context, subject string, limit int64) (bool, error) {
+	window := time.Now().Truncate(confirmationWindow).Unix()
+	key := fmt.Sprintf("ratelimit:confirmations:%s:%d", subject, window)
+
+	n, err := s.redis.Incr(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+	if n == 1 {
+		if err := s.redis.Expire(ctx, key, confirmationWindow).Err(); err != nil {
+			return false, err
+		}
+	}
+	return n <= limit, nil
+}
@@ -0,0 +1,329 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/mailer"
+	"github.com/TrySquadDF/formify/lib/config"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+const (
+	// digestInterval is how often forms are checked for a due digest
+	digestInterval = 5 * time.Minute
+	// digestListSize limits how many responses are listed in a digest
+	digestListSize = 10
+	// previewAnswers is how many answers make up the preview of a response in a digest
+	previewAnswers = 2
+)
+
+type Opts struct {
+	fx.In
+	LC fx.Lifecycle
+
+	Database *gorm.DB
+	Config   config.Config
+	Redis    *redis.Client
+}
+
+type Service struct {
+	database *gorm.DB
+	config   config.Config
+	redis    *redis.Client
+}
+
+func New(opts Opts) *Service {
+	s := &Service{
+		database: opts.Database,
+		config:   opts.Config,
+		redis:    opts.Redis,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	opts.LC.Append(
+		fx.Hook{
+			OnStart: func(_ context.Context) error {
+				go s.runDigests(ctx)
+				return nil
+			},
+			OnStop: func(_ context.Context) error {
+				cancel()
+				return nil
+			},
+		},
+	)
+
+	return s
+}
+
+// ResponseSubmitted queues the emails about a new response: a notification to the owner
+// when the form asks for one per response, and a copy of the answers to the respondent
+// when the form has a respondent email question and confirm is set, see
+// AllowConfirmation. Answers must have Question and SelectedOptions set.
+func ResponseSubmitted(tx *gorm.DB, cfg config.Config, form *gomodel.Form, response *gomodel.FormResponse, answers []gomodel.Answer, confirm bool) error {
+	lines := answerLines(form, answers)
+
+	if form.ResponseNotifications == gomodel.ResponseNotificationsEach {
+		owner, err := ownerEmail(tx, form.OwnerID)
+		if err != nil {
+			return err
+		}
+		if owner != "" {
+			if err := mailer.Enqueue(tx, owner, mailer.TemplateResponseNotification, mailer.ResponseNotificationData{
+				FormTitle:    form.Title,
+				ResponsesURL: responsesURL(cfg, form.ID),
+				SubmittedAt:  response.CreatedAt,
+				Answers:      lines,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if respondent := RespondentEmail(answers); confirm && respondent != "" {
+		return mailer.Enqueue(tx, respondent, mailer.TemplateResponseConfirmation, mailer.ResponseConfirmationData{
+			FormTitle:   form.Title,
+			SubmittedAt: response.CreatedAt,
+			Answers:     lines,
+		})
+	}
+
+	return nil
+}
+
+// SendDigests queues the hourly and daily digests that are due and returns how many were queued.
+func (s *Service) SendDigests(ctx context.Context) (int, error) {
+	var forms []gomodel.Form
+	if err := s.database.WithContext(ctx).
+		Where(`"responseNotifications" IN ?`, []gomodel.ResponseNotifications{gomodel.ResponseNotificationsHourly, gomodel.ResponseNotificationsDaily}).
+		Find(&forms).Error; err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := range forms {
+		ok, err := s.sendDigest(ctx, &forms[i])
+		if err != nil {
+			return sent, err
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// sendDigest queues the digest of a form if its period is over and there were new responses.
+func (s *Service) sendDigest(ctx context.Context, form *gomodel.Form) (bool, error) {
+	period, periodName := time.Hour, "hour"
+	if form.ResponseNotifications == gomodel.ResponseNotificationsDaily {
+		period, periodName = 24*time.Hour, "day"
+	}
+
+	now := time.Now()
+	since := now.Add(-period)
+	if form.LastDigestAt != nil {
+		since = *form.LastDigestAt
+	}
+	if now.Sub(since) < period {
+		return false, nil
+	}
+
+	sent := false
+	err := s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Claim the period so that several instances never send the same digest
+		claim := tx.Model(&gomodel.Form{}).Where("id = ?", form.ID)
+		if form.LastDigestAt == nil {
+			claim = claim.Where(`"lastDigestAt" IS NULL`)
+		} else {
+			claim = claim.Where(`"lastDigestAt" = ?`, *form.LastDigestAt)
+		}
+		result := claim.UpdateColumn("lastDigestAt", now)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		var count int64
+		query := tx.Model(&gomodel.FormResponse{}).Where("form_id = ? AND created_at > ? AND created_at <= ?", form.ID, since, now)
+		if err := query.Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return nil
+		}
+
+		owner, err := ownerEmail(tx, form.OwnerID)
+		if err != nil || owner == "" {
+			return err
+		}
+
+		var responses []gomodel.FormResponse
+		if err := tx.
+			Preload("Answers.Question").
+			Preload("Answers.SelectedOptions").
+			Where("form_id = ? AND created_at > ? AND created_at <= ?", form.ID, since, now).
+			Order("created_at DESC").
+			Limit(digestListSize).
+			Find(&responses).Error; err != nil {
+			return err
+		}
+
+		data := mailer.ResponseDigestData{
+			FormTitle:    form.Title,
+			ResponsesURL: responsesURL(s.config, form.ID),
+			Period:       periodName,
+			Count:        int(count),
+			Responses:    make([]mailer.DigestEntry, len(responses)),
+			More:         int(count) - len(responses),
+		}
+		for i := range responses {
+			data.Responses[i] = mailer.DigestEntry{
+				SubmittedAt: responses[i].CreatedAt,
+				Preview:     preview(form, responses[i].Answers),
+			}
+		}
+
+		sent = true
+		return mailer.Enqueue(tx, owner, mailer.TemplateResponseDigest, data)
+	})
+	return sent, err
+}
+
+func (s *Service) runDigests(ctx context.Context) {
+	ticker := time.NewTicker(digestInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := s.SendDigests(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Error sending response digests: %v", err)
+		} else if n > 0 {
+			log.Printf("Queued %d response digests", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func ownerEmail(tx *gorm.DB, ownerID string) (string, error) {
+	var owner gomodel.Users
+	if err := tx.Select("email").First(&owner, "id = ?", ownerID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return owner.Email, nil
+}
+
+// RespondentEmail returns the address given in the respondent email question, if it is valid.
+func RespondentEmail(answers []gomodel.Answer) string {
+	for _, a := range answers {
+		if !a.Question.RespondentEmail || a.Question.Type != gomodel.QuestionTypeEmail {
+			continue
+		}
+		address, err := mail.ParseAddress(strings.TrimSpace(a.TextValue))
+		if err != nil {
+			return ""
+		}
+		return address.Address
+	}
+	return ""
+}
+
+func responsesURL(cfg config.Config, formID string) string {
+	u, err := url.Parse(cfg.SiteBaseUrl)
+	if err != nil {
+		return cfg.SiteBaseUrl
+	}
+	return u.JoinPath("answers", formID).String()
+}
+
+// answerLines formats answers in the order of the questions of the form.
+func answerLines(form *gomodel.Form, answers []gomodel.Answer) []mailer.AnswerLine {
+	byQuestion := make(map[string]*gomodel.Answer, len(answers))
+	for i := range answers {
+		byQuestion[answers[i].QuestionID] = &answers[i]
+	}
+
+	lines := make([]mailer.AnswerLine, 0, len(answers))
+	for _, q := range orderedQuestions(form, answers) {
+		value := "—"
+		if a, ok := byQuestion[q.ID]; ok {
+			if formatted := formatAnswer(a); formatted != "" {
+				value = formatted
+			}
+		}
+		lines = append(lines, mailer.AnswerLine{Question: q.Text, Value: value})
+	}
+	return lines
+}
+
+// orderedQuestions returns the questions of the form, or of the answers when the
+// form was loaded without them, sorted by their order.
+func orderedQuestions(form *gomodel.Form, answers []gomodel.Answer) []gomodel.Question {
+	questions := form.Questions
+	if len(questions) == 0 {
+		questions = make([]gomodel.Question, len(answers))
+		for i := range answers {
+			questions[i] = answers[i].Question
+		}
+	}
+
+	sorted := make([]gomodel.Question, len(questions))
+	copy(sorted, questions)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Order < sorted[j].Order })
+	return sorted
+}
+
+func preview(form *gomodel.Form, answers []gomodel.Answer) string {
+	parts := make([]string, 0, previewAnswers)
+	for _, line := range answerLines(form, answers) {
+		if line.Value == "—" {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", line.Question, line.Value))
+		if len(parts) == previewAnswers {
+			break
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+func formatAnswer(a *gomodel.Answer) string {
+	switch {
+	case a.BoolValue != nil:
+		if *a.BoolValue {
+			return "Да"
+		}
+		return "Нет"
+	case a.NumberValue != nil:
+		return strconv.FormatFloat(*a.NumberValue, 'f', -1, 64)
+	case a.DateValue != nil:
+		return a.DateValue.Format("02.01.2006")
+	case len(a.SelectedOptions) > 0:
+		texts := make([]string, len(a.SelectedOptions))
+		for i, o := range a.SelectedOptions {
+			texts[i] = o.Text
+		}
+		return strings.Join(texts, ", ")
+	default:
+		return a.TextValue
+	}
+}
@@ -0,0 +1,134 @@
+package presence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+)
+
+// TTL is how long an editor stays present after the last heartbeat
+const TTL = 30 * time.Second
+
+type Opts struct {
+	fx.In
+
+	Redis *redis.Client
+}
+
+// Service tracks who has a form open. Every editor has a key that expires with TTL,
+// and a sorted set per form indexes the editors by the time their key expires.
+type Service struct {
+	redis *redis.Client
+}
+
+func New(opts Opts) *Service {
+	return &Service{
+		redis: opts.Redis,
+	}
+}
+
+type Editor struct {
+	UserID      string `json:"userId"`
+	DisplayName string `json:"displayName"`
+	Picture     string `json:"picture"`
+	// Editing names what the editor is working on, e.g. "question:<id>"; empty while just viewing
+	Editing  string    `json:"editing,omitempty"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+func indexKey(formID string) string {
+	return fmt.Sprintf("presence:forms:%s", formID)
+}
+
+func editorKey(formID, userID string) string {
+	return fmt.Sprintf("presence:forms:%s:%s", formID, userID)
+}
+
+// Heartbeat marks the editor as present for another TTL. It reports whether the editor
+// was not present before or changed what they are editing.
+func (s *Service) Heartbeat(ctx context.Context, formID string, editor Editor) (bool, error) {
+	editor.LastSeen = time.Now()
+	data, err := json.Marshal(editor)
+	if err != nil {
+		return false, err
+	}
+
+	previous, err := s.redis.Get(ctx, editorKey(formID, editor.UserID)).Result()
+	if err != nil && err != redis.Nil {
+		return false, err
+	}
+
+	expiresAt := editor.LastSeen.Add(TTL)
+	_, err = s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, editorKey(formID, editor.UserID), data, TTL)
+		pipe.ZAdd(ctx, indexKey(formID), redis.Z{Score: float64(expiresAt.UnixMilli()), Member: editor.UserID})
+		pipe.Expire(ctx, indexKey(formID), TTL)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if previous == "" {
+		return true, nil
+	}
+	var before Editor
+	if err := json.Unmarshal([]byte(previous), &before); err != nil {
+		return true, nil
+	}
+	return before.Editing != editor.Editing, nil
+}
+
+// Leave removes the editor from the form right away.
+func (s *Service) Leave(ctx context.Context, formID, userID string) error {
+	_, err := s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, editorKey(formID, userID))
+		pipe.ZRem(ctx, indexKey(formID), userID)
+		return nil
+	})
+	return err
+}
+
+// Editors returns who has the form open, dropping editors whose heartbeat expired.
+func (s *Service) Editors(ctx context.Context, formID string) ([]Editor, error) {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	if err := s.redis.ZRemRangeByScore(ctx, indexKey(formID), "-inf", now).Err(); err != nil {
+		return nil, err
+	}
+
+	userIDs, err := s.redis.ZRange(ctx, indexKey(formID), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(userIDs) == 0 {
+		return []Editor{}, nil
+	}
+
+	keys := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		keys[i] = editorKey(formID, id)
+	}
+	values, err := s.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	editors := make([]Editor, 0, len(values))
+	for _, v := range values {
+		data, ok := v.(string)
+		if !ok {
+			continue
+		}
+		var editor Editor
+		if err := json.Unmarshal([]byte(data), &editor); err != nil {
+			continue
+		}
+		editors = append(editors, editor)
+	}
+	return editors, nil
+}
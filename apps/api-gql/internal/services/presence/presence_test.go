@@ -0,0 +1,100 @@
+package presence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/redistest"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestHeartbeat(t *testing.T) {
+	client, server := redistest.Start(t)
+	s := New(Opts{Redis: client})
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		editing string
+		want    bool
+	}{
+		{name: "arrives", want: true},
+		{name: "still viewing", want: false},
+		{name: "starts editing", editing: "question:q1", want: true},
+		{name: "still editing", editing: "question:q1", want: false},
+		{name: "edits another question", editing: "question:q2", want: true},
+	}
+	for _, tt := range tests {
+		changed, err := s.Heartbeat(ctx, "f1", Editor{UserID: "u1", DisplayName: "Ann", Editing: tt.editing})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if changed != tt.want {
+			t.Errorf("%s: Heartbeat() = %v, want %v", tt.name, changed, tt.want)
+		}
+	}
+
+	// The editor and the index live for TTL after the last heartbeat
+	for _, key := range []string{editorKey("f1", "u1"), indexKey("f1")} {
+		if ttl := server.TTL(key); ttl <= TTL-time.Second || ttl > TTL {
+			t.Errorf("TTL of %s = %v, want %v", key, ttl, TTL)
+		}
+	}
+	score, err := client.ZScore(ctx, indexKey("f1"), "u1").Result()
+	if expiresAt := time.UnixMilli(int64(score)); err != nil || time.Until(expiresAt) <= TTL-time.Second || time.Until(expiresAt) > TTL {
+		t.Errorf("index expires the editor at %v (%v), want in %v", expiresAt, err, TTL)
+	}
+
+	// A heartbeat after the editor expired counts as an arrival
+	server.FastForward(TTL)
+	if changed, err := s.Heartbeat(ctx, "f1", Editor{UserID: "u1", Editing: "question:q2"}); err != nil || !changed {
+		t.Errorf("Heartbeat() after expiry = %v, %v, want an arrival", changed, err)
+	}
+}
+
+func TestEditors(t *testing.T) {
+	client, server := redistest.Start(t)
+	s := New(Opts{Redis: client})
+	ctx := context.Background()
+
+	for _, editor := range []Editor{{UserID: "u1", DisplayName: "Ann"}, {UserID: "u2", DisplayName: "Bob", Editing: "question:q1"}} {
+		if _, err := s.Heartbeat(ctx, "f1", editor); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// u3 stopped sending heartbeats: the index says so before its key is gone
+	client.ZAdd(ctx, indexKey("f1"), redis.Z{Score: float64(time.Now().Add(-time.Second).UnixMilli()), Member: "u3"})
+	// u4 is still indexed but its key is gone
+	client.ZAdd(ctx, indexKey("f1"), redis.Z{Score: float64(time.Now().Add(time.Minute).UnixMilli()), Member: "u4"})
+
+	editors, err := s.Editors(ctx, "f1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	byID := make(map[string]Editor)
+	for _, editor := range editors {
+		byID[editor.UserID] = editor
+	}
+	if len(byID) != 2 || byID["u1"].DisplayName != "Ann" || byID["u2"].Editing != "question:q1" || byID["u1"].LastSeen.IsZero() {
+		t.Errorf("editors = %+v, want Ann and Bob", editors)
+	}
+	if err := client.ZScore(ctx, indexKey("f1"), "u3").Err(); err != redis.Nil {
+		t.Errorf("expired editor left in the index: %v", err)
+	}
+
+	if err := s.Leave(ctx, "f1", "u1"); err != nil {
+		t.Fatal(err)
+	}
+	if server.Exists(editorKey("f1", "u1")) {
+		t.Error("editor key left after Leave")
+	}
+	if editors, _ := s.Editors(ctx, "f1"); len(editors) != 1 || editors[0].UserID != "u2" {
+		t.Errorf("editors after Leave = %+v, want Bob", editors)
+	}
+
+	server.FastForward(TTL)
+	if editors, err := s.Editors(ctx, "f1"); err != nil || len(editors) != 0 {
+		t.Errorf("editors after TTL = %+v, %v, want none", editors, err)
+	}
+}
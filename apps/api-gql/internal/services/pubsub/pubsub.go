@@ -0,0 +1,167 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+)
+
+type Opts struct {
+	fx.In
+
+	Redis *redis.Client
+}
+
+// Service fans events out to subscribers on every API instance through Redis pub/sub.
+// Messages carry only identifiers; subscribers load the current state themselves.
+type Service struct {
+	redis *redis.Client
+}
+
+func New(opts Opts) *Service {
+	return &Service{
+		redis: opts.Redis,
+	}
+}
+
+func responsesChannel(formID string) string {
+	return fmt.Sprintf("forms:%s:responses", formID)
+}
+
+func formChannel(formID string) string {
+	return fmt.Sprintf("forms:%s:updated", formID)
+}
+
+func changesChannel(formID string) string {
+	return fmt.Sprintf("forms:%s:changes", formID)
+}
+
+// Kinds of fine-grained form changes
+const (
+	FormChangeFormUpdated       = "FORM_UPDATED"
+	FormChangeQuestionsReplaced = "QUESTIONS_REPLACED"
+	FormChangeQuestionUpdated   = "QUESTION_UPDATED"
+	FormChangeQuestionDeleted   = "QUESTION_DELETED"
+	FormChangeOptionUpdated     = "OPTION_UPDATED"
+	FormChangeOptionDeleted     = "OPTION_DELETED"
+	FormChangeFormClosed        = "FORM_CLOSED"
+	FormChangeFormReopened      = "FORM_REOPENED"
+	FormChangePresenceChanged   = "PRESENCE_CHANGED"
+)
+
+// FormChange describes one change of a form for collaborating editors.
+type FormChange struct {
+	Kind       string `json:"kind"`
+	FormID     string `json:"formId"`
+	Version    int64  `json:"version"`
+	ActorID    string `json:"actorId"`
+	QuestionID string `json:"questionId,omitempty"`
+	OptionID   string `json:"optionId,omitempty"`
+}
+
+// PublishResponseSubmitted announces a new response to the form. Publishing is best
+// effort: a failure is logged and does not affect the submission.
+func (s *Service) PublishResponseSubmitted(ctx context.Context, formID, responseID string) {
+	s.publish(ctx, responsesChannel(formID), responseID)
+}
+
+// PublishFormUpdated announces a change of the form or its questions.
+func (s *Service) PublishFormUpdated(ctx context.Context, formID string) {
+	s.publish(ctx, formChannel(formID), formID)
+}
+
+func (s *Service) publish(ctx context.Context, channel, payload string) {
+	if err := s.redis.Publish(ctx, channel, payload).Err(); err != nil {
+		log.Printf("Error publishing to %s: %v", channel, err)
+	}
+}
+
+// PublishFormChange announces a fine-grained change to collaborating editors. Changes
+// of the form itself are announced to formUpdated subscribers as well.
+func (s *Service) PublishFormChange(ctx context.Context, change FormChange) {
+	data, err := json.Marshal(change)
+	if err != nil {
+		log.Printf("Error encoding form change: %v", err)
+		return
+	}
+
+	s.publish(ctx, changesChannel(change.FormID), string(data))
+	if change.Kind != FormChangePresenceChanged {
+		s.PublishFormUpdated(ctx, change.FormID)
+	}
+}
+
+// ResponseSubmitted streams the IDs of new responses to the form until ctx is done.
+func (s *Service) ResponseSubmitted(ctx context.Context, formID string) (<-chan string, error) {
+	return s.subscribe(ctx, responsesChannel(formID))
+}
+
+// FormUpdated streams a message for every change of the form until ctx is done.
+func (s *Service) FormUpdated(ctx context.Context, formID string) (<-chan string, error) {
+	return s.subscribe(ctx, formChannel(formID))
+}
+
+// FormChanges streams the fine-grained changes of the form until ctx is done.
+func (s *Service) FormChanges(ctx context.Context, formID string) (<-chan FormChange, error) {
+	messages, err := s.subscribe(ctx, changesChannel(formID))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan FormChange)
+	go func() {
+		defer close(out)
+		for msg := range messages {
+			var change FormChange
+			if err := json.Unmarshal([]byte(msg), &change); err != nil {
+				log.Printf("Error decoding form change: %v", err)
+				continue
+			}
+			select {
+			case out <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (s *Service) subscribe(ctx context.Context, channel string) (<-chan string, error) {
+	sub := s.redis.Subscribe(ctx, channel)
+	// Wait for the confirmation so no message published after we return is missed
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return nil, err
+	}
+
+	out := make(chan string)
+	go func() {
+		defer close(out)
+		defer sub.Close()
+
+		messages := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				select {
+				case out <- msg.Payload:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
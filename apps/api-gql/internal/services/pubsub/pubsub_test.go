@@ -0,0 +1,114 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/redistest"
+)
+
+// receive waits for the next message of a subscription.
+func receive[T any](t *testing.T, messages <-chan T) T {
+	t.Helper()
+	select {
+	case msg, ok := <-messages:
+		if !ok {
+			t.Fatal("subscription closed")
+		}
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("no message")
+	}
+	var zero T
+	return zero
+}
+
+// silent checks that a subscription gets no message for a while.
+func silent[T any](t *testing.T, messages <-chan T) {
+	t.Helper()
+	select {
+	case msg := <-messages:
+		t.Errorf("unexpected message %v", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestResponseSubmitted(t *testing.T) {
+	client, _ := redistest.Start(t)
+	s := New(Opts{Redis: client})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	responses, err := s.ResponseSubmitted(ctx, "f1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := s.ResponseSubmitted(ctx, "f2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.PublishResponseSubmitted(ctx, "f1", "r1")
+	if id := receive(t, responses); id != "r1" {
+		t.Errorf("response = %q, want r1", id)
+	}
+	silent(t, other)
+}
+
+func TestFormChanges(t *testing.T) {
+	client, _ := redistest.Start(t)
+	s := New(Opts{Redis: client})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := s.FormChanges(ctx, "f1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	updates, err := s.FormUpdated(ctx, "f1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A message that is not a change is skipped
+	client.Publish(ctx, changesChannel("f1"), "garbage")
+
+	change := FormChange{Kind: FormChangeQuestionUpdated, FormID: "f1", Version: 4, ActorID: "u1", QuestionID: "q1"}
+	s.PublishFormChange(ctx, change)
+	if got := receive(t, changes); got != change {
+		t.Errorf("change = %+v, want %+v", got, change)
+	}
+	if id := receive(t, updates); id != "f1" {
+		t.Errorf("update = %q, want f1", id)
+	}
+
+	// Presence is not a change of the form
+	presence := FormChange{Kind: FormChangePresenceChanged, FormID: "f1", Version: 4, ActorID: "u2"}
+	s.PublishFormChange(ctx, presence)
+	if got := receive(t, changes); got != presence {
+		t.Errorf("change = %+v, want %+v", got, presence)
+	}
+	silent(t, updates)
+}
+
+func TestSubscriptionEndsWithContext(t *testing.T) {
+	client, _ := redistest.Start(t)
+	s := New(Opts{Redis: client})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	changes, err := s.FormChanges(ctx, "f1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	select {
+	case _, ok := <-changes:
+		if ok {
+			t.Error("message after the subscription ended")
+		}
+	case <-time.After(time.Second):
+		t.Error("subscription still open")
+	}
+}
@@ -0,0 +1,124 @@
+package responses
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/TrySquadDF/formify/crypto"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"gorm.io/gorm"
+)
+
+// draftTokenBytes is the amount of randomness in a draft resume token
+const draftTokenBytes = 32
+
+var ErrDraftNotFound = errors.New("draft not found or expired")
+
+type Draft struct {
+	// Token is the resume token in clear, as known to the respondent
+	Token     string
+	FormID    string
+	Answers   []AnswerInput
+	UpdatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// SaveDraft stores partial answers without enforcing required questions. Without a
+// token a new draft is created; otherwise the draft behind the token is replaced.
+// Every save extends the draft lifetime by the configured TTL.
+func (s *Service) SaveDraft(ctx context.Context, form *gomodel.Form, inputs []AnswerInput, token *string) (*Draft, error) {
+	if form.ClosedAt != nil {
+		return nil, ErrFormClosed
+	}
+	if _, err := ValidateAnswers(form, "", inputs, true); err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.config.DraftTTL)
+
+	if token == nil {
+		newToken, err := crypto.NewToken(draftTokenBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.database.WithContext(ctx).Create(&gomodel.ResponseDraft{
+			FormID:    form.ID,
+			TokenHash: crypto.HashToken(newToken),
+			Answers:   string(encoded),
+			ExpiresAt: expiresAt,
+		}).Error; err != nil {
+			return nil, err
+		}
+
+		token = &newToken
+	} else {
+		result := s.database.WithContext(ctx).
+			Model(&gomodel.ResponseDraft{}).
+			Where("token_hash = ? AND form_id = ? AND expires_at > ?", crypto.HashToken(*token), form.ID, now).
+			Updates(map[string]interface{}{
+				"answers":    string(encoded),
+				"updated_at": now,
+				"expires_at": expiresAt,
+			})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil, ErrDraftNotFound
+		}
+	}
+
+	return &Draft{
+		Token:     *token,
+		FormID:    form.ID,
+		Answers:   inputs,
+		UpdatedAt: now,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// LoadDraft returns an unexpired draft by its resume token.
+func (s *Service) LoadDraft(ctx context.Context, token string) (*Draft, error) {
+	var draft gomodel.ResponseDraft
+	if err := s.database.WithContext(ctx).
+		Where("token_hash = ? AND expires_at > ?", crypto.HashToken(token), time.Now()).
+		First(&draft).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDraftNotFound
+		}
+		return nil, err
+	}
+
+	var inputs []AnswerInput
+	if err := json.Unmarshal([]byte(draft.Answers), &inputs); err != nil {
+		return nil, err
+	}
+
+	return &Draft{
+		Token:     token,
+		FormID:    draft.FormID,
+		Answers:   inputs,
+		UpdatedAt: draft.UpdatedAt,
+		ExpiresAt: draft.ExpiresAt,
+	}, nil
+}
+
+// DeleteDraft removes the draft behind a resume token, if any.
+func DeleteDraft(tx *gorm.DB, formID, token string) error {
+	return tx.Where("token_hash = ? AND form_id = ?", crypto.HashToken(token), formID).Delete(&gomodel.ResponseDraft{}).Error
+}
+
+// PurgeExpiredDrafts removes drafts whose lifetime is over.
+func (s *Service) PurgeExpiredDrafts(ctx context.Context) (int64, error) {
+	result := s.database.WithContext(ctx).Where("expires_at <= ?", time.Now()).Delete(&gomodel.ResponseDraft{})
+	return result.RowsAffected, result.Error
+}
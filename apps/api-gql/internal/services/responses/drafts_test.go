@@ -0,0 +1,134 @@
+package responses
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/dbtest"
+	"github.com/TrySquadDF/formify/crypto"
+	"github.com/TrySquadDF/formify/lib/config"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+func draftForm() *gomodel.Form {
+	return &gomodel.Form{ID: "f1", Questions: []gomodel.Question{
+		{ID: "name", Type: gomodel.QuestionTypeShortText, Required: true},
+		{ID: "bio", Type: gomodel.QuestionTypeParagraph},
+	}}
+}
+
+func TestSaveNewDraft(t *testing.T) {
+	db, recorder := dbtest.Serve(t)
+	s := &Service{database: db, config: config.Config{DraftTTL: 72 * time.Hour}}
+	bio := "About me"
+
+	// A draft may leave required questions unanswered
+	draft, err := s.SaveDraft(context.Background(), draftForm(), []AnswerInput{{QuestionID: "bio", TextValue: &bio}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if draft.Token == "" || draft.ExpiresAt.Sub(draft.UpdatedAt) != 72*time.Hour {
+		t.Errorf("draft = %+v, want a token and the TTL", draft)
+	}
+
+	// Only the hash of the token is stored
+	inserts := recorder.Find(`INSERT INTO "response_drafts"`, "'"+crypto.HashToken(draft.Token)+"'", `"textValue":"About me"`)
+	if len(inserts) != 1 || strings.Contains(inserts[0], draft.Token) {
+		t.Errorf("statements = %q, want the draft stored under the hash of its token", recorder.Statements())
+	}
+}
+
+func TestSaveDraftByToken(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		affected int64
+		want     error
+	}{
+		{name: "live draft", affected: 1},
+		{name: "expired or unknown draft", affected: 0, want: ErrDraftNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			db, recorder := dbtest.Serve(t, dbtest.Reply{Match: `UPDATE "response_drafts"`, Affected: tt.affected})
+			s := &Service{database: db, config: config.Config{DraftTTL: time.Hour}}
+			token := "resume"
+
+			draft, err := s.SaveDraft(context.Background(), draftForm(), nil, &token)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("SaveDraft() error = %v, want %v", err, tt.want)
+			}
+			if err == nil && draft.Token != token {
+				t.Errorf("token = %q, want the same token back", draft.Token)
+			}
+
+			// The draft is replaced in place and only while it lives; its lifetime starts over
+			updates := recorder.Find(`UPDATE "response_drafts" SET`, `"expires_at"=`,
+				"token_hash = '"+crypto.HashToken(token)+"' AND form_id = 'f1' AND expires_at > ")
+			if len(updates) != 1 || len(recorder.Find("INSERT")) != 0 {
+				t.Errorf("statements = %q, want the draft updated", recorder.Statements())
+			}
+		})
+	}
+}
+
+func TestSaveDraftOfClosedForm(t *testing.T) {
+	db, recorder := dbtest.Serve(t)
+	s := &Service{database: db}
+	form := draftForm()
+	closed := time.Now()
+	form.ClosedAt = &closed
+
+	if _, err := s.SaveDraft(context.Background(), form, nil, nil); !errors.Is(err, ErrFormClosed) {
+		t.Fatalf("SaveDraft() error = %v, want ErrFormClosed", err)
+	}
+	if statements := recorder.Statements(); len(statements) != 0 {
+		t.Errorf("statements = %q, want none", statements)
+	}
+}
+
+func TestLoadDraft(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+	db, recorder := dbtest.Serve(t, dbtest.Reply{
+		Match:   `FROM "response_drafts"`,
+		Columns: []string{"id", "form_id", "answers", "expires_at"},
+		Rows:    [][]driver.Value{{"d1", "f1", `[{"questionId":"bio","textValue":"About me"}]`, expires}},
+	})
+	s := &Service{database: db}
+
+	draft, err := s.LoadDraft(context.Background(), "resume")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if draft.FormID != "f1" || draft.Token != "resume" || len(draft.Answers) != 1 ||
+		draft.Answers[0].TextValue == nil || *draft.Answers[0].TextValue != "About me" || !draft.ExpiresAt.Equal(expires) {
+		t.Errorf("draft = %+v", draft)
+	}
+	if len(recorder.Find("token_hash = '"+crypto.HashToken("resume")+"' AND expires_at > ")) != 1 {
+		t.Errorf("statements = %q, want only a live draft looked up", recorder.Statements())
+	}
+}
+
+func TestLoadMissingDraft(t *testing.T) {
+	db, _ := dbtest.Serve(t)
+	s := &Service{database: db}
+
+	if _, err := s.LoadDraft(context.Background(), "resume"); !errors.Is(err, ErrDraftNotFound) {
+		t.Errorf("LoadDraft() error = %v, want ErrDraftNotFound", err)
+	}
+}
+
+func TestPurgeExpiredDrafts(t *testing.T) {
+	db, recorder := dbtest.Serve(t, dbtest.Reply{Match: `DELETE FROM "response_drafts"`, Affected: 4})
+	s := &Service{database: db}
+
+	n, err := s.PurgeExpiredDrafts(context.Background())
+	if err != nil || n != 4 {
+		t.Fatalf("PurgeExpiredDrafts() = %d, %v, want 4", n, err)
+	}
+	if len(recorder.Find(`DELETE FROM "response_drafts" WHERE expires_at <= `)) != 1 {
+		t.Errorf("statements = %q, want expired drafts deleted", recorder.Statements())
+	}
+}
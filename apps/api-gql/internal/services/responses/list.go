@@ -0,0 +1,126 @@
+package responses
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/lib/pq"
+)
+
+// ListParams selects the responses List returns. A zero Limit returns every response.
+type ListParams struct {
+	FormID   string
+	Statuses []string
+	Tags     []string
+	Limit    int
+	Offset   int
+}
+
+// List returns responses of a form with their answers, newest first, and the number of
+// responses matching the filter regardless of Limit and Offset.
+func (s *Service) List(ctx context.Context, params ListParams) ([]gomodel.FormResponse, int64, error) {
+	query := s.database.WithContext(ctx).Model(&gomodel.FormResponse{}).Where("form_id = ?", params.FormID)
+	if len(params.Statuses) > 0 {
+		query = query.Where("status IN ?", params.Statuses)
+	}
+	if len(params.Tags) > 0 {
+		query = query.Where("tags @> ?", pq.StringArray(params.Tags))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Preload("Answers.SelectedOptions").Order("created_at DESC, id")
+	if params.Limit > 0 {
+		query = query.Limit(params.Limit).Offset(params.Offset)
+	}
+
+	var list []gomodel.FormResponse
+	if err := query.Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+// WriteCSV writes responses as a spreadsheet with a column per question, titled with the
+// question text, so the file can be imported back with importResponses. The form must
+// have its Questions.Options loaded.
+func WriteCSV(w io.Writer, form *gomodel.Form, list []gomodel.FormResponse) error {
+	questions := make([]gomodel.Question, len(form.Questions))
+	copy(questions, form.Questions)
+	sort.SliceStable(questions, func(i, j int) bool { return questions[i].Order < questions[j].Order })
+
+	header := []string{"Response ID", "Timestamp", "Status"}
+	for _, q := range questions {
+		header = append(header, q.Text)
+	}
+
+	out := csv.NewWriter(w)
+	if err := out.Write(header); err != nil {
+		return err
+	}
+
+	for _, response := range list {
+		answers := make(map[string]*gomodel.Answer, len(response.Answers))
+		for i := range response.Answers {
+			answers[response.Answers[i].QuestionID] = &response.Answers[i]
+		}
+
+		row := []string{response.ID, response.CreatedAt.Format(time.RFC3339), response.Status}
+		for i := range questions {
+			row = append(row, csvValue(&questions[i], answers[questions[i].ID]))
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+func csvValue(question *gomodel.Question, answer *gomodel.Answer) string {
+	if answer == nil {
+		return ""
+	}
+
+	switch question.Type {
+	case gomodel.QuestionTypeBoolean:
+		if answer.BoolValue == nil {
+			return ""
+		}
+		return strconv.FormatBool(*answer.BoolValue)
+	case gomodel.QuestionTypeNumber:
+		if answer.NumberValue == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*answer.NumberValue, 'f', -1, 64)
+	case gomodel.QuestionTypeDate:
+		if answer.DateValue == nil {
+			return ""
+		}
+		return answer.DateValue.Format(time.RFC3339)
+	case gomodel.QuestionTypeSingleChoice, gomodel.QuestionTypeMultipleChoice:
+		// Options in the order the form lists them
+		selected := make(map[string]bool, len(answer.SelectedOptions))
+		for _, o := range answer.SelectedOptions {
+			selected[o.ID] = true
+		}
+		texts := []string{}
+		for _, o := range question.Options {
+			if o != nil && selected[o.ID] {
+				texts = append(texts, o.Text)
+			}
+		}
+		return strings.Join(texts, ", ")
+	}
+	return answer.TextValue
+}
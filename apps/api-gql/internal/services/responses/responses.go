@@ -0,0 +1,155 @@
+package responses
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/notifications"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/pubsub"
+	"github.com/TrySquadDF/formify/lib/config"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// purgeInterval is how often the trash and drafts are checked for expired entries
+const purgeInterval = time.Hour
+
+type Opts struct {
+	fx.In
+	LC fx.Lifecycle
+
+	Database      *gorm.DB
+	Config        config.Config
+	PubSub        *pubsub.Service
+	Notifications *notifications.Service
+}
+
+type Service struct {
+	database      *gorm.DB
+	config        config.Config
+	pubsub        *pubsub.Service
+	notifications *notifications.Service
+}
+
+func New(opts Opts) *Service {
+	s := &Service{
+		database:      opts.Database,
+		config:        opts.Config,
+		pubsub:        opts.PubSub,
+		notifications: opts.Notifications,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	opts.LC.Append(
+		fx.Hook{
+			OnStart: func(_ context.Context) error {
+				go s.runPurge(ctx)
+				return nil
+			},
+			OnStop: func(_ context.Context) error {
+				cancel()
+				return nil
+			},
+		},
+	)
+
+	return s
+}
+
+// Delete moves responses to the trash.
+func (s *Service) Delete(ctx context.Context, ids []string) (int64, error) {
+	result := s.database.WithContext(ctx).Where("id IN ?", ids).Delete(&gomodel.FormResponse{})
+	return result.RowsAffected, result.Error
+}
+
+// Restore takes responses back out of the trash.
+func (s *Service) Restore(ctx context.Context, ids []string) (int64, error) {
+	result := s.database.WithContext(ctx).Unscoped().
+		Model(&gomodel.FormResponse{}).
+		Where("id IN ? AND deleted_at IS NOT NULL", ids).
+		Update("deleted_at", nil)
+	return result.RowsAffected, result.Error
+}
+
+// Trashed returns the deleted responses of a form, most recently deleted first.
+func (s *Service) Trashed(ctx context.Context, formID string) ([]gomodel.FormResponse, error) {
+	var responses []gomodel.FormResponse
+	err := s.database.WithContext(ctx).Unscoped().
+		Preload("Answers").
+		Preload("Answers.Question").
+		Preload("Answers.SelectedOptions").
+		Where("form_id = ? AND deleted_at IS NOT NULL", formID).
+		Order("deleted_at DESC").
+		Find(&responses).Error
+	return responses, err
+}
+
+// PurgeExpired permanently removes responses that stayed in the trash longer than the purge delay.
+func (s *Service) PurgeExpired(ctx context.Context) (int64, error) {
+	var purged int64
+	err := s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		n, err := purgeWhere(tx, "deleted_at IS NOT NULL AND deleted_at < ?", time.Now().Add(-s.config.ResponsePurgeDelay))
+		purged = n
+		return err
+	})
+	return purged, err
+}
+
+// PurgeResponses permanently removes responses together with their answers, selected
+// options, revisions and review notes and activity.
+func PurgeResponses(tx *gorm.DB, ids []string) error {
+	_, err := purgeWhere(tx, "id IN ?", ids)
+	return err
+}
+
+// PurgeFormResponses permanently removes every response of the form, trashed or not.
+func PurgeFormResponses(tx *gorm.DB, formID string) error {
+	_, err := purgeWhere(tx, "form_id = ?", formID)
+	return err
+}
+
+func purgeWhere(tx *gorm.DB, cond string, args ...interface{}) (int64, error) {
+	responses := "SELECT id FROM form_responses WHERE " + cond
+
+	if err := tx.Exec("DELETE FROM answer_options WHERE answer_id IN (SELECT id FROM answers WHERE response_id IN ("+responses+"))", args...).Error; err != nil {
+		return 0, err
+	}
+
+	for _, table := range []string{"answers", "response_revisions", "response_notes", "response_activities"} {
+		if err := tx.Exec("DELETE FROM "+table+" WHERE response_id IN ("+responses+")", args...).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	result := tx.Exec("DELETE FROM form_responses WHERE "+cond, args...)
+	return result.RowsAffected, result.Error
+}
+
+func (s *Service) runPurge(ctx context.Context) {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := s.PurgeExpired(ctx)
+		if err != nil {
+			log.Printf("Error purging trashed responses: %v", err)
+		} else if n > 0 {
+			log.Printf("Purged %d trashed responses", n)
+		}
+
+		n, err = s.PurgeExpiredDrafts(ctx)
+		if err != nil {
+			log.Printf("Error purging expired drafts: %v", err)
+		} else if n > 0 {
+			log.Printf("Purged %d expired drafts", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
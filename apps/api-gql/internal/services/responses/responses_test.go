@@ -0,0 +1,85 @@
+package responses
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/dbtest"
+	"github.com/TrySquadDF/formify/lib/config"
+)
+
+func TestDeleteMovesToTrash(t *testing.T) {
+	db, recorder := dbtest.Open(t)
+	s := &Service{database: db}
+
+	if _, err := s.Delete(context.Background(), []string{"r1", "r2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	statements := recorder.Statements()
+	if len(statements) != 1 || !strings.HasPrefix(statements[0], `UPDATE "form_responses" SET "deleted_at"=`) ||
+		!strings.Contains(statements[0], `id IN ('r1','r2') AND "form_responses"."deleted_at" IS NULL`) {
+		t.Errorf("statements = %q, want a soft delete of live responses", statements)
+	}
+}
+
+func TestRestoreTakesOnlyTrashedResponses(t *testing.T) {
+	db, recorder := dbtest.Open(t)
+	s := &Service{database: db}
+
+	if _, err := s.Restore(context.Background(), []string{"r1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	statements := recorder.Statements()
+	if len(statements) != 1 || !strings.Contains(statements[0], `SET "deleted_at"=NULL`) ||
+		!strings.Contains(statements[0], `id IN ('r1') AND deleted_at IS NOT NULL`) {
+		t.Errorf("statements = %q, want trashed responses restored", statements)
+	}
+}
+
+func TestPurgeExpired(t *testing.T) {
+	db, recorder := dbtest.Open(t)
+	s := &Service{database: db, config: config.Config{ResponsePurgeDelay: 720 * time.Hour}}
+
+	before := time.Now().Add(-720 * time.Hour)
+	if _, err := s.PurgeExpired(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	statements := recorder.Statements()
+	// Dependent rows go first, so no answer, revision or review entry outlives its response
+	wantTables := []string{"answer_options", "answers", "response_revisions", "response_notes", "response_activities", "form_responses"}
+	if len(statements) != len(wantTables) {
+		t.Fatalf("statements = %q, want %d", statements, len(wantTables))
+	}
+	for i, table := range wantTables {
+		if !strings.HasPrefix(statements[i], "DELETE FROM "+table+" WHERE") {
+			t.Errorf("statement %d = %q, want a delete from %s", i, statements[i], table)
+		}
+		_, cutoff, ok := strings.Cut(statements[i], "deleted_at IS NOT NULL AND deleted_at < '")
+		if !ok {
+			t.Errorf("statement %d = %q, want only trashed responses", i, statements[i])
+			continue
+		}
+		at, err := time.ParseInLocation("2006-01-02 15:04:05.999", cutoff[:strings.Index(cutoff, "'")], time.Local)
+		if err != nil || at.Sub(before).Abs() > time.Minute {
+			t.Errorf("statement %d purges responses trashed before %q, want %v", i, cutoff, before)
+		}
+	}
+}
+
+func TestPurgeFormResponsesTakesLiveResponsesToo(t *testing.T) {
+	db, recorder := dbtest.Open(t)
+
+	if err := PurgeFormResponses(db, "f1"); err != nil {
+		t.Fatal(err)
+	}
+	for _, statement := range recorder.Statements() {
+		if !strings.Contains(statement, "form_id = 'f1'") || strings.Contains(statement, "deleted_at") {
+			t.Errorf("statement = %q, want every response of the form", statement)
+		}
+	}
+}
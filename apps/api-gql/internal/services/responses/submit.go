@@ -0,0 +1,441 @@
+package responses
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/notifications"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/webhooks"
+	"github.com/TrySquadDF/formify/crypto"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// editTokenBytes is the amount of randomness in a respondent edit token
+const editTokenBytes = 32
+
+// maxClockSkew is how far in the future a client submittedAt timestamp may lie
+const maxClockSkew = 5 * time.Minute
+
+var (
+	ErrInvalidEditToken   = errors.New("invalid edit token")
+	ErrEditingDisabled    = errors.New("editing responses is not allowed for this form")
+	ErrEditDeadlinePassed = errors.New("the deadline for editing this response has passed")
+	ErrSubmittedInFuture  = errors.New("submittedAt lies in the future")
+	ErrDuplicateDeleted   = errors.New("a response with this idempotency key was already submitted and deleted")
+	ErrFormClosed         = errors.New("form is closed and no longer accepts responses")
+
+	// errDuplicate rolls back a submission that lost an idempotency race
+	errDuplicate = errors.New("duplicate idempotency key")
+)
+
+// AnswerInput is a single answer as sent by a respondent.
+type AnswerInput struct {
+	QuestionID  string   `json:"questionId"`
+	TextValue   *string  `json:"textValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	NumberValue *float64 `json:"numberValue,omitempty"`
+	DateValue   *string  `json:"dateValue,omitempty"`
+	OptionIDs   []string `json:"optionIds,omitempty"`
+}
+
+type SubmitParams struct {
+	// Form must have Questions.Options preloaded
+	Form    *gomodel.Form
+	Answers []AnswerInput
+	// DraftToken, when set, names the draft that is discarded once the response is stored
+	DraftToken *string
+	// IdempotencyKey makes retries of the same submission return the stored response. It
+	// only matches earlier submissions of the same submitter.
+	IdempotencyKey *string
+	// SubmitterID is the signed-in user or API key owner; empty for anonymous respondents,
+	// whose idempotency keys are shared by the form, so a retry from another network still
+	// matches. Anonymous clients should send random keys
+	SubmitterID string
+	// SubmittedAt is the time the response was filled in on the client, if it was sent later
+	SubmittedAt *time.Time
+	// RemoteIP is the address the response came from; it limits the copies of answers
+	// emailed to respondents
+	RemoteIP string
+}
+
+type Submission struct {
+	Response *gomodel.FormResponse
+	// EditToken is only set when the form allows editing; it is never stored in clear
+	EditToken string
+	// Duplicate reports that the idempotency key matched an earlier submission. Response
+	// then only carries the ID: the answers are not handed out again.
+	Duplicate bool
+}
+
+// ValidateAnswers checks answers against the questions of the form and converts them to models.
+// With partial set, unanswered required questions are allowed.
+func ValidateAnswers(form *gomodel.Form, responseID string, inputs []AnswerInput, partial bool) ([]gomodel.Answer, error) {
+	questions := make(map[string]*gomodel.Question, len(form.Questions))
+	for i := range form.Questions {
+		questions[form.Questions[i].ID] = &form.Questions[i]
+	}
+
+	answers := make([]gomodel.Answer, 0, len(inputs))
+	answered := make(map[string]bool, len(inputs))
+
+	for _, in := range inputs {
+		question, ok := questions[in.QuestionID]
+		if !ok {
+			return nil, fmt.Errorf("question not found: %s", in.QuestionID)
+		}
+		if _, seen := answered[question.ID]; seen {
+			return nil, fmt.Errorf("question answered more than once: %s", question.ID)
+		}
+
+		answer := gomodel.Answer{
+			ID:              uuid.New().String(),
+			ResponseID:      responseID,
+			QuestionID:      question.ID,
+			Question:        *question,
+			SelectedOptions: make([]gomodel.Option, 0),
+		}
+
+		hasValue := false
+		switch question.Type {
+		case gomodel.QuestionTypeBoolean:
+			if in.BoolValue != nil {
+				boolCopy := *in.BoolValue
+				answer.BoolValue = &boolCopy
+				hasValue = true
+			}
+		case gomodel.QuestionTypeNumber:
+			if in.NumberValue != nil {
+				numCopy := *in.NumberValue
+				answer.NumberValue = &numCopy
+				hasValue = true
+			}
+		case gomodel.QuestionTypeDate:
+			if in.DateValue != nil {
+				parsedTime, err := time.Parse(time.RFC3339, *in.DateValue)
+				if err != nil {
+					return nil, errors.New("invalid date format: " + *in.DateValue)
+				}
+				answer.DateValue = &parsedTime
+				hasValue = true
+			}
+		case gomodel.QuestionTypeSingleChoice, gomodel.QuestionTypeMultipleChoice:
+			if question.Type == gomodel.QuestionTypeSingleChoice && len(in.OptionIDs) > 1 {
+				return nil, fmt.Errorf("only one option may be selected for question: %s", question.ID)
+			}
+			for _, optionID := range in.OptionIDs {
+				option := findOption(question, optionID)
+				if option == nil {
+					return nil, fmt.Errorf("option not found: %s", optionID)
+				}
+				answer.SelectedOptions = append(answer.SelectedOptions, *option)
+			}
+			hasValue = len(answer.SelectedOptions) > 0
+		default:
+			if in.TextValue != nil {
+				answer.TextValue = *in.TextValue
+				hasValue = strings.TrimSpace(answer.TextValue) != ""
+			}
+		}
+
+		answered[question.ID] = hasValue
+		answers = append(answers, answer)
+	}
+
+	if !partial {
+		for _, q := range form.Questions {
+			if q.Required && !answered[q.ID] {
+				return nil, fmt.Errorf("answer required for question: %s", q.ID)
+			}
+		}
+	}
+
+	return answers, nil
+}
+
+// Submit stores a new response to the form. When the submitter already used the
+// idempotency key for the form, the ID of the earlier response is returned instead.
+func (s *Service) Submit(ctx context.Context, params SubmitParams) (*Submission, error) {
+	var scope *string
+	if params.IdempotencyKey != nil {
+		scope = idempotencyScope(params)
+		if existing, err := s.findByIdempotencyKey(ctx, params.Form.ID, *scope, *params.IdempotencyKey); err != nil || existing != nil {
+			return existing, err
+		}
+	}
+
+	if params.Form.ClosedAt != nil {
+		return nil, ErrFormClosed
+	}
+
+	responseID := uuid.New().String()
+
+	answers, err := ValidateAnswers(params.Form, responseID, params.Answers, false)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt := time.Now()
+	if params.SubmittedAt != nil {
+		if params.SubmittedAt.After(createdAt.Add(maxClockSkew)) {
+			return nil, ErrSubmittedInFuture
+		}
+		createdAt = *params.SubmittedAt
+	}
+
+	submission := &Submission{}
+	formResponse := gomodel.FormResponse{
+		ID:               responseID,
+		FormID:           params.Form.ID,
+		CreatedAt:        createdAt,
+		IdempotencyScope: scope,
+		IdempotencyKey:   params.IdempotencyKey,
+	}
+
+	if params.Form.AllowResponseEditing {
+		token, err := crypto.NewToken(editTokenBytes)
+		if err != nil {
+			return nil, err
+		}
+		hash := crypto.HashToken(token)
+		formResponse.EditTokenHash = &hash
+		submission.EditToken = token
+	}
+
+	confirm := notifications.RespondentEmail(answers) != "" &&
+		s.notifications.AllowConfirmation(ctx, params.Form.ID, params.RemoteIP)
+
+	err = s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "form_id"}, {Name: "idempotency_scope"}, {Name: "idempotency_key"}},
+			DoNothing: true,
+		}).Create(&formResponse)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errDuplicate
+		}
+		if err := saveAnswers(tx, answers); err != nil {
+			return err
+		}
+		if err := webhooks.Enqueue(tx, params.Form.ID, gomodel.WebhookEventResponseCreated, webhooks.NewResponseData(&formResponse, answers)); err != nil {
+			return err
+		}
+		if err := notifications.ResponseSubmitted(tx, s.config, params.Form, &formResponse, answers, confirm); err != nil {
+			return err
+		}
+		if params.DraftToken != nil {
+			return DeleteDraft(tx, params.Form.ID, *params.DraftToken)
+		}
+		return nil
+	})
+	if errors.Is(err, errDuplicate) && params.IdempotencyKey != nil {
+		// A concurrent retry stored the response first
+		return s.findByIdempotencyKey(ctx, params.Form.ID, *scope, *params.IdempotencyKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	submission.Response, err = s.Find(ctx, responseID)
+	if err != nil {
+		return nil, err
+	}
+	s.pubsub.PublishResponseSubmitted(ctx, params.Form.ID, responseID)
+
+	return submission, nil
+}
+
+// idempotencyScope hashes who the submission comes from. Anonymous submissions share
+// one scope: field clients retry from changing addresses, so the address cannot tell
+// the same submitter apart.
+func idempotencyScope(params SubmitParams) *string {
+	submitter := "anonymous"
+	if params.SubmitterID != "" {
+		submitter = "user:" + params.SubmitterID
+	}
+	scope := crypto.HashToken(submitter)
+	return &scope
+}
+
+// findByIdempotencyKey returns the ID of the earlier submission made by the submitter
+// with the key, or nil if there is none.
+func (s *Service) findByIdempotencyKey(ctx context.Context, formID, scope, key string) (*Submission, error) {
+	var response gomodel.FormResponse
+	if err := s.database.WithContext(ctx).Unscoped().
+		Select("id", "deleted_at").
+		First(&response, "form_id = ? AND idempotency_scope = ? AND idempotency_key = ?", formID, scope, key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if response.DeletedAt.Valid {
+		return nil, ErrDuplicateDeleted
+	}
+
+	return &Submission{
+		Response:  &gomodel.FormResponse{ID: response.ID, FormID: formID},
+		Duplicate: true,
+	}, nil
+}
+
+// Find returns a response with its answers, questions and selected options.
+func (s *Service) Find(ctx context.Context, id string) (*gomodel.FormResponse, error) {
+	var response gomodel.FormResponse
+	if err := s.database.WithContext(ctx).
+		Preload("Answers").
+		Preload("Answers.Question").
+		Preload("Answers.SelectedOptions").
+		First(&response, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// FindByEditToken returns the response a respondent edit token was issued for.
+func (s *Service) FindByEditToken(ctx context.Context, token string) (*gomodel.FormResponse, error) {
+	var response gomodel.FormResponse
+	if err := s.database.WithContext(ctx).
+		Select("id").
+		First(&response, "edit_token_hash = ?", crypto.HashToken(token)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidEditToken
+		}
+		return nil, err
+	}
+	return s.Find(ctx, response.ID)
+}
+
+// UpdateByEditToken replaces the answers of a response with the same validation as
+// Submit. The previous answers are kept as a revision.
+func (s *Service) UpdateByEditToken(ctx context.Context, token string, inputs []AnswerInput) (*gomodel.FormResponse, error) {
+	current, err := s.FindByEditToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	var form gomodel.Form
+	if err := s.database.WithContext(ctx).Preload("Questions.Options").First(&form, "id = ?", current.FormID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("form not found")
+		}
+		return nil, err
+	}
+
+	if !form.AllowResponseEditing {
+		return nil, ErrEditingDisabled
+	}
+	if form.ResponseEditDeadline != nil && time.Now().After(*form.ResponseEditDeadline) {
+		return nil, ErrEditDeadlinePassed
+	}
+
+	answers, err := ValidateAnswers(&form, current.ID, inputs, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The lock serializes edits of the response, so each one snapshots the answers
+		// it replaces and gets its own revision number
+		var locked gomodel.FormResponse
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("Answers").
+			Preload("Answers.Question").
+			Preload("Answers.SelectedOptions").
+			First(&locked, "id = ?", current.ID).Error; err != nil {
+			return err
+		}
+		snapshot, err := json.Marshal(locked.Answers)
+		if err != nil {
+			return err
+		}
+
+		var revisions int64
+		if err := tx.Model(&gomodel.ResponseRevision{}).Where("response_id = ?", current.ID).Count(&revisions).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&gomodel.ResponseRevision{
+			ResponseID: current.ID,
+			Revision:   int32(revisions) + 1,
+			Answers:    string(snapshot),
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := deleteAnswers(tx, current.ID); err != nil {
+			return err
+		}
+		if err := saveAnswers(tx, answers); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := tx.Model(&gomodel.FormResponse{}).Where("id = ?", current.ID).Update("updated_at", now).Error; err != nil {
+			return err
+		}
+
+		current.UpdatedAt = &now
+		return webhooks.Enqueue(tx, form.ID, gomodel.WebhookEventResponseUpdated, webhooks.NewResponseData(current, answers))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Find(ctx, current.ID)
+}
+
+// Revisions returns earlier answer sets of a response, oldest first.
+func (s *Service) Revisions(ctx context.Context, responseID string) ([]gomodel.ResponseRevision, error) {
+	var revisions []gomodel.ResponseRevision
+	err := s.database.WithContext(ctx).
+		Where("response_id = ?", responseID).
+		Order("revision").
+		Find(&revisions).Error
+	return revisions, err
+}
+
+// saveAnswers inserts answers and the options selected in them.
+func saveAnswers(tx *gorm.DB, answers []gomodel.Answer) error {
+	for i := range answers {
+		if err := tx.Omit(clause.Associations).Create(&answers[i]).Error; err != nil {
+			return err
+		}
+
+		for _, opt := range answers[i].SelectedOptions {
+			if err := tx.Create(&gomodel.AnswerOption{
+				ID:       uuid.New().String(),
+				AnswerID: answers[i].ID,
+				OptionID: opt.ID,
+			}).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func deleteAnswers(tx *gorm.DB, responseID string) error {
+	if err := tx.Exec("DELETE FROM answer_options WHERE answer_id IN (SELECT id FROM answers WHERE response_id = ?)", responseID).Error; err != nil {
+		return err
+	}
+	return tx.Exec("DELETE FROM answers WHERE response_id = ?", responseID).Error
+}
+
+func findOption(q *gomodel.Question, id string) *gomodel.Option {
+	for _, o := range q.Options {
+		if o.ID == id {
+			return o
+		}
+	}
+	return nil
+}
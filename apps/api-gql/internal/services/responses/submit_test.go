@@ -0,0 +1,160 @@
+package responses
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/dbtest"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+func TestIdempotencyScope(t *testing.T) {
+	ann := *idempotencyScope(SubmitParams{SubmitterID: "ann", RemoteIP: "203.0.113.7"})
+	if again := *idempotencyScope(SubmitParams{SubmitterID: "ann", RemoteIP: "198.51.100.1"}); again != ann {
+		t.Error("a signed-in submitter got another scope from another address")
+	}
+
+	anonymous := *idempotencyScope(SubmitParams{RemoteIP: "203.0.113.7"})
+	if retry := *idempotencyScope(SubmitParams{RemoteIP: "198.51.100.1"}); retry != anonymous {
+		t.Error("an anonymous retry from another address got another scope")
+	}
+
+	submitters := map[string]SubmitParams{
+		"ann":            {SubmitterID: "ann", RemoteIP: "203.0.113.7"},
+		"bob":            {SubmitterID: "bob", RemoteIP: "203.0.113.7"},
+		"anonymous":      {RemoteIP: "203.0.113.7"},
+		"anonymous-like": {SubmitterID: "anonymous"},
+	}
+	seen := make(map[string]string)
+	for name, params := range submitters {
+		scope := *idempotencyScope(params)
+		if other, ok := seen[scope]; ok {
+			t.Errorf("%s and %s share a scope", name, other)
+		}
+		seen[scope] = name
+	}
+}
+
+func TestValidateAnswers(t *testing.T) {
+	form := &gomodel.Form{Questions: []gomodel.Question{
+		{ID: "name", Type: gomodel.QuestionTypeShortText, Required: true},
+		{ID: "color", Type: gomodel.QuestionTypeSingleChoice, Options: []*gomodel.Option{{ID: "red"}, {ID: "blue"}}},
+	}}
+	text := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		inputs  []AnswerInput
+		partial bool
+		wantErr string
+	}{
+		{name: "complete", inputs: []AnswerInput{{QuestionID: "name", TextValue: text("Ann")}, {QuestionID: "color", OptionIDs: []string{"red"}}}},
+		{name: "required missing", inputs: []AnswerInput{{QuestionID: "color", OptionIDs: []string{"red"}}}, wantErr: "answer required"},
+		{name: "required blank", inputs: []AnswerInput{{QuestionID: "name", TextValue: text("  ")}}, wantErr: "answer required"},
+		{name: "partial", inputs: []AnswerInput{{QuestionID: "color", OptionIDs: []string{"blue"}}}, partial: true},
+		{name: "unknown question", inputs: []AnswerInput{{QuestionID: "age"}}, wantErr: "question not found"},
+		{name: "answered twice", inputs: []AnswerInput{{QuestionID: "name", TextValue: text("a")}, {QuestionID: "name", TextValue: text("b")}}, wantErr: "more than once"},
+		{name: "two options for one choice", inputs: []AnswerInput{{QuestionID: "name", TextValue: text("Ann")}, {QuestionID: "color", OptionIDs: []string{"red", "blue"}}}, wantErr: "only one option"},
+		{name: "foreign option", inputs: []AnswerInput{{QuestionID: "name", TextValue: text("Ann")}, {QuestionID: "color", OptionIDs: []string{"green"}}}, wantErr: "option not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answers, err := ValidateAnswers(form, "response", tt.inputs, tt.partial)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ValidateAnswers() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateAnswers() error = %v", err)
+			}
+			for _, a := range answers {
+				if a.ResponseID != "response" || a.ID == "" {
+					t.Errorf("answer %+v is not tied to the response", a)
+				}
+			}
+		})
+	}
+}
+
+// editReplies answer for response r1 of form f1, which has one required text question
+// answered "Old" and two earlier revisions.
+func editReplies(editing bool, deadline interface{}) []dbtest.Reply {
+	return []dbtest.Reply{
+		{Match: "edit_token_hash", Columns: []string{"id"}, Rows: [][]driver.Value{{"r1"}}},
+		{Match: `FROM "form_responses"`, Columns: []string{"id", "form_id"}, Rows: [][]driver.Value{{"r1", "f1"}}},
+		{Match: `FROM "answers"`, Columns: []string{"id", "response_id", "question_id", "text_value"}, Rows: [][]driver.Value{{"a1", "r1", "q1", "Old"}}},
+		{Match: `FROM "forms"`, Columns: []string{"id", "allowResponseEditing", "responseEditDeadline"}, Rows: [][]driver.Value{{"f1", editing, deadline}}},
+		{Match: `FROM "questions"`, Columns: []string{"id", "form_id", "type", "required"}, Rows: [][]driver.Value{{"q1", "f1", string(gomodel.QuestionTypeShortText), true}}},
+		{Match: `FROM "response_revisions"`, Columns: []string{"count"}, Rows: [][]driver.Value{{int64(2)}}},
+	}
+}
+
+func TestUpdateByEditTokenKeepsRevision(t *testing.T) {
+	db, recorder := dbtest.Serve(t, editReplies(true, time.Now().Add(time.Hour))...)
+	s := &Service{database: db}
+	text := "New"
+
+	if _, err := s.UpdateByEditToken(context.Background(), "token", []AnswerInput{{QuestionID: "q1", TextValue: &text}}); err != nil {
+		t.Fatal(err)
+	}
+
+	statements := recorder.Statements()
+	index := func(parts ...string) int {
+		for i, statement := range statements {
+			matches := true
+			for _, part := range parts {
+				matches = matches && strings.Contains(statement, part)
+			}
+			if matches {
+				return i
+			}
+		}
+		t.Errorf("no statement with %q in %q", parts, statements)
+		return -1
+	}
+
+	// The old answers are snapshotted as the next revision before they are replaced
+	revision := index(`INSERT INTO "response_revisions"`, `'r1',3,`, `"textValue":"Old"`)
+	deleted := index("DELETE FROM answers WHERE response_id = 'r1'")
+	inserted := index(`INSERT INTO "answers"`, "'New'")
+	if revision > deleted || deleted > inserted {
+		t.Errorf("statements = %q, want the revision saved, then the answers replaced", statements)
+	}
+	index(`UPDATE "form_responses" SET "updated_at"=`)
+	if len(recorder.Find("FOR UPDATE")) != 1 {
+		t.Errorf("statements = %q, want the response locked while it is edited", statements)
+	}
+}
+
+func TestUpdateByEditTokenRefused(t *testing.T) {
+	text := "New"
+	tests := []struct {
+		name    string
+		replies []dbtest.Reply
+		want    error
+	}{
+		{name: "unknown token", want: ErrInvalidEditToken},
+		{name: "editing disabled", replies: editReplies(false, nil), want: ErrEditingDisabled},
+		{name: "deadline passed", replies: editReplies(true, time.Now().Add(-time.Minute)), want: ErrEditDeadlinePassed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, recorder := dbtest.Serve(t, tt.replies...)
+			s := &Service{database: db}
+
+			_, err := s.UpdateByEditToken(context.Background(), "token", []AnswerInput{{QuestionID: "q1", TextValue: &text}})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("UpdateByEditToken() error = %v, want %v", err, tt.want)
+			}
+			if changes := recorder.Find("INSERT"); len(changes) != 0 {
+				t.Errorf("statements = %q, want nothing written", changes)
+			}
+		})
+	}
+}
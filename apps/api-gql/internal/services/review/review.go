@@ -0,0 +1,226 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/labels"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnknownStatus    = errors.New("unknown response status")
+	ErrAssigneeNotFound = errors.New("assignee not found")
+	ErrAssigneeNoAccess = errors.New("assignee cannot view the responses of the form")
+	ErrNoteNotFound     = errors.New("note not found")
+	ErrEmptyNote        = errors.New("note must not be empty")
+)
+
+type Opts struct {
+	fx.In
+
+	Database *gorm.DB
+	Access   *access.Service
+}
+
+type Service struct {
+	database *gorm.DB
+	access   *access.Service
+}
+
+func New(opts Opts) *Service {
+	return &Service{
+		database: opts.Database,
+		access:   opts.Access,
+	}
+}
+
+// SetStatus changes the review status of a response. The status must be one of the form statuses.
+func (s *Service) SetStatus(ctx context.Context, actorID string, form *gomodel.Form, response *gomodel.FormResponse, status string) error {
+	status = strings.TrimSpace(status)
+
+	allowed := false
+	for _, st := range form.Statuses() {
+		if st == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return ErrUnknownStatus
+	}
+
+	if response.Status == status {
+		return nil
+	}
+
+	old := response.Status
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(response).Update("status", status).Error; err != nil {
+			return err
+		}
+		return logActivity(tx, response.ID, actorID, gomodel.ResponseActivityStatusChanged, &old, &status)
+	})
+}
+
+// Assign sets the user responsible for a response; nil removes the assignee. Responses
+// can only be assigned to someone who is able to see them.
+func (s *Service) Assign(ctx context.Context, actorID string, response *gomodel.FormResponse, assigneeID *string) error {
+	if assigneeID != nil {
+		var count int64
+		if err := s.database.WithContext(ctx).Model(&gomodel.Users{}).Where("id = ?", *assigneeID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrAssigneeNotFound
+		}
+
+		role, err := s.access.Role(ctx, response.FormID, *assigneeID)
+		if err != nil {
+			return err
+		}
+		if !access.Allows(role, access.ViewResponses) {
+			return ErrAssigneeNoAccess
+		}
+	}
+
+	if equalIDs(response.AssigneeID, assigneeID) {
+		return nil
+	}
+
+	old := response.AssigneeID
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(response).Update("assignee_id", assigneeID).Error; err != nil {
+			return err
+		}
+		return logActivity(tx, response.ID, actorID, gomodel.ResponseActivityAssigneeChanged, old, assigneeID)
+	})
+}
+
+// SetTags replaces the tags of a response. Tags are trimmed and deduplicated.
+func (s *Service) SetTags(ctx context.Context, actorID string, response *gomodel.FormResponse, tags []string) error {
+	normalized := labels.Normalize(tags)
+
+	oldValue := strings.Join(response.Tags, ",")
+	newValue := strings.Join(normalized, ",")
+	if oldValue == newValue {
+		return nil
+	}
+
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(response).Update("tags", normalized).Error; err != nil {
+			return err
+		}
+		return logActivity(tx, response.ID, actorID, gomodel.ResponseActivityTagsChanged, &oldValue, &newValue)
+	})
+}
+
+// AddNote attaches an internal note to a response, optionally as a reply to another note.
+func (s *Service) AddNote(ctx context.Context, actorID string, response *gomodel.FormResponse, parentID *string, body string) (*gomodel.ResponseNote, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, ErrEmptyNote
+	}
+
+	note := &gomodel.ResponseNote{
+		ResponseID: response.ID,
+		ParentID:   parentID,
+		AuthorID:   actorID,
+		Body:       body,
+	}
+
+	err := s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if parentID != nil {
+			var count int64
+			if err := tx.Model(&gomodel.ResponseNote{}).
+				Where("id = ? AND response_id = ?", *parentID, response.ID).
+				Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return ErrNoteNotFound
+			}
+		}
+
+		if err := tx.Create(note).Error; err != nil {
+			return err
+		}
+		return logActivity(tx, response.ID, actorID, gomodel.ResponseActivityNoteAdded, nil, &note.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.FindNote(ctx, note.ID)
+}
+
+// FindNote returns a note with its author.
+func (s *Service) FindNote(ctx context.Context, id string) (*gomodel.ResponseNote, error) {
+	var note gomodel.ResponseNote
+	if err := s.database.WithContext(ctx).Preload("Author").First(&note, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoteNotFound
+		}
+		return nil, err
+	}
+	return &note, nil
+}
+
+// DeleteNote removes a note together with its replies.
+func (s *Service) DeleteNote(ctx context.Context, actorID string, note *gomodel.ResponseNote) error {
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+WITH RECURSIVE thread AS (
+	SELECT id FROM response_notes WHERE id = ?
+	UNION ALL
+	SELECT n.id FROM response_notes n JOIN thread t ON n.parent_id = t.id
+)
+DELETE FROM response_notes WHERE id IN (SELECT id FROM thread)`, note.ID).Error; err != nil {
+			return err
+		}
+		return logActivity(tx, note.ResponseID, actorID, gomodel.ResponseActivityNoteDeleted, &note.ID, nil)
+	})
+}
+
+// Notes returns the notes of a response in the order they were written.
+func (s *Service) Notes(ctx context.Context, responseID string) ([]gomodel.ResponseNote, error) {
+	var notes []gomodel.ResponseNote
+	err := s.database.WithContext(ctx).
+		Preload("Author").
+		Where("response_id = ?", responseID).
+		Order("created_at").
+		Find(&notes).Error
+	return notes, err
+}
+
+// Activity returns the change log of a response, newest first.
+func (s *Service) Activity(ctx context.Context, responseID string) ([]gomodel.ResponseActivity, error) {
+	var activity []gomodel.ResponseActivity
+	err := s.database.WithContext(ctx).
+		Preload("Actor").
+		Where("response_id = ?", responseID).
+		Order("created_at DESC").
+		Find(&activity).Error
+	return activity, err
+}
+
+func logActivity(tx *gorm.DB, responseID, actorID string, action gomodel.ResponseActivityAction, oldValue, newValue *string) error {
+	return tx.Create(&gomodel.ResponseActivity{
+		ResponseID: responseID,
+		ActorID:    actorID,
+		Action:     action,
+		OldValue:   oldValue,
+		NewValue:   newValue,
+	}).Error
+}
+
+func equalIDs(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
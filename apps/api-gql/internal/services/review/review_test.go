@@ -0,0 +1,84 @@
+package review
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/dbtest"
+	"github.com/TrySquadDF/formify/api-gql/internal/services/access"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+// assignReplies answer the checks of Assign: u2 exists and, unless role is empty, is a
+// member of the personal form f1 of u1.
+func assignReplies(role gomodel.FormRole) []dbtest.Reply {
+	replies := []dbtest.Reply{
+		{Match: `FROM "users"`, Columns: []string{"count"}, Rows: [][]driver.Value{{int64(1)}}},
+		{Match: `FROM "forms"`, Columns: []string{"id", "owner_id", "workspaceId"}, Rows: [][]driver.Value{{"f1", "u1", nil}}},
+	}
+	if role != "" {
+		replies = append(replies, dbtest.Reply{
+			Match:   `FROM "form_members"`,
+			Columns: []string{"form_id", "user_id", "role"},
+			Rows:    [][]driver.Value{{"f1", "u2", string(role)}},
+		})
+	}
+	return replies
+}
+
+func TestAssign(t *testing.T) {
+	u2 := "u2"
+
+	tests := []struct {
+		name    string
+		replies []dbtest.Reply
+		current *string
+		want    error
+		changed bool
+	}{
+		{name: "unknown user", want: ErrAssigneeNotFound},
+		{name: "no role", replies: assignReplies(""), want: ErrAssigneeNoAccess},
+		{name: "viewer", replies: assignReplies(gomodel.FormRoleViewer), want: ErrAssigneeNoAccess},
+		{name: "analyst", replies: assignReplies(gomodel.FormRoleAnalyst), changed: true},
+		{name: "editor", replies: assignReplies(gomodel.FormRoleEditor), changed: true},
+		{name: "already assigned", replies: assignReplies(gomodel.FormRoleEditor), current: &u2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, recorder := dbtest.Serve(t, tt.replies...)
+			s := New(Opts{Database: db, Access: access.New(access.Opts{Database: db})})
+			response := &gomodel.FormResponse{ID: "r1", FormID: "f1", AssigneeID: tt.current}
+
+			err := s.Assign(context.Background(), "u1", response, &u2)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Assign() error = %v, want %v", err, tt.want)
+			}
+
+			updates := recorder.Find(`UPDATE "form_responses" SET "assignee_id"='u2'`)
+			logged := recorder.Find(`INSERT INTO "response_activities"`, "'u2'")
+			if changed := len(updates) == 1 && len(logged) == 1; changed != tt.changed {
+				t.Errorf("statements = %q, want changed %v", recorder.Statements(), tt.changed)
+			}
+		})
+	}
+}
+
+func TestUnassign(t *testing.T) {
+	db, recorder := dbtest.Serve(t)
+	s := New(Opts{Database: db, Access: access.New(access.Opts{Database: db})})
+	u2 := "u2"
+
+	// Removing the assignee needs no checks: nobody has to see the response
+	if err := s.Assign(context.Background(), "u1", &gomodel.FormResponse{ID: "r1", FormID: "f1", AssigneeID: &u2}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	statements := recorder.Statements()
+	if len(statements) != 2 || !strings.Contains(statements[0], `SET "assignee_id"=NULL`) ||
+		!strings.HasPrefix(statements[1], `INSERT INTO "response_activities"`) || !strings.Contains(statements[1], "'u2'") {
+		t.Errorf("statements = %q, want the assignee removed and the change logged", statements)
+	}
+}
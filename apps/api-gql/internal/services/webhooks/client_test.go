@@ -0,0 +1,109 @@
+package webhooks
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:4700::1111", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fc00::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:10.0.0.1", false},
+		{"64:ff9b::a9fe:a9fe", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+	}
+	for _, tt := range tests {
+		if got := publicAddr(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("publicAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want error
+	}{
+		{"https://example.com/hook", nil},
+		{"http://93.184.216.34:8080/hook", nil},
+		{"ftp://example.com", ErrInvalidURL},
+		{"/hook", ErrInvalidURL},
+		{"http://localhost:8080/hook", ErrPrivateAddress},
+		{"http://api.localhost./hook", ErrPrivateAddress},
+		{"http://127.0.0.1/hook", ErrPrivateAddress},
+		{"http://[::1]/hook", ErrPrivateAddress},
+		{"http://169.254.169.254/latest/meta-data", ErrPrivateAddress},
+	}
+	for _, tt := range tests {
+		if err := validateURL(tt.url, false); !errors.Is(err, tt.want) {
+			t.Errorf("validateURL(%q) = %v, want %v", tt.url, err, tt.want)
+		}
+	}
+}
+
+func TestValidateURLAllowPrivate(t *testing.T) {
+	for _, raw := range []string{"http://localhost:8080/hook", "http://127.0.0.1/hook", "http://10.0.0.1/hook"} {
+		if err := validateURL(raw, true); err != nil {
+			t.Errorf("validateURL(%q) = %v, want nil", raw, err)
+		}
+	}
+	if err := validateURL("ftp://localhost", true); !errors.Is(err, ErrInvalidURL) {
+		t.Errorf("validateURL(ftp) = %v, want ErrInvalidURL", err)
+	}
+}
+
+func TestClientRefusesPrivateAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the request reached a loopback receiver")
+	}))
+	defer server.Close()
+
+	_, err := newClient(time.Second, false).Post(server.URL, "application/json", nil)
+	if !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("Post() error = %v, want ErrPrivateAddress", err)
+	}
+}
+
+func TestClientDoesNotFollowRedirects(t *testing.T) {
+	// Only the redirect policy is under test: allow the loopback server
+	client := newClient(time.Second, true)
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the redirect was followed")
+	}))
+	defer target.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	resp, err := client.Post(server.URL, "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTemporaryRedirect {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusTemporaryRedirect)
+	}
+}
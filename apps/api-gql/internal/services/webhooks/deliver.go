@@ -0,0 +1,216 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/TrySquadDF/formify/crypto"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Headers sent with every delivery. The signature is an HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the webhook secret, see Sign.
+const (
+	SignatureHeader = "X-Formify-Signature"
+	TimestampHeader = "X-Formify-Timestamp"
+	EventHeader     = "X-Formify-Event"
+	DeliveryHeader  = "X-Formify-Delivery"
+)
+
+const (
+	// pollInterval is how often the worker looks for due deliveries
+	pollInterval = 5 * time.Second
+	// deliveryBatchSize limits how many deliveries are claimed at once
+	deliveryBatchSize = 20
+	// claimMargin is added to the lease for the database work around the requests
+	claimMargin = time.Minute
+	// retryBaseDelay is doubled after every failed attempt up to retryMaxDelay
+	retryBaseDelay = 30 * time.Second
+	retryMaxDelay  = 6 * time.Hour
+	// maxResponseBody limits how much of the receiver's answer is kept in the log
+	maxResponseBody = 64 << 10
+)
+
+// Sign returns the value of the signature header for a request body.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// DeliverDue sends the deliveries whose next attempt is due and returns how many were sent.
+func (s *Service) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := s.claimDue(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range deliveries {
+		if err := s.deliver(ctx, &deliveries[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(deliveries), nil
+}
+
+// claimDue picks due deliveries and moves their next attempt past the lease, so
+// several API instances never send the same delivery at once.
+func (s *Service) claimDue(ctx context.Context) ([]gomodel.WebhookDelivery, error) {
+	var deliveries []gomodel.WebhookDelivery
+	err := s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", gomodel.WebhookDeliveryPending, now).
+			Order("next_attempt_at").
+			Limit(deliveryBatchSize).
+			Find(&deliveries).Error; err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(deliveries))
+		for i, d := range deliveries {
+			ids[i] = d.ID
+		}
+		return tx.Model(&gomodel.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(s.claimLease())).Error
+	})
+	return deliveries, err
+}
+
+// claimLease keeps claimed deliveries away from other workers while they are sent.
+// They are sent one after another, so the lease outlasts a whole batch of timeouts.
+func (s *Service) claimLease() time.Duration {
+	return deliveryBatchSize*s.config.WebhookTimeout + claimMargin
+}
+
+// deliver makes one attempt to send a delivery and records the outcome.
+func (s *Service) deliver(ctx context.Context, delivery *gomodel.WebhookDelivery) error {
+	status, body, err := s.send(ctx, delivery)
+	updates := attemptOutcome(delivery.Attempts+1, status, body, err, time.Now(), s.config.WebhookMaxAttempts)
+	return s.database.WithContext(ctx).Model(&gomodel.WebhookDelivery{}).Where("id = ?", delivery.ID).Updates(updates).Error
+}
+
+// attemptOutcome returns the log fields of a delivery after an attempt: the receiver's
+// answer or the error, and either the final status or the time of the next attempt.
+func attemptOutcome(attempts int32, status int32, body string, err error, now time.Time, maxAttempts int) map[string]interface{} {
+	updates := map[string]interface{}{
+		"attempts":        attempts,
+		"response_status": nil,
+		"response_body":   nil,
+		"error":           nil,
+	}
+
+	switch {
+	case err != nil:
+		updates["error"] = err.Error()
+	default:
+		updates["response_status"] = status
+		updates["response_body"] = body
+		if status < 200 || status >= 300 {
+			updates["error"] = fmt.Sprintf("receiver responded with status %d", status)
+		}
+	}
+
+	switch {
+	case updates["error"] == nil:
+		updates["status"] = gomodel.WebhookDeliverySucceeded
+		updates["delivered_at"] = now
+	case errors.Is(err, ErrWebhookNotFound) || int(attempts) >= maxAttempts:
+		updates["status"] = gomodel.WebhookDeliveryFailed
+	default:
+		updates["next_attempt_at"] = now.Add(retryDelay(attempts))
+	}
+	return updates
+}
+
+// send posts the signed payload to the webhook of the delivery and returns the
+// receiver's status and body.
+func (s *Service) send(ctx context.Context, delivery *gomodel.WebhookDelivery) (int32, string, error) {
+	var hook gomodel.Webhook
+	if err := s.database.WithContext(ctx).First(&hook, "id = ?", delivery.WebhookID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, "", ErrWebhookNotFound
+		}
+		return 0, "", err
+	}
+	return s.post(ctx, &hook, delivery, time.Now())
+}
+
+// post signs the payload with the secret of the hook as of now and sends it.
+func (s *Service) post(ctx context.Context, hook *gomodel.Webhook, delivery *gomodel.WebhookDelivery, now time.Time) (int32, string, error) {
+	secret, err := crypto.Decrypt(hook.Secret, s.config.CYPHER_KEY)
+	if err != nil {
+		return 0, "", err
+	}
+
+	body := []byte(delivery.RequestBody)
+	timestamp := now.Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Formify-Webhooks/1.0")
+	req.Header.Set(EventHeader, string(delivery.Event))
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return int32(resp.StatusCode), "", err
+	}
+	return int32(resp.StatusCode), string(respBody), nil
+}
+
+func retryDelay(attempts int32) time.Duration {
+	delay := retryBaseDelay
+	for i := int32(1); i < attempts && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
+
+func (s *Service) runDeliveries(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Error delivering webhooks: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
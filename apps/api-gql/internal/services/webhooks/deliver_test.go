@@ -0,0 +1,120 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/TrySquadDF/formify/crypto"
+	"github.com/TrySquadDF/formify/lib/config"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+const testCypherKey = "0123456789abcdef0123456789abcdef"
+
+func TestDeliveryAttempts(t *testing.T) {
+	const secret = "whsec_test"
+	var statuses = []int{http.StatusServiceUnavailable, http.StatusInternalServerError, http.StatusOK}
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(statuses[len(requests)-1])
+		io.WriteString(w, "answer "+strconv.Itoa(len(requests)))
+	}))
+	defer server.Close()
+
+	cfg := config.Config{CYPHER_KEY: testCypherKey, WebhookTimeout: time.Second, WebhookMaxAttempts: 8, AllowPrivateWebhooks: true}
+	s := &Service{config: cfg, client: newClient(cfg.WebhookTimeout, cfg.AllowPrivateWebhooks)}
+	encrypted, err := crypto.Encrypt(secret, testCypherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook := &gomodel.Webhook{ID: "hook", URL: server.URL, Secret: encrypted}
+	delivery := &gomodel.WebhookDelivery{ID: "delivery", WebhookID: hook.ID, Event: gomodel.WebhookEventResponseCreated, RequestBody: `{"id":"r1"}`}
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	wantDelays := []time.Duration{retryBaseDelay, 2 * retryBaseDelay}
+	for attempt := range statuses {
+		status, body, err := s.post(context.Background(), hook, delivery, now)
+		updates := attemptOutcome(delivery.Attempts+1, status, body, err, now, cfg.WebhookMaxAttempts)
+
+		r := requests[attempt]
+		timestamp := strconv.FormatInt(now.Unix(), 10)
+		if got := r.Header.Get(TimestampHeader); got != timestamp {
+			t.Errorf("attempt %d: timestamp = %q, want %q", attempt+1, got, timestamp)
+		}
+		if got, want := r.Header.Get(SignatureHeader), Sign(secret, now.Unix(), []byte(bodies[attempt])); got != want {
+			t.Errorf("attempt %d: signature = %q, want %q", attempt+1, got, want)
+		}
+		if r.Header.Get(EventHeader) != string(delivery.Event) || r.Header.Get(DeliveryHeader) != delivery.ID {
+			t.Errorf("attempt %d: event and delivery headers = %q, %q", attempt+1, r.Header.Get(EventHeader), r.Header.Get(DeliveryHeader))
+		}
+		if bodies[attempt] != delivery.RequestBody {
+			t.Errorf("attempt %d: body = %q, want %q", attempt+1, bodies[attempt], delivery.RequestBody)
+		}
+
+		if updates["attempts"] != int32(attempt+1) || updates["response_status"] != int32(statuses[attempt]) ||
+			updates["response_body"] != "answer "+strconv.Itoa(attempt+1) {
+			t.Errorf("attempt %d: log = %v", attempt+1, updates)
+		}
+		if attempt < len(wantDelays) {
+			if updates["error"] == nil || updates["status"] != nil {
+				t.Errorf("attempt %d: updates = %v, want a failed attempt left pending", attempt+1, updates)
+			}
+			if got := updates["next_attempt_at"]; got != now.Add(wantDelays[attempt]) {
+				t.Errorf("attempt %d: next attempt at %v, want %v", attempt+1, got, now.Add(wantDelays[attempt]))
+			}
+		} else if updates["status"] != gomodel.WebhookDeliverySucceeded || updates["delivered_at"] != now || updates["error"] != nil {
+			t.Errorf("attempt %d: updates = %v, want a succeeded delivery", attempt+1, updates)
+		}
+
+		delivery.Attempts++
+		now = now.Add(time.Minute)
+	}
+}
+
+func TestAttemptOutcomeGivesUp(t *testing.T) {
+	now := time.Now()
+
+	updates := attemptOutcome(8, http.StatusBadGateway, "", nil, now, 8)
+	if updates["status"] != gomodel.WebhookDeliveryFailed || updates["next_attempt_at"] != nil {
+		t.Errorf("last attempt: updates = %v, want failed", updates)
+	}
+
+	updates = attemptOutcome(1, 0, "", ErrWebhookNotFound, now, 8)
+	if updates["status"] != gomodel.WebhookDeliveryFailed || updates["error"] != ErrWebhookNotFound.Error() || updates["response_status"] != nil {
+		t.Errorf("deleted webhook: updates = %v, want failed", updates)
+	}
+
+	updates = attemptOutcome(2, 0, "", errors.New("connection refused"), now, 8)
+	if updates["status"] != nil || updates["next_attempt_at"] != now.Add(2*retryBaseDelay) {
+		t.Errorf("network error: updates = %v, want a retry", updates)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{5, 8 * time.Minute},
+		{10, 256 * time.Minute},
+		{11, retryMaxDelay},
+		{40, retryMaxDelay},
+	}
+	for _, tt := range tests {
+		if got := retryDelay(tt.attempts); got != tt.want {
+			t.Errorf("retryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Payload is the JSON body posted to a webhook.
+type Payload struct {
+	// ID identifies the event; it is the same for every webhook that receives it
+	ID        string               `json:"id"`
+	Event     gomodel.WebhookEvent `json:"event"`
+	FormID    string               `json:"formId"`
+	CreatedAt time.Time            `json:"createdAt"`
+	Data      any                  `json:"data"`
+}
+
+// ResponseData is the payload data of response.created and response.updated.
+type ResponseData struct {
+	ResponseID  string       `json:"responseId"`
+	SubmittedAt time.Time    `json:"submittedAt"`
+	UpdatedAt   *time.Time   `json:"updatedAt,omitempty"`
+	Answers     []AnswerData `json:"answers"`
+}
+
+type AnswerData struct {
+	QuestionID  string     `json:"questionId"`
+	TextValue   *string    `json:"textValue,omitempty"`
+	BoolValue   *bool      `json:"boolValue,omitempty"`
+	NumberValue *float64   `json:"numberValue,omitempty"`
+	DateValue   *time.Time `json:"dateValue,omitempty"`
+	OptionIDs   []string   `json:"optionIds,omitempty"`
+}
+
+// FormData is the payload data of form.closed.
+type FormData struct {
+	Title    string    `json:"title"`
+	ClosedAt time.Time `json:"closedAt"`
+}
+
+// NewResponseData describes a stored response for a webhook payload.
+func NewResponseData(response *gomodel.FormResponse, answers []gomodel.Answer) ResponseData {
+	data := ResponseData{
+		ResponseID:  response.ID,
+		SubmittedAt: response.CreatedAt,
+		UpdatedAt:   response.UpdatedAt,
+		Answers:     make([]AnswerData, len(answers)),
+	}
+
+	for i, a := range answers {
+		answer := AnswerData{
+			QuestionID:  a.QuestionID,
+			BoolValue:   a.BoolValue,
+			NumberValue: a.NumberValue,
+			DateValue:   a.DateValue,
+		}
+		if a.TextValue != "" {
+			text := a.TextValue
+			answer.TextValue = &text
+		}
+		for _, o := range a.SelectedOptions {
+			answer.OptionIDs = append(answer.OptionIDs, o.ID)
+		}
+		data.Answers[i] = answer
+	}
+
+	return data
+}
+
+// Enqueue schedules a delivery of the event to every enabled webhook of the form that
+// subscribed to it. Called inside the transaction that makes the change, it only writes
+// the deliveries; they are sent later by the worker.
+func Enqueue(tx *gorm.DB, formID string, event gomodel.WebhookEvent, data any) error {
+	var hooks []gomodel.Webhook
+	if err := tx.Where("form_id = ? AND enabled AND ? = ANY(events)", formID, string(event)).Find(&hooks).Error; err != nil {
+		return err
+	}
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	body, err := json.Marshal(Payload{
+		ID:        uuid.New().String(),
+		Event:     event,
+		FormID:    formID,
+		CreatedAt: now,
+		Data:      data,
+	})
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]gomodel.WebhookDelivery, len(hooks))
+	for i, hook := range hooks {
+		deliveries[i] = gomodel.WebhookDelivery{
+			WebhookID:     hook.ID,
+			Event:         event,
+			RequestBody:   string(body),
+			Status:        gomodel.WebhookDeliveryPending,
+			NextAttemptAt: now,
+		}
+	}
+	return tx.Create(&deliveries).Error
+}
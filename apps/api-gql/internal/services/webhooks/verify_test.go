@@ -0,0 +1,48 @@
+package webhooks
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/TrySquadDF/formify/lib/client"
+)
+
+// TestSignatureVerifiesWithClient checks that receivers using the Go client accept what
+// deliveries are signed with.
+func TestSignatureVerifiesWithClient(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"id":"e1","event":"response.created","formId":"f1","data":{}}`)
+	signed := func(timestamp int64, body []byte) http.Header {
+		header := http.Header{}
+		header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+		header.Set(SignatureHeader, Sign(secret, timestamp, body))
+		return header
+	}
+	now := time.Now().Unix()
+
+	if err := client.VerifyWebhook(secret, signed(now, body), body, 0); err != nil {
+		t.Errorf("fresh delivery: %v", err)
+	}
+
+	stale := now - int64(client.DefaultWebhookTolerance/time.Second) - 60
+	if err := client.VerifyWebhook(secret, signed(stale, body), body, 0); !errors.Is(err, client.ErrStaleWebhook) {
+		t.Errorf("stale delivery: %v, want ErrStaleWebhook", err)
+	}
+
+	tampered := []byte(`{"id":"e1","event":"response.created","formId":"f2","data":{}}`)
+	if err := client.VerifyWebhook(secret, signed(now, body), tampered, 0); !errors.Is(err, client.ErrInvalidSignature) {
+		t.Errorf("tampered body: %v, want ErrInvalidSignature", err)
+	}
+
+	if err := client.VerifyWebhook("another secret", signed(now, body), body, 0); !errors.Is(err, client.ErrInvalidSignature) {
+		t.Errorf("another secret: %v, want ErrInvalidSignature", err)
+	}
+
+	if client.SignatureHeader != SignatureHeader || client.TimestampHeader != TimestampHeader ||
+		client.EventHeader != EventHeader || client.DeliveryHeader != DeliveryHeader {
+		t.Error("the client reads other headers than deliveries are sent with")
+	}
+}
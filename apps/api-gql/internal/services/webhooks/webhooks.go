@@ -0,0 +1,241 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/TrySquadDF/formify/crypto"
+	"github.com/TrySquadDF/formify/lib/config"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"github.com/lib/pq"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// secretBytes is the amount of randomness in a generated signing secret
+const secretBytes = 32
+
+var (
+	ErrInvalidURL       = errors.New("webhook url must be an absolute http or https url")
+	ErrPrivateAddress   = errors.New("webhook url must point to a public address")
+	ErrNoEvents         = errors.New("webhook must subscribe to at least one event")
+	ErrUnknownEvent     = errors.New("unknown webhook event")
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("delivery not found")
+)
+
+type Opts struct {
+	fx.In
+	LC fx.Lifecycle
+
+	Database *gorm.DB
+	Config   config.Config
+}
+
+type Service struct {
+	database *gorm.DB
+	config   config.Config
+	client   *http.Client
+}
+
+func New(opts Opts) *Service {
+	s := &Service{
+		database: opts.Database,
+		config:   opts.Config,
+		client:   newClient(opts.Config.WebhookTimeout, opts.Config.AllowPrivateWebhooks),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	opts.LC.Append(
+		fx.Hook{
+			OnStart: func(_ context.Context) error {
+				go s.runDeliveries(ctx)
+				return nil
+			},
+			OnStop: func(_ context.Context) error {
+				cancel()
+				return nil
+			},
+		},
+	)
+
+	return s
+}
+
+type WebhookParams struct {
+	URL    string
+	Events []gomodel.WebhookEvent
+	// Secret is generated when empty
+	Secret string
+}
+
+type WebhookUpdate struct {
+	URL     *string
+	Events  []gomodel.WebhookEvent
+	Enabled *bool
+}
+
+// Create subscribes a form to events. The signing secret is returned in clear only here.
+func (s *Service) Create(ctx context.Context, formID string, params WebhookParams) (*gomodel.Webhook, string, error) {
+	if err := validateURL(params.URL, s.config.AllowPrivateWebhooks); err != nil {
+		return nil, "", err
+	}
+	events, err := normalizeEvents(params.Events)
+	if err != nil {
+		return nil, "", err
+	}
+
+	secret := params.Secret
+	if secret == "" {
+		if secret, err = crypto.NewToken(secretBytes); err != nil {
+			return nil, "", err
+		}
+	}
+	encrypted, err := crypto.Encrypt(secret, s.config.CYPHER_KEY)
+	if err != nil {
+		return nil, "", err
+	}
+
+	hook := gomodel.Webhook{
+		FormID:  formID,
+		URL:     params.URL,
+		Events:  events,
+		Secret:  encrypted,
+		Enabled: true,
+	}
+	if err := s.database.WithContext(ctx).Create(&hook).Error; err != nil {
+		return nil, "", err
+	}
+
+	return &hook, secret, nil
+}
+
+// Update changes the url, events or state of a webhook.
+func (s *Service) Update(ctx context.Context, hook *gomodel.Webhook, update WebhookUpdate) error {
+	updates := map[string]interface{}{}
+
+	if update.URL != nil {
+		if err := validateURL(*update.URL, s.config.AllowPrivateWebhooks); err != nil {
+			return err
+		}
+		updates["url"] = *update.URL
+		hook.URL = *update.URL
+	}
+	if update.Events != nil {
+		events, err := normalizeEvents(update.Events)
+		if err != nil {
+			return err
+		}
+		updates["events"] = events
+		hook.Events = events
+	}
+	if update.Enabled != nil {
+		updates["enabled"] = *update.Enabled
+		hook.Enabled = *update.Enabled
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+	return s.database.WithContext(ctx).Model(&gomodel.Webhook{}).Where("id = ?", hook.ID).Updates(updates).Error
+}
+
+// Delete removes a webhook together with its delivery log.
+func (s *Service) Delete(ctx context.Context, id string) error {
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", id).Delete(&gomodel.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&gomodel.Webhook{}).Error
+	})
+}
+
+func (s *Service) Find(ctx context.Context, id string) (*gomodel.Webhook, error) {
+	var hook gomodel.Webhook
+	if err := s.database.WithContext(ctx).First(&hook, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return &hook, nil
+}
+
+// List returns the webhooks of a form, oldest first.
+func (s *Service) List(ctx context.Context, formID string) ([]gomodel.Webhook, error) {
+	var hooks []gomodel.Webhook
+	err := s.database.WithContext(ctx).Where("form_id = ?", formID).Order("created_at").Find(&hooks).Error
+	return hooks, err
+}
+
+// Deliveries returns the delivery log of a webhook, newest first.
+func (s *Service) Deliveries(ctx context.Context, webhookID string, limit int) ([]gomodel.WebhookDelivery, error) {
+	var deliveries []gomodel.WebhookDelivery
+	err := s.database.WithContext(ctx).
+		Where("webhook_id = ?", webhookID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (s *Service) FindDelivery(ctx context.Context, id string) (*gomodel.WebhookDelivery, error) {
+	var delivery gomodel.WebhookDelivery
+	if err := s.database.WithContext(ctx).First(&delivery, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// Replay schedules the payload of an earlier delivery to be sent again as a new delivery.
+func (s *Service) Replay(ctx context.Context, delivery *gomodel.WebhookDelivery) (*gomodel.WebhookDelivery, error) {
+	replay := gomodel.WebhookDelivery{
+		WebhookID:     delivery.WebhookID,
+		Event:         delivery.Event,
+		RequestBody:   delivery.RequestBody,
+		Status:        gomodel.WebhookDeliveryPending,
+		NextAttemptAt: time.Now(),
+	}
+	if err := s.database.WithContext(ctx).Create(&replay).Error; err != nil {
+		return nil, err
+	}
+	return &replay, nil
+}
+
+func validateURL(raw string, allowPrivate bool) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+	if allowPrivate {
+		return nil
+	}
+	return checkHost(u.Hostname())
+}
+
+func normalizeEvents(events []gomodel.WebhookEvent) (pq.StringArray, error) {
+	if len(events) == 0 {
+		return nil, ErrNoEvents
+	}
+
+	result := make(pq.StringArray, 0, len(events))
+	seen := make(map[gomodel.WebhookEvent]bool, len(events))
+	for _, e := range events {
+		switch e {
+		case gomodel.WebhookEventResponseCreated, gomodel.WebhookEventResponseUpdated, gomodel.WebhookEventFormClosed:
+		default:
+			return nil, ErrUnknownEvent
+		}
+		if !seen[e] {
+			seen[e] = true
+			result = append(result, string(e))
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,364 @@
+package workspaces
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/services/forms"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrWorkspaceNotFound = errors.New("workspace not found")
+	ErrEmptyName         = errors.New("workspace name must not be empty")
+	ErrInvalidRole       = errors.New("invalid workspace role")
+	ErrUserNotFound      = errors.New("user not found")
+	ErrAlreadyMember     = errors.New("user is already a member of the workspace")
+	ErrMemberNotFound    = errors.New("member not found")
+	ErrLastAdmin         = errors.New("workspace must keep at least one admin")
+	ErrNotEmpty          = errors.New("workspace still has forms")
+	ErrMoveDenied        = errors.New("only a workspace admin can move a form out of the workspace")
+)
+
+type Opts struct {
+	fx.In
+
+	Database *gorm.DB
+}
+
+type Service struct {
+	database *gorm.DB
+}
+
+func New(opts Opts) *Service {
+	return &Service{
+		database: opts.Database,
+	}
+}
+
+// Create makes a workspace with the user as its first admin.
+func (s *Service) Create(ctx context.Context, userID, name string) (*gomodel.Workspace, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+
+	workspace := &gomodel.Workspace{
+		Name:        name,
+		CreatedByID: userID,
+	}
+	err := s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(workspace).Error; err != nil {
+			return err
+		}
+		return tx.Create(&gomodel.WorkspaceMember{
+			WorkspaceID: workspace.ID,
+			UserID:      userID,
+			Role:        gomodel.WorkspaceRoleAdmin,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// Find loads a workspace by ID.
+func (s *Service) Find(ctx context.Context, id string) (*gomodel.Workspace, error) {
+	var workspace gomodel.Workspace
+	if err := s.database.WithContext(ctx).First(&workspace, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// ForUser returns the workspaces the user is a member of, by name.
+func (s *Service) ForUser(ctx context.Context, userID string) ([]gomodel.Workspace, error) {
+	var workspaces []gomodel.Workspace
+	err := s.database.WithContext(ctx).
+		Joins("JOIN workspace_members ON workspace_members.workspace_id = workspaces.id").
+		Where("workspace_members.user_id = ?", userID).
+		Order("workspaces.name").
+		Find(&workspaces).Error
+	return workspaces, err
+}
+
+// Rename changes the name of the workspace.
+func (s *Service) Rename(ctx context.Context, workspace *gomodel.Workspace, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyName
+	}
+	return s.database.WithContext(ctx).Model(workspace).Update("name", name).Error
+}
+
+// Delete removes a workspace that has no forms left, trashed ones included.
+func (s *Service) Delete(ctx context.Context, id string) error {
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Unscoped().Model(&gomodel.Form{}).
+			Where(`"workspaceId" = ?`, id).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrNotEmpty
+		}
+
+		if err := tx.Model(&gomodel.Users{}).
+			Where(`"currentWorkspaceId" = ?`, id).
+			Update("currentWorkspaceId", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&gomodel.Folder{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&gomodel.WorkspaceMember{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&gomodel.Workspace{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrWorkspaceNotFound
+		}
+		return nil
+	})
+}
+
+// Members returns the members of the workspace with their users, oldest first.
+func (s *Service) Members(ctx context.Context, workspaceID string) ([]gomodel.WorkspaceMember, error) {
+	var members []gomodel.WorkspaceMember
+	err := s.database.WithContext(ctx).
+		Preload("User").
+		Where("workspace_id = ?", workspaceID).
+		Order("created_at").
+		Find(&members).Error
+	return members, err
+}
+
+// AddMember adds the registered user with the email to the workspace.
+func (s *Service) AddMember(ctx context.Context, workspaceID, email string, role gomodel.WorkspaceRole) (*gomodel.WorkspaceMember, error) {
+	if !validRole(role) {
+		return nil, ErrInvalidRole
+	}
+
+	var user gomodel.Users
+	if err := s.database.WithContext(ctx).
+		Where("LOWER(email) = ?", strings.ToLower(strings.TrimSpace(email))).
+		Take(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	member := &gomodel.WorkspaceMember{
+		WorkspaceID: workspaceID,
+		UserID:      user.ID,
+		Role:        role,
+		User:        &user,
+	}
+	result := s.database.WithContext(ctx).
+		Omit("User").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(member)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrAlreadyMember
+	}
+	return member, nil
+}
+
+// ChangeRole gives a member another role. The last admin cannot step down.
+func (s *Service) ChangeRole(ctx context.Context, workspaceID, userID string, role gomodel.WorkspaceRole) (*gomodel.WorkspaceMember, error) {
+	if !validRole(role) {
+		return nil, ErrInvalidRole
+	}
+
+	var member gomodel.WorkspaceMember
+	err := s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := lockMember(tx, workspaceID, userID)
+		if err != nil {
+			return err
+		}
+
+		if current.Role == gomodel.WorkspaceRoleAdmin && role != gomodel.WorkspaceRoleAdmin {
+			if err := checkOtherAdmins(tx, workspaceID, userID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(current).Update("role", role).Error; err != nil {
+			return err
+		}
+		member = *current
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.database.WithContext(ctx).Preload("User").First(&member, "id = ?", member.ID).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// RemoveMember takes the user out of the workspace. The forms the user created stay
+// in the workspace and pass to its longest-standing admin, so that the user keeps no
+// hold on them. The last admin cannot leave.
+func (s *Service) RemoveMember(ctx context.Context, workspaceID, userID string) error {
+	return s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		member, err := lockMember(tx, workspaceID, userID)
+		if err != nil {
+			return err
+		}
+
+		if member.Role == gomodel.WorkspaceRoleAdmin {
+			if err := checkOtherAdmins(tx, workspaceID, userID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Delete(member).Error; err != nil {
+			return err
+		}
+
+		var admin gomodel.WorkspaceMember
+		if err := tx.Where("workspace_id = ? AND role = ?", workspaceID, gomodel.WorkspaceRoleAdmin).
+			Order("created_at").
+			Take(&admin).Error; err != nil {
+			return err
+		}
+		// Trashed forms too, or the user could still restore or purge them
+		if err := tx.Unscoped().Model(&gomodel.Form{}).
+			Where(`"workspaceId" = ? AND owner_id = ?`, workspaceID, userID).
+			Update("owner_id", admin.UserID).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&gomodel.Users{}).
+			Where(`id = ? AND "currentWorkspaceId" = ?`, userID, workspaceID).
+			Update("currentWorkspaceId", nil).Error
+	})
+}
+
+// Switch makes the workspace current for the user; nil switches to the personal workspace.
+// The caller checks that the user is a member of the workspace.
+func (s *Service) Switch(ctx context.Context, userID string, workspaceID *string) error {
+	return s.database.WithContext(ctx).Model(&gomodel.Users{}).
+		Where("id = ?", userID).
+		Update("currentWorkspaceId", workspaceID).Error
+}
+
+// MoveForm puts the form into the workspace; nil moves it to the personal workspace of its owner.
+// The user must be an admin of the workspace the form leaves and a member of the one it
+// enters, see CanMoveForm. The form leaves its folder, which belongs to the previous
+// workspace. A form whose key is used in the target workspace fails with forms.ErrKeyTaken.
+func (s *Service) MoveForm(ctx context.Context, form *gomodel.Form, userID string, workspaceID *string) error {
+	err := s.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		source, err := memberRole(tx, form.WorkspaceID, userID)
+		if err != nil {
+			return err
+		}
+		target, err := memberRole(tx, workspaceID, userID)
+		if err != nil {
+			return err
+		}
+		if !CanMoveForm(form.WorkspaceID, workspaceID, source, target) {
+			return ErrMoveDenied
+		}
+
+		if err := tx.Model(form).Updates(map[string]interface{}{
+			"workspaceId": workspaceID,
+			"folderId":    nil,
+		}).Error; err != nil {
+			return err
+		}
+		version, err := forms.BumpVersion(tx, form.ID, nil)
+		form.Version = version
+		return err
+	})
+	if forms.IsKeyTaken(err) {
+		return forms.ErrKeyTaken
+	}
+	return err
+}
+
+// CanMoveForm reports whether a user holding the source role in the workspace the form
+// is in and the target role in the workspace it goes to may move the form. Nil stands
+// for the personal workspace. Leaving a workspace takes an admin of it, since the form
+// belongs to the team even when a member wrote it; entering one takes any member.
+func CanMoveForm(from, to *string, source, target gomodel.WorkspaceRole) bool {
+	if from != nil && (to == nil || *to != *from) && source != gomodel.WorkspaceRoleAdmin {
+		return false
+	}
+	if to != nil && (from == nil || *from != *to) && target == "" {
+		return false
+	}
+	return true
+}
+
+// memberRole returns the role of the user in the workspace, the empty role when the user
+// is not a member or workspaceID is nil.
+func memberRole(tx *gorm.DB, workspaceID *string, userID string) (gomodel.WorkspaceRole, error) {
+	if workspaceID == nil {
+		return "", nil
+	}
+	var member gomodel.WorkspaceMember
+	err := tx.Where("workspace_id = ? AND user_id = ?", *workspaceID, userID).Take(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return member.Role, nil
+}
+
+// lockMember loads a workspace member and locks its row and the rows of the other
+// admins, so concurrent role changes cannot remove every admin.
+func lockMember(tx *gorm.DB, workspaceID, userID string) (*gomodel.WorkspaceMember, error) {
+	var members []gomodel.WorkspaceMember
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("workspace_id = ? AND (user_id = ? OR role = ?)", workspaceID, userID, gomodel.WorkspaceRoleAdmin).
+		Find(&members).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range members {
+		if members[i].UserID == userID {
+			return &members[i], nil
+		}
+	}
+	return nil, ErrMemberNotFound
+}
+
+func checkOtherAdmins(tx *gorm.DB, workspaceID, userID string) error {
+	var count int64
+	if err := tx.Model(&gomodel.WorkspaceMember{}).
+		Where("workspace_id = ? AND role = ? AND user_id <> ?", workspaceID, gomodel.WorkspaceRoleAdmin, userID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrLastAdmin
+	}
+	return nil
+}
+
+func validRole(role gomodel.WorkspaceRole) bool {
+	return role == gomodel.WorkspaceRoleAdmin || role == gomodel.WorkspaceRoleMember
+}
@@ -0,0 +1,122 @@
+package workspaces
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/TrySquadDF/formify/api-gql/internal/dbtest"
+	gomodel "github.com/TrySquadDF/formify/lib/gomodels"
+)
+
+func TestCanMoveForm(t *testing.T) {
+	team, other := "team", "other"
+	admin, member := gomodel.WorkspaceRoleAdmin, gomodel.WorkspaceRoleMember
+
+	tests := []struct {
+		name           string
+		from, to       *string
+		source, target gomodel.WorkspaceRole
+		want           bool
+	}{
+		{"member author moves a team form out", &team, nil, member, "", false},
+		{"member moves a team form across", &team, &other, member, member, false},
+		{"admin moves a team form out", &team, nil, admin, "", true},
+		{"admin moves a team form across", &team, &other, admin, member, true},
+		{"admin moves into a foreign workspace", &team, &other, admin, "", false},
+		{"owner moves a personal form in", nil, &team, "", member, true},
+		{"owner moves into a foreign workspace", nil, &team, "", "", false},
+		{"form stays in its workspace", &team, &team, member, member, true},
+		{"personal form stays personal", nil, nil, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanMoveForm(tt.from, tt.to, tt.source, tt.target); got != tt.want {
+				t.Errorf("CanMoveForm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// memberRows are workspace members of w1 as the database returns them.
+func memberRows(members ...[2]string) [][]driver.Value {
+	rows := make([][]driver.Value, len(members))
+	for i, m := range members {
+		rows[i] = []driver.Value{"m-" + m[0], "w1", m[0], m[1]}
+	}
+	return rows
+}
+
+func TestRemoveMember(t *testing.T) {
+	admin, member := string(gomodel.WorkspaceRoleAdmin), string(gomodel.WorkspaceRoleMember)
+	columns := []string{"id", "workspace_id", "user_id", "role"}
+
+	tests := []struct {
+		name string
+		// locked are the rows of the member and the admins
+		locked     [][]driver.Value
+		otherAdmin int64
+		// heir is the longest-standing admin left
+		heir string
+		want error
+	}{
+		{name: "member", locked: memberRows([2]string{"u1", admin}, [2]string{"u2", member}), heir: "u1"},
+		{name: "admin with another admin", locked: memberRows([2]string{"u2", admin}, [2]string{"u3", admin}), otherAdmin: 1, heir: "u3"},
+		{name: "last admin", locked: memberRows([2]string{"u2", admin}), want: ErrLastAdmin},
+		{name: "not a member", locked: memberRows([2]string{"u1", admin}), want: ErrMemberNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, recorder := dbtest.Serve(t,
+				dbtest.Reply{Match: "FOR UPDATE", Columns: columns, Rows: tt.locked},
+				dbtest.Reply{Match: "count(*)", Columns: []string{"count"}, Rows: [][]driver.Value{{tt.otherAdmin}}},
+				dbtest.Reply{Match: "ORDER BY created_at", Columns: columns, Rows: memberRows([2]string{tt.heir, admin})},
+			)
+			s := New(Opts{Database: db})
+
+			err := s.RemoveMember(context.Background(), "w1", "u2")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("RemoveMember() error = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil {
+				if changes := recorder.Find("DELETE"); len(changes) != 0 {
+					t.Errorf("statements = %q, want the member kept", changes)
+				}
+				return
+			}
+
+			if len(recorder.Find(`DELETE FROM "workspace_members" WHERE "workspace_members"."id" = 'm-u2'`)) != 1 {
+				t.Errorf("statements = %q, want the member deleted", recorder.Statements())
+			}
+			// Forms in the trash pass on too, so the handoff is not limited to live forms
+			handoff := recorder.Find(`UPDATE "forms" SET "owner_id"='`+tt.heir+`'`, `WHERE "workspaceId" = 'w1' AND owner_id = 'u2'`)
+			if len(handoff) != 1 || strings.Contains(handoff[0], "deletedAt") {
+				t.Errorf("statements = %q, want the forms of u2 passed to %s", recorder.Statements(), tt.heir)
+			}
+			if len(recorder.Find(`SET "currentWorkspaceId"=NULL`, `id = 'u2' AND "currentWorkspaceId" = 'w1'`)) != 1 {
+				t.Errorf("statements = %q, want the workspace no longer current for u2", recorder.Statements())
+			}
+		})
+	}
+}
+
+func TestMoveFormOutAsMember(t *testing.T) {
+	db, recorder := dbtest.Serve(t, dbtest.Reply{
+		Match:   `FROM "workspace_members"`,
+		Columns: []string{"id", "workspace_id", "user_id", "role"},
+		Rows:    memberRows([2]string{"u2", string(gomodel.WorkspaceRoleMember)}),
+	})
+	s := New(Opts{Database: db})
+	team := "w1"
+
+	// The author of a team form is no admin, so the form stays with the team
+	form := &gomodel.Form{ID: "f1", OwnerID: "u2", WorkspaceID: &team}
+	if err := s.MoveForm(context.Background(), form, "u2", nil); !errors.Is(err, ErrMoveDenied) {
+		t.Fatalf("MoveForm() error = %v, want ErrMoveDenied", err)
+	}
+	if changes := recorder.Find("UPDATE"); len(changes) != 0 {
+		t.Errorf("statements = %q, want the form left in place", changes)
+	}
+}
@@ -0,0 +1,165 @@
+// Package client is a typed Go client for the Formify GraphQL API.
+//
+// The operations in operations/*.graphql are compiled against the server schema by
+// genqlient (go generate), so a change of the schema that breaks a query fails the
+// generation instead of a call at run time. The hand-written part wraps the generated
+// functions with API key authentication, retries, typed errors and pagination:
+//
+//	c := client.New("https://formify.example.com/graphql", client.WithAPIKey(key))
+//	for form, err := range c.Forms(ctx, client.FormFilter{}) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(form.Title)
+//	}
+//
+// Receivers of webhooks check deliveries with VerifyWebhook.
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+// APIKeyHeader is the header the API reads the key from
+const APIKeyHeader = "api-key"
+
+// DefaultPageSize is the number of items the iterators fetch per request
+const DefaultPageSize = 100
+
+// Client calls the API. It is safe for concurrent use.
+type Client struct {
+	graphql  graphql.Client
+	retry    retryPolicy
+	pageSize int32
+}
+
+type options struct {
+	apiKey     string
+	httpClient *http.Client
+	userAgent  string
+	retry      retryPolicy
+	pageSize   int32
+}
+
+// ClientOption configures a Client. (Option is the choice option of a question.)
+type ClientOption func(*options)
+
+// WithAPIKey authenticates every request with the key.
+func WithAPIKey(key string) ClientOption {
+	return func(o *options) { o.apiKey = key }
+}
+
+// WithHTTPClient sends the requests through c instead of http.DefaultClient.
+func WithHTTPClient(c *http.Client) ClientOption {
+	return func(o *options) { o.httpClient = c }
+}
+
+// WithUserAgent sets the User-Agent of the requests.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(o *options) { o.userAgent = userAgent }
+}
+
+// WithRetries sets how many times a failed request is retried, 3 by default; 0 turns
+// retries off.
+func WithRetries(retries int) ClientOption {
+	return func(o *options) { o.retry.retries = max(retries, 0) }
+}
+
+// WithBackoff sets the delay before the first retry and the bound of the delay, which
+// doubles with every attempt. The defaults are 500ms and 30s.
+func WithBackoff(base, limit time.Duration) ClientOption {
+	return func(o *options) {
+		o.retry.base = base
+		o.retry.limit = limit
+	}
+}
+
+// WithPageSize sets the number of items the iterators fetch per request, between 1 and
+// 500.
+func WithPageSize(size int) ClientOption {
+	return func(o *options) { o.pageSize = int32(min(max(size, 1), 500)) }
+}
+
+// New returns a client of the GraphQL endpoint, e.g. https://formify.example.com/graphql.
+func New(endpoint string, opts ...ClientOption) *Client {
+	o := options{
+		httpClient: http.DefaultClient,
+		userAgent:  "formify-go-client",
+		retry:      retryPolicy{retries: 3, base: 500 * time.Millisecond, limit: 30 * time.Second},
+		pageSize:   DefaultPageSize,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	doer := &transport{client: o.httpClient, apiKey: o.apiKey, userAgent: o.userAgent}
+	return &Client{
+		graphql:  graphql.NewClient(endpoint, doer),
+		retry:    o.retry,
+		pageSize: o.pageSize,
+	}
+}
+
+// transport adds the authentication and client headers to every request.
+type transport struct {
+	client    *http.Client
+	apiKey    string
+	userAgent string
+}
+
+func (t *transport) Do(req *http.Request) (*http.Response, error) {
+	if t.apiKey != "" {
+		req.Header.Set(APIKeyHeader, t.apiKey)
+	}
+	req.Header.Set("User-Agent", t.userAgent)
+
+	resp, err := t.client.Do(req)
+	if err == nil {
+		recordRetryAfter(req, resp)
+	}
+	return resp, err
+}
+
+// query runs a read-only operation, retrying on any temporary failure.
+func (c *Client) query(ctx context.Context, call func(context.Context, graphql.Client) error) error {
+	return c.do(ctx, true, call)
+}
+
+// mutate runs an operation that changes data. It is retried only when the server
+// certainly did not run it, so a change is never applied twice.
+func (c *Client) mutate(ctx context.Context, call func(context.Context, graphql.Client) error) error {
+	return c.do(ctx, false, call)
+}
+
+func (c *Client) do(ctx context.Context, idempotent bool, call func(context.Context, graphql.Client) error) error {
+	for attempt := 0; ; attempt++ {
+		attemptCtx, retryAfter := withRetryAfter(ctx)
+		err := wrapError(call(attemptCtx, c.graphql))
+		if err == nil {
+			return nil
+		}
+
+		var apiErr *Error
+		if errors.As(err, &apiErr) {
+			apiErr.retryAfter = *retryAfter
+		}
+
+		delay, retry := c.retry.next(attempt, idempotent, err)
+		if !retry {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
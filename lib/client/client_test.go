@@ -0,0 +1,276 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// graphQLRequest is the body genqlient posts.
+type graphQLRequest struct {
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// fakeAPI is a GraphQL endpoint that answers every request with the next reply.
+type fakeAPI struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []graphQLRequest
+	headers  []http.Header
+	reply    func(n int, req graphQLRequest, w http.ResponseWriter)
+}
+
+func newFakeAPI(t *testing.T, reply func(n int, req graphQLRequest, w http.ResponseWriter)) *fakeAPI {
+	api := &fakeAPI{reply: reply}
+	api.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+
+		api.mu.Lock()
+		api.requests = append(api.requests, req)
+		api.headers = append(api.headers, r.Header.Clone())
+		n := len(api.requests)
+		api.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		api.reply(n, req, w)
+	}))
+	t.Cleanup(api.Close)
+	return api
+}
+
+func (a *fakeAPI) count() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.requests)
+}
+
+func writeData(w http.ResponseWriter, data interface{}) {
+	json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+}
+
+func writeErrors(w http.ResponseWriter, status int, errs ...map[string]interface{}) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{"errors": errs})
+}
+
+// fastRetries keeps the tests from waiting on the backoff.
+func fastRetries(retries int) []ClientOption {
+	return []ClientOption{WithRetries(retries), WithBackoff(time.Millisecond, 5*time.Millisecond)}
+}
+
+func TestClientSendsHeaders(t *testing.T) {
+	api := newFakeAPI(t, func(_ int, _ graphQLRequest, w http.ResponseWriter) {
+		writeData(w, map[string]interface{}{"form": map[string]interface{}{"id": "f1", "title": "Survey"}})
+	})
+
+	c := New(api.URL, WithAPIKey("fmk_secret"), WithUserAgent("test-agent"))
+	form, err := c.Form(context.Background(), "f1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if form.Id != "f1" || form.Title != "Survey" {
+		t.Errorf("form = %+v", form)
+	}
+	if got := api.headers[0].Get(APIKeyHeader); got != "fmk_secret" {
+		t.Errorf("%s = %q", APIKeyHeader, got)
+	}
+	if got := api.headers[0].Get("User-Agent"); got != "test-agent" {
+		t.Errorf("User-Agent = %q", got)
+	}
+}
+
+func TestClientRetriesThrottledRequests(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusServiceUnavailable} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			api := newFakeAPI(t, func(n int, _ graphQLRequest, w http.ResponseWriter) {
+				if n < 3 {
+					w.Header().Set("Retry-After", "1")
+					writeErrors(w, status, map[string]interface{}{"message": "slow down"})
+					return
+				}
+				writeData(w, map[string]interface{}{"deleteForm": true})
+			})
+
+			// A mutation is retried too: the server turned it away before running it
+			c := New(api.URL, fastRetries(3)...)
+			start := time.Now()
+			if err := c.DeleteForm(context.Background(), "f1"); err != nil {
+				t.Fatalf("DeleteForm() error = %v", err)
+			}
+			if api.count() != 3 {
+				t.Errorf("requests = %d, want 3", api.count())
+			}
+			// Retry-After is bounded by the backoff limit, so the test does not wait a second
+			if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+				t.Errorf("retries took %v", elapsed)
+			}
+		})
+	}
+}
+
+func TestClientGivesUpWhenRateLimited(t *testing.T) {
+	api := newFakeAPI(t, func(_ int, _ graphQLRequest, w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "1")
+		writeErrors(w, http.StatusTooManyRequests, map[string]interface{}{"message": "rate limit exceeded"})
+	})
+
+	c := New(api.URL, fastRetries(2)...)
+	_, err := c.Form(context.Background(), "f1")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Form() error = %v, want ErrRateLimited", err)
+	}
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.retryAfter != time.Second {
+		t.Errorf("error = %#v, want the Retry-After recorded", err)
+	}
+	if api.count() != 3 {
+		t.Errorf("requests = %d, want the first try and 2 retries", api.count())
+	}
+}
+
+func TestClientRetriesGatewayErrorsOfQueriesOnly(t *testing.T) {
+	badGateway := func(_ int, _ graphQLRequest, w http.ResponseWriter) {
+		writeErrors(w, http.StatusBadGateway)
+	}
+
+	api := newFakeAPI(t, badGateway)
+	c := New(api.URL, fastRetries(2)...)
+	if _, err := c.Form(context.Background(), "f1"); err == nil {
+		t.Fatal("Form() succeeded")
+	}
+	if api.count() != 3 {
+		t.Errorf("query requests = %d, want 3", api.count())
+	}
+
+	api = newFakeAPI(t, badGateway)
+	c = New(api.URL, fastRetries(2)...)
+	if err := c.DeleteForm(context.Background(), "f1"); err == nil {
+		t.Fatal("DeleteForm() succeeded")
+	}
+	if api.count() != 1 {
+		t.Errorf("mutation requests = %d, want 1: the server may have run it", api.count())
+	}
+}
+
+func TestClientDoesNotRetryOperationErrors(t *testing.T) {
+	api := newFakeAPI(t, func(_ int, _ graphQLRequest, w http.ResponseWriter) {
+		writeErrors(w, http.StatusOK, map[string]interface{}{"message": "access denied", "path": []string{"form"}})
+	})
+
+	c := New(api.URL, fastRetries(3)...)
+	if _, err := c.Form(context.Background(), "f1"); !errors.Is(err, ErrAccessDenied) {
+		t.Fatalf("Form() error = %v, want ErrAccessDenied", err)
+	}
+	if api.count() != 1 {
+		t.Errorf("requests = %d, want 1", api.count())
+	}
+}
+
+func TestClientStopsRetryingWhenCanceled(t *testing.T) {
+	api := newFakeAPI(t, func(_ int, _ graphQLRequest, w http.ResponseWriter) {
+		writeErrors(w, http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	c := New(api.URL, WithRetries(10), WithBackoff(time.Second, time.Second))
+	_, err := c.Form(ctx, "f1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Form() error = %v, want the deadline", err)
+	}
+	if api.count() != 1 {
+		t.Errorf("requests = %d, want 1", api.count())
+	}
+}
+
+func TestFormsPages(t *testing.T) {
+	tests := []struct {
+		total     int
+		wantPages int
+	}{
+		{total: 5, wantPages: 3},
+		{total: 4, wantPages: 3},
+		{total: 0, wantPages: 1},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.total), func(t *testing.T) {
+			api := newFakeAPI(t, func(_ int, req graphQLRequest, w http.ResponseWriter) {
+				limit, offset := int(req.Variables["limit"].(float64)), int(req.Variables["offset"].(float64))
+				forms := []map[string]interface{}{}
+				for i := offset; i < min(offset+limit, tt.total); i++ {
+					forms = append(forms, map[string]interface{}{"id": fmt.Sprintf("f%d", i)})
+				}
+				writeData(w, map[string]interface{}{"forms": forms})
+			})
+
+			c := New(api.URL, WithPageSize(2))
+			var ids []string
+			for form, err := range c.Forms(context.Background(), FormFilter{}) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				ids = append(ids, form.Id)
+			}
+
+			if len(ids) != tt.total {
+				t.Errorf("forms = %v, want %d", ids, tt.total)
+			}
+			for i, id := range ids {
+				if id != fmt.Sprintf("f%d", i) {
+					t.Errorf("forms[%d] = %s", i, id)
+				}
+			}
+			if api.count() != tt.wantPages {
+				t.Errorf("pages = %d, want %d", api.count(), tt.wantPages)
+			}
+			for i, req := range api.requests {
+				if req.Variables["limit"] != 2.0 || req.Variables["offset"] != float64(2*i) {
+					t.Errorf("page %d: limit %v offset %v", i, req.Variables["limit"], req.Variables["offset"])
+				}
+			}
+		})
+	}
+}
+
+func TestResponsesStopsAtBreakAndError(t *testing.T) {
+	api := newFakeAPI(t, func(n int, _ graphQLRequest, w http.ResponseWriter) {
+		if n > 1 {
+			writeErrors(w, http.StatusOK, map[string]interface{}{"message": "form not found"})
+			return
+		}
+		writeData(w, map[string]interface{}{"formResponses": []map[string]interface{}{{"id": "r1"}, {"id": "r2"}}})
+	})
+	c := New(api.URL, WithPageSize(2))
+
+	for range c.Responses(context.Background(), "f1", nil) {
+		break
+	}
+	if api.count() != 1 {
+		t.Errorf("requests after break = %d, want 1", api.count())
+	}
+
+	var ids []string
+	var last error
+	for response, err := range c.Responses(context.Background(), "f1", nil) {
+		if err != nil {
+			last = err
+			continue
+		}
+		ids = append(ids, response.Id)
+	}
+	// The first page was served by request 1 above; this iteration starts at request 2
+	if len(ids) != 0 || !errors.Is(last, ErrNotFound) {
+		t.Errorf("responses = %v, error = %v, want ErrNotFound", ids, last)
+	}
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Sentinel errors an *Error matches with errors.Is.
+var (
+	// ErrUnauthorized means the API key is missing, unknown or expired
+	ErrUnauthorized = errors.New("not authenticated")
+	// ErrAccessDenied means the key is valid but may not do this
+	ErrAccessDenied = errors.New("access denied")
+	// ErrMissingScope means the key lacks the scope of the operation; an *Error that
+	// matches it also matches ErrAccessDenied
+	ErrMissingScope = errors.New("missing api key scope")
+	// ErrNotFound means the form, response or other object does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrVersionConflict means the form changed since the version sent with the change
+	ErrVersionConflict = errors.New("version conflict")
+	// ErrInvalidDocument means a form document failed validation
+	ErrInvalidDocument = errors.New("invalid form document")
+	// ErrRateLimited means the server asked to slow down and the retries ran out
+	ErrRateLimited = errors.New("rate limited")
+)
+
+// Error is an error returned by the API: a GraphQL error of the operation, or a failed
+// HTTP request.
+type Error struct {
+	// StatusCode is the HTTP status, 200 for errors of the operation itself
+	StatusCode int
+	Message    string
+	// Code is the "code" extension, e.g. VERSION_CONFLICT, when the server sets one
+	Code string
+	// Path is the field the error is about, e.g. "form.questions"
+	Path       string
+	Extensions map[string]interface{}
+	// Errors holds all errors of the response; the fields above describe the first
+	Errors gqlerror.List
+
+	// retryAfter is the delay the server asked for with a 429 or 503
+	retryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	message := e.Message
+	if e.Path != "" {
+		message = e.Path + ": " + message
+	}
+	switch {
+	case e.StatusCode == http.StatusOK:
+	case message == "":
+		message = http.StatusText(e.StatusCode)
+	default:
+		message = http.StatusText(e.StatusCode) + ": " + message
+	}
+	if len(e.Errors) > 1 {
+		message += " (and more)"
+	}
+	return "formify: " + message
+}
+
+// Is reports whether the error is of one of the kinds of the sentinel errors. The API
+// sets codes only on some errors, so the others are told apart by their messages.
+func (e *Error) Is(target error) bool {
+	message := strings.ToLower(e.Message)
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized ||
+			message == "not authenticated" || message == "authorization required"
+	case ErrAccessDenied:
+		return e.StatusCode == http.StatusForbidden || message == "access denied" || e.Code == "MISSING_SCOPE"
+	case ErrMissingScope:
+		return e.Code == "MISSING_SCOPE"
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || strings.HasSuffix(message, "not found")
+	case ErrVersionConflict:
+		return e.Code == "VERSION_CONFLICT"
+	case ErrInvalidDocument:
+		return e.Code == "INVALID_FORM_DOCUMENT"
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// CurrentVersion is the version of the form the server holds, reported with a
+// version conflict.
+func (e *Error) CurrentVersion() (int32, bool) {
+	if version, ok := e.Extensions["currentVersion"].(float64); ok {
+		return int32(version), true
+	}
+	return 0, false
+}
+
+// wrapError turns the errors of the generated code into *Error; transport errors are
+// returned as they are.
+func wrapError(err error) error {
+	var list gqlerror.List
+	var httpErr *graphql.HTTPError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &httpErr):
+		return newError(httpErr.StatusCode, httpErr.Response.Errors)
+	case errors.As(err, &list):
+		return newError(http.StatusOK, list)
+	}
+	return err
+}
+
+func newError(status int, list gqlerror.List) *Error {
+	e := &Error{StatusCode: status, Errors: list}
+	if len(list) == 0 {
+		return e
+	}
+
+	first := list[0]
+	e.Message = first.Message
+	e.Extensions = first.Extensions
+	if code, ok := first.Extensions["code"].(string); ok {
+		e.Code = code
+	}
+	if len(first.Path) > 0 {
+		e.Path = first.Path.String()
+	}
+	return e
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestErrorMapping(t *testing.T) {
+	all := []error{ErrUnauthorized, ErrAccessDenied, ErrMissingScope, ErrNotFound, ErrVersionConflict, ErrInvalidDocument, ErrRateLimited}
+
+	tests := []struct {
+		name   string
+		status int
+		errors []map[string]interface{}
+		want   []error
+	}{
+		{
+			name:   "unauthorized status",
+			status: http.StatusUnauthorized,
+			want:   []error{ErrUnauthorized},
+		},
+		{
+			name:   "authorization required",
+			status: http.StatusOK,
+			errors: []map[string]interface{}{{"message": "authorization required"}},
+			want:   []error{ErrUnauthorized},
+		},
+		{
+			name:   "missing scope",
+			status: http.StatusOK,
+			errors: []map[string]interface{}{{"message": "api key lacks scope forms:write", "extensions": map[string]interface{}{"code": "MISSING_SCOPE"}}},
+			want:   []error{ErrMissingScope, ErrAccessDenied},
+		},
+		{
+			name:   "forbidden status",
+			status: http.StatusForbidden,
+			want:   []error{ErrAccessDenied},
+		},
+		{
+			name:   "not found",
+			status: http.StatusOK,
+			errors: []map[string]interface{}{{"message": "Form not found", "path": []string{"form"}}},
+			want:   []error{ErrNotFound},
+		},
+		{
+			name:   "version conflict",
+			status: http.StatusOK,
+			errors: []map[string]interface{}{{"message": "form was changed by someone else", "extensions": map[string]interface{}{"code": "VERSION_CONFLICT", "currentVersion": 7}}},
+			want:   []error{ErrVersionConflict},
+		},
+		{
+			name:   "invalid document",
+			status: http.StatusOK,
+			errors: []map[string]interface{}{{"message": "invalid form document", "extensions": map[string]interface{}{"code": "INVALID_FORM_DOCUMENT"}}},
+			want:   []error{ErrInvalidDocument},
+		},
+		{
+			name:   "other error",
+			status: http.StatusOK,
+			errors: []map[string]interface{}{{"message": "title must not be empty"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeAPI(t, func(_ int, _ graphQLRequest, w http.ResponseWriter) {
+				writeErrors(w, tt.status, tt.errors...)
+			})
+
+			_, err := New(api.URL, WithRetries(0)).Form(context.Background(), "f1")
+			var apiErr *Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error = %v, want *Error", err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, tt.status)
+			}
+			for _, target := range all {
+				want := false
+				for _, w := range tt.want {
+					want = want || w == target
+				}
+				if got := errors.Is(err, target); got != want {
+					t.Errorf("errors.Is(%v) = %v, want %v", target, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestErrorDetails(t *testing.T) {
+	api := newFakeAPI(t, func(_ int, _ graphQLRequest, w http.ResponseWriter) {
+		writeErrors(w, http.StatusOK,
+			map[string]interface{}{
+				"message":    "form was changed by someone else",
+				"path":       []interface{}{"updateForm", "questions", 1},
+				"extensions": map[string]interface{}{"code": "VERSION_CONFLICT", "currentVersion": 7},
+			},
+			map[string]interface{}{"message": "second"},
+		)
+	})
+
+	_, err := New(api.URL).UpdateForm(context.Background(), "f1", FormUpdateInput{Version: 6})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error = %v, want *Error", err)
+	}
+	if apiErr.Code != "VERSION_CONFLICT" || apiErr.Path != "updateForm.questions[1]" || len(apiErr.Errors) != 2 {
+		t.Errorf("error = %+v", apiErr)
+	}
+	if version, ok := apiErr.CurrentVersion(); !ok || version != 7 {
+		t.Errorf("CurrentVersion() = %d, %v, want 7", version, ok)
+	}
+	if want := "formify: updateForm.questions[1]: form was changed by someone else (and more)"; apiErr.Error() != want {
+		t.Errorf("Error() = %q, want %q", apiErr.Error(), want)
+	}
+	if api.count() != 1 {
+		t.Errorf("requests = %d, want 1", api.count())
+	}
+}
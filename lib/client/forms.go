@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"iter"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+// FormFilter narrows the forms Forms lists. Zero fields do not filter.
+type FormFilter struct {
+	OwnerID     *string
+	Access      *FormAccess
+	WorkspaceID *string
+	FolderID    *string
+	Tag         *string
+	Starred     *bool
+	Sort        *FormSort
+}
+
+// Forms lists the forms matching the filter, fetching them page by page as the loop
+// advances. The iteration stops at the first error, which it yields.
+func (c *Client) Forms(ctx context.Context, filter FormFilter) iter.Seq2[*Form, error] {
+	return paginate(c.pageSize, func(limit, offset int32) ([]*Form, error) {
+		var forms []*Form
+		err := c.query(ctx, func(ctx context.Context, g graphql.Client) error {
+			resp, err := listForms(ctx, g, filter.OwnerID, filter.Access, filter.WorkspaceID,
+				filter.FolderID, filter.Tag, filter.Starred, filter.Sort, limit, offset)
+			if err == nil {
+				forms = resp.Forms
+			}
+			return err
+		})
+		return forms, err
+	})
+}
+
+// Form returns a form with its questions.
+func (c *Client) Form(ctx context.Context, id string) (*Form, error) {
+	var form *Form
+	err := c.query(ctx, func(ctx context.Context, g graphql.Client) error {
+		resp, err := getForm(ctx, g, id)
+		if err == nil {
+			form = resp.Form
+		}
+		return err
+	})
+	return found(form, err)
+}
+
+// FormByKey returns the form with the external key in the workspace, or among the
+// personal forms when workspaceID is nil.
+func (c *Client) FormByKey(ctx context.Context, key string, workspaceID *string) (*Form, error) {
+	var form *Form
+	err := c.query(ctx, func(ctx context.Context, g graphql.Client) error {
+		resp, err := getFormByKey(ctx, g, key, workspaceID)
+		if err == nil {
+			form = resp.FormByKey
+		}
+		return err
+	})
+	return found(form, err)
+}
+
+// CreateForm creates a personal form, or a form of the workspace in input.WorkspaceId.
+func (c *Client) CreateForm(ctx context.Context, input FormInput) (*Form, error) {
+	var form *Form
+	err := c.mutate(ctx, func(ctx context.Context, g graphql.Client) error {
+		resp, err := createForm(ctx, g, &input)
+		if err == nil {
+			form = resp.CreateForm
+		}
+		return err
+	})
+	return form, err
+}
+
+// UpdateForm changes the fields set in the input. input.Version is the version of the
+// form the change is based on; the change fails with ErrVersionConflict if the form
+// changed since that version.
+func (c *Client) UpdateForm(ctx context.Context, id string, input FormUpdateInput) (*Form, error) {
+	var form *Form
+	err := c.mutate(ctx, func(ctx context.Context, g graphql.Client) error {
+		resp, err := updateForm(ctx, g, id, &input)
+		if err == nil {
+			form = resp.UpdateForm
+		}
+		return err
+	})
+	return form, err
+}
+
+// DeleteForm moves a form to the trash.
+func (c *Client) DeleteForm(ctx context.Context, id string) error {
+	return c.mutate(ctx, func(ctx context.Context, g graphql.Client) error {
+		_, err := deleteForm(ctx, g, id)
+		return err
+	})
+}
+
+// CloseForm stops accepting responses.
+func (c *Client) CloseForm(ctx context.Context, id string) (*Form, error) {
+	var form *Form
+	err := c.mutate(ctx, func(ctx context.Context, g graphql.Client) error {
+		resp, err := closeForm(ctx, g, id)
+		if err == nil {
+			form = resp.CloseForm
+		}
+		return err
+	})
+	return form, err
+}
+
+// ReopenForm accepts responses to a closed form again.
+func (c *Client) ReopenForm(ctx context.Context, id string) (*Form, error) {
+	var form *Form
+	err := c.mutate(ctx, func(ctx context.Context, g graphql.Client) error {
+		resp, err := reopenForm(ctx, g, id)
+		if err == nil {
+			form = resp.ReopenForm
+		}
+		return err
+	})
+	return form, err
+}
+
+// ExportForm returns the form document of a form: its settings, questions and options
+// without responses.
+func (c *Client) ExportForm(ctx context.Context, id string, format FormDocumentFormat) (string, error) {
+	var document string
+	err := c.query(ctx, func(ctx context.Context, g graphql.Client) error {
+		resp, err := exportForm(ctx, g, id, format)
+		if err == nil {
+			document = resp.ExportForm
+		}
+		return err
+	})
+	return document, err
+}
+
+// ApplyForm creates or updates the form with the key of the document, in the workspace
+// or among the personal forms when workspaceID is nil. Applying the same document again
+// changes nothing, so the call is safe to repeat. An invalid document fails with
+// ErrInvalidDocument.
+func (c *Client) ApplyForm(ctx context.Context, document string, format FormDocumentFormat, workspaceID *string) (*FormApply, error) {
+	var result *FormApply
+	// Repeating the call is safe, so it is retried like a query
+	err := c.query(ctx, func(ctx context.Context, g graphql.Client) error {
+		resp, err := applyForm(ctx, g, document, &format, workspaceID)
+		if err == nil {
+			result = resp.ApplyForm
+		}
+		return err
+	})
+	return result, err
+}
+
+// paginate yields the items of the pages fetch returns, until a page is short.
+func paginate[T any](size int32, fetch func(limit, offset int32) ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for offset := int32(0); ; offset += size {
+			page, err := fetch(size, offset)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if int32(len(page)) < size {
+				return
+			}
+		}
+	}
+}
+
+// found turns the null of a nullable field into ErrNotFound.
+func found[T any](v *T, err error) (*T, error) {
+	if err == nil && v == nil {
+		return nil, ErrNotFound
+	}
+	return v, err
+}
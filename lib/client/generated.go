@@ -0,0 +1,2388 @@
+// Code generated by github.com/Khan/genqlient, DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+// Answer includes the GraphQL fields of Answer requested by the fragment Answer.
+type Answer struct {
+	Id              string    `json:"id"`
+	QuestionId      string    `json:"questionId"`
+	TextValue       *string   `json:"textValue"`
+	BoolValue       *bool     `json:"boolValue"`
+	NumberValue     *float64  `json:"numberValue"`
+	DateValue       *string   `json:"dateValue"`
+	SelectedOptions []*Option `json:"selectedOptions"`
+}
+
+// GetId returns Answer.Id, and is useful for accessing the field via an interface.
+func (v *Answer) GetId() string { return v.Id }
+
+// GetQuestionId returns Answer.QuestionId, and is useful for accessing the field via an interface.
+func (v *Answer) GetQuestionId() string { return v.QuestionId }
+
+// GetTextValue returns Answer.TextValue, and is useful for accessing the field via an interface.
+func (v *Answer) GetTextValue() *string { return v.TextValue }
+
+// GetBoolValue returns Answer.BoolValue, and is useful for accessing the field via an interface.
+func (v *Answer) GetBoolValue() *bool { return v.BoolValue }
+
+// GetNumberValue returns Answer.NumberValue, and is useful for accessing the field via an interface.
+func (v *Answer) GetNumberValue() *float64 { return v.NumberValue }
+
+// GetDateValue returns Answer.DateValue, and is useful for accessing the field via an interface.
+func (v *Answer) GetDateValue() *string { return v.DateValue }
+
+// GetSelectedOptions returns Answer.SelectedOptions, and is useful for accessing the field via an interface.
+func (v *Answer) GetSelectedOptions() []*Option { return v.SelectedOptions }
+
+type AnswerInput struct {
+	QuestionId  string   `json:"questionId"`
+	TextValue   *string  `json:"textValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	NumberValue *float64 `json:"numberValue,omitempty"`
+	DateValue   *string  `json:"dateValue,omitempty"`
+	OptionIds   []string `json:"optionIds,omitempty"`
+}
+
+// GetQuestionId returns AnswerInput.QuestionId, and is useful for accessing the field via an interface.
+func (v *AnswerInput) GetQuestionId() string { return v.QuestionId }
+
+// GetTextValue returns AnswerInput.TextValue, and is useful for accessing the field via an interface.
+func (v *AnswerInput) GetTextValue() *string { return v.TextValue }
+
+// GetBoolValue returns AnswerInput.BoolValue, and is useful for accessing the field via an interface.
+func (v *AnswerInput) GetBoolValue() *bool { return v.BoolValue }
+
+// GetNumberValue returns AnswerInput.NumberValue, and is useful for accessing the field via an interface.
+func (v *AnswerInput) GetNumberValue() *float64 { return v.NumberValue }
+
+// GetDateValue returns AnswerInput.DateValue, and is useful for accessing the field via an interface.
+func (v *AnswerInput) GetDateValue() *string { return v.DateValue }
+
+// GetOptionIds returns AnswerInput.OptionIds, and is useful for accessing the field via an interface.
+func (v *AnswerInput) GetOptionIds() []string { return v.OptionIds }
+
+// CrossTab includes the requested fields of the GraphQL type CrossTab.
+type CrossTab struct {
+	FormId           string              `json:"formId"`
+	RowQuestion      *Question           `json:"rowQuestion"`
+	ColumnQuestion   *Question           `json:"columnQuestion"`
+	Rows             []*CrossTabCategory `json:"rows"`
+	Columns          []*CrossTabCategory `json:"columns"`
+	Cells            []*CrossTabCell     `json:"cells"`
+	Total            int32               `json:"total"`
+	ChiSquare        *float64            `json:"chiSquare"`
+	DegreesOfFreedom int32               `json:"degreesOfFreedom"`
+	PValue           *float64            `json:"pValue"`
+}
+
+// GetFormId returns CrossTab.FormId, and is useful for accessing the field via an interface.
+func (v *CrossTab) GetFormId() string { return v.FormId }
+
+// GetRowQuestion returns CrossTab.RowQuestion, and is useful for accessing the field via an interface.
+func (v *CrossTab) GetRowQuestion() *Question { return v.RowQuestion }
+
+// GetColumnQuestion returns CrossTab.ColumnQuestion, and is useful for accessing the field via an interface.
+func (v *CrossTab) GetColumnQuestion() *Question { return v.ColumnQuestion }
+
+// GetRows returns CrossTab.Rows, and is useful for accessing the field via an interface.
+func (v *CrossTab) GetRows() []*CrossTabCategory { return v.Rows }
+
+// GetColumns returns CrossTab.Columns, and is useful for accessing the field via an interface.
+func (v *CrossTab) GetColumns() []*CrossTabCategory { return v.Columns }
+
+// GetCells returns CrossTab.Cells, and is useful for accessing the field via an interface.
+func (v *CrossTab) GetCells() []*CrossTabCell { return v.Cells }
+
+// GetTotal returns CrossTab.Total, and is useful for accessing the field via an interface.
+func (v *CrossTab) GetTotal() int32 { return v.Total }
+
+// GetChiSquare returns CrossTab.ChiSquare, and is useful for accessing the field via an interface.
+func (v *CrossTab) GetChiSquare() *float64 { return v.ChiSquare }
+
+// GetDegreesOfFreedom returns CrossTab.DegreesOfFreedom, and is useful for accessing the field via an interface.
+func (v *CrossTab) GetDegreesOfFreedom() int32 { return v.DegreesOfFreedom }
+
+// GetPValue returns CrossTab.PValue, and is useful for accessing the field via an interface.
+func (v *CrossTab) GetPValue() *float64 { return v.PValue }
+
+// CrossTabCategory includes the requested fields of the GraphQL type CrossTabCategory.
+type CrossTabCategory struct {
+	Key     string  `json:"key"`
+	Label   string  `json:"label"`
+	Total   int32   `json:"total"`
+	Percent float64 `json:"percent"`
+}
+
+// GetKey returns CrossTabCategory.Key, and is useful for accessing the field via an interface.
+func (v *CrossTabCategory) GetKey() string { return v.Key }
+
+// GetLabel returns CrossTabCategory.Label, and is useful for accessing the field via an interface.
+func (v *CrossTabCategory) GetLabel() string { return v.Label }
+
+// GetTotal returns CrossTabCategory.Total, and is useful for accessing the field via an interface.
+func (v *CrossTabCategory) GetTotal() int32 { return v.Total }
+
+// GetPercent returns CrossTabCategory.Percent, and is useful for accessing the field via an interface.
+func (v *CrossTabCategory) GetPercent() float64 { return v.Percent }
+
+// CrossTabCell includes the requested fields of the GraphQL type CrossTabCell.
+type CrossTabCell struct {
+	RowKey        string  `json:"rowKey"`
+	ColumnKey     string  `json:"columnKey"`
+	Count         int32   `json:"count"`
+	Expected      float64 `json:"expected"`
+	RowPercent    float64 `json:"rowPercent"`
+	ColumnPercent float64 `json:"columnPercent"`
+	TotalPercent  float64 `json:"totalPercent"`
+}
+
+// GetRowKey returns CrossTabCell.RowKey, and is useful for accessing the field via an interface.
+func (v *CrossTabCell) GetRowKey() string { return v.RowKey }
+
+// GetColumnKey returns CrossTabCell.ColumnKey, and is useful for accessing the field via an interface.
+func (v *CrossTabCell) GetColumnKey() string { return v.ColumnKey }
+
+// GetCount returns CrossTabCell.Count, and is useful for accessing the field via an interface.
+func (v *CrossTabCell) GetCount() int32 { return v.Count }
+
+// GetExpected returns CrossTabCell.Expected, and is useful for accessing the field via an interface.
+func (v *CrossTabCell) GetExpected() float64 { return v.Expected }
+
+// GetRowPercent returns CrossTabCell.RowPercent, and is useful for accessing the field via an interface.
+func (v *CrossTabCell) GetRowPercent() float64 { return v.RowPercent }
+
+// GetColumnPercent returns CrossTabCell.ColumnPercent, and is useful for accessing the field via an interface.
+func (v *CrossTabCell) GetColumnPercent() float64 { return v.ColumnPercent }
+
+// GetTotalPercent returns CrossTabCell.TotalPercent, and is useful for accessing the field via an interface.
+func (v *CrossTabCell) GetTotalPercent() float64 { return v.TotalPercent }
+
+type CrossTabFilterInput struct {
+	QuestionId string   `json:"questionId"`
+	OptionIds  []string `json:"optionIds,omitempty"`
+	BoolValue  *bool    `json:"boolValue,omitempty"`
+	NumberMin  *float64 `json:"numberMin,omitempty"`
+	NumberMax  *float64 `json:"numberMax,omitempty"`
+	TextValue  *string  `json:"textValue,omitempty"`
+}
+
+// GetQuestionId returns CrossTabFilterInput.QuestionId, and is useful for accessing the field via an interface.
+func (v *CrossTabFilterInput) GetQuestionId() string { return v.QuestionId }
+
+// GetOptionIds returns CrossTabFilterInput.OptionIds, and is useful for accessing the field via an interface.
+func (v *CrossTabFilterInput) GetOptionIds() []string { return v.OptionIds }
+
+// GetBoolValue returns CrossTabFilterInput.BoolValue, and is useful for accessing the field via an interface.
+func (v *CrossTabFilterInput) GetBoolValue() *bool { return v.BoolValue }
+
+// GetNumberMin returns CrossTabFilterInput.NumberMin, and is useful for accessing the field via an interface.
+func (v *CrossTabFilterInput) GetNumberMin() *float64 { return v.NumberMin }
+
+// GetNumberMax returns CrossTabFilterInput.NumberMax, and is useful for accessing the field via an interface.
+func (v *CrossTabFilterInput) GetNumberMax() *float64 { return v.NumberMax }
+
+// GetTextValue returns CrossTabFilterInput.TextValue, and is useful for accessing the field via an interface.
+func (v *CrossTabFilterInput) GetTextValue() *string { return v.TextValue }
+
+// Form includes the GraphQL fields of Form requested by the fragment Form.
+type Form struct {
+	Id                    string                `json:"id"`
+	OwnerId               string                `json:"ownerId"`
+	WorkspaceId           *string               `json:"workspaceId"`
+	FolderId              *string               `json:"folderId"`
+	Tags                  []string              `json:"tags"`
+	IsTemplate            bool                  `json:"isTemplate"`
+	ExternalKey           *string               `json:"externalKey"`
+	Title                 string                `json:"title"`
+	Description           string                `json:"description"`
+	Access                FormAccess            `json:"access"`
+	CreatedAt             string                `json:"createdAt"`
+	UpdatedAt             string                `json:"updatedAt"`
+	AllowResponseEditing  bool                  `json:"allowResponseEditing"`
+	ResponseEditDeadline  *string               `json:"responseEditDeadline"`
+	ClosedAt              *string               `json:"closedAt"`
+	ResponseNotifications ResponseNotifications `json:"responseNotifications"`
+	ResponseStatuses      []string              `json:"responseStatuses"`
+	Version               int32                 `json:"version"`
+	Questions             []*Question           `json:"questions"`
+}
+
+// GetId returns Form.Id, and is useful for accessing the field via an interface.
+func (v *Form) GetId() string { return v.Id }
+
+// GetOwnerId returns Form.OwnerId, and is useful for accessing the field via an interface.
+func (v *Form) GetOwnerId() string { return v.OwnerId }
+
+// GetWorkspaceId returns Form.WorkspaceId, and is useful for accessing the field via an interface.
+func (v *Form) GetWorkspaceId() *string { return v.WorkspaceId }
+
+// GetFolderId returns Form.FolderId, and is useful for accessing the field via an interface.
+func (v *Form) GetFolderId() *string { return v.FolderId }
+
+// GetTags returns Form.Tags, and is useful for accessing the field via an interface.
+func (v *Form) GetTags() []string { return v.Tags }
+
+// GetIsTemplate returns Form.IsTemplate, and is useful for accessing the field via an interface.
+func (v *Form) GetIsTemplate() bool { return v.IsTemplate }
+
+// GetExternalKey returns Form.ExternalKey, and is useful for accessing the field via an interface.
+func (v *Form) GetExternalKey() *string { return v.ExternalKey }
+
+// GetTitle returns Form.Title, and is useful for accessing the field via an interface.
+func (v *Form) GetTitle() string { return v.Title }
+
+// GetDescription returns Form.Description, and is useful for accessing the field via an interface.
+func (v *Form) GetDescription() string { return v.Description }
+
+// GetAccess returns Form.Access, and is useful for accessing the field via an interface.
+func (v *Form) GetAccess() FormAccess { return v.Access }
+
+// GetCreatedAt returns Form.CreatedAt, and is useful for accessing the field via an interface.
+func (v *Form) GetCreatedAt() string { return v.CreatedAt }
+
+// GetUpdatedAt returns Form.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *Form) GetUpdatedAt() string { return v.UpdatedAt }
+
+// GetAllowResponseEditing returns Form.AllowResponseEditing, and is useful for accessing the field via an interface.
+func (v *Form) GetAllowResponseEditing() bool { return v.AllowResponseEditing }
+
+// GetResponseEditDeadline returns Form.ResponseEditDeadline, and is useful for accessing the field via an interface.
+func (v *Form) GetResponseEditDeadline() *string { return v.ResponseEditDeadline }
+
+// GetClosedAt returns Form.ClosedAt, and is useful for accessing the field via an interface.
+func (v *Form) GetClosedAt() *string { return v.ClosedAt }
+
+// GetResponseNotifications returns Form.ResponseNotifications, and is useful for accessing the field via an interface.
+func (v *Form) GetResponseNotifications() ResponseNotifications { return v.ResponseNotifications }
+
+// GetResponseStatuses returns Form.ResponseStatuses, and is useful for accessing the field via an interface.
+func (v *Form) GetResponseStatuses() []string { return v.ResponseStatuses }
+
+// GetVersion returns Form.Version, and is useful for accessing the field via an interface.
+func (v *Form) GetVersion() int32 { return v.Version }
+
+// GetQuestions returns Form.Questions, and is useful for accessing the field via an interface.
+func (v *Form) GetQuestions() []*Question { return v.Questions }
+
+type FormAccess string
+
+const (
+	FormAccessPrivate FormAccess = "PRIVATE"
+	FormAccessByLink  FormAccess = "BY_LINK"
+	FormAccessPublic  FormAccess = "PUBLIC"
+)
+
+var AllFormAccess = []FormAccess{
+	FormAccessPrivate,
+	FormAccessByLink,
+	FormAccessPublic,
+}
+
+// FormApply includes the requested fields of the GraphQL type FormApply.
+type FormApply struct {
+	Form   *Form           `json:"form"`
+	Action FormApplyAction `json:"action"`
+}
+
+// GetForm returns FormApply.Form, and is useful for accessing the field via an interface.
+func (v *FormApply) GetForm() *Form { return v.Form }
+
+// GetAction returns FormApply.Action, and is useful for accessing the field via an interface.
+func (v *FormApply) GetAction() FormApplyAction { return v.Action }
+
+type FormApplyAction string
+
+const (
+	FormApplyActionCreated   FormApplyAction = "CREATED"
+	FormApplyActionUpdated   FormApplyAction = "UPDATED"
+	FormApplyActionUnchanged FormApplyAction = "UNCHANGED"
+)
+
+var AllFormApplyAction = []FormApplyAction{
+	FormApplyActionCreated,
+	FormApplyActionUpdated,
+	FormApplyActionUnchanged,
+}
+
+type FormDocumentFormat string
+
+const (
+	FormDocumentFormatJson FormDocumentFormat = "JSON"
+	FormDocumentFormatYaml FormDocumentFormat = "YAML"
+)
+
+var AllFormDocumentFormat = []FormDocumentFormat{
+	FormDocumentFormatJson,
+	FormDocumentFormatYaml,
+}
+
+type FormInput struct {
+	WorkspaceId           *string                `json:"workspaceId,omitempty"`
+	FolderId              *string                `json:"folderId,omitempty"`
+	Tags                  []string               `json:"tags,omitempty"`
+	Title                 string                 `json:"title"`
+	Description           *string                `json:"description,omitempty"`
+	Access                *FormAccess            `json:"access,omitempty"`
+	Questions             []*QuestionInput       `json:"questions,omitempty"`
+	AllowResponseEditing  *bool                  `json:"allowResponseEditing,omitempty"`
+	ResponseEditDeadline  *string                `json:"responseEditDeadline,omitempty"`
+	ResponseNotifications *ResponseNotifications `json:"responseNotifications,omitempty"`
+}
+
+// GetWorkspaceId returns FormInput.WorkspaceId, and is useful for accessing the field via an interface.
+func (v *FormInput) GetWorkspaceId() *string { return v.WorkspaceId }
+
+// GetFolderId returns FormInput.FolderId, and is useful for accessing the field via an interface.
+func (v *FormInput) GetFolderId() *string { return v.FolderId }
+
+// GetTags returns FormInput.Tags, and is useful for accessing the field via an interface.
+func (v *FormInput) GetTags() []string { return v.Tags }
+
+// GetTitle returns FormInput.Title, and is useful for accessing the field via an interface.
+func (v *FormInput) GetTitle() string { return v.Title }
+
+// GetDescription returns FormInput.Description, and is useful for accessing the field via an interface.
+func (v *FormInput) GetDescription() *string { return v.Description }
+
+// GetAccess returns FormInput.Access, and is useful for accessing the field via an interface.
+func (v *FormInput) GetAccess() *FormAccess { return v.Access }
+
+// GetQuestions returns FormInput.Questions, and is useful for accessing the field via an interface.
+func (v *FormInput) GetQuestions() []*QuestionInput { return v.Questions }
+
+// GetAllowResponseEditing returns FormInput.AllowResponseEditing, and is useful for accessing the field via an interface.
+func (v *FormInput) GetAllowResponseEditing() *bool { return v.AllowResponseEditing }
+
+// GetResponseEditDeadline returns FormInput.ResponseEditDeadline, and is useful for accessing the field via an interface.
+func (v *FormInput) GetResponseEditDeadline() *string { return v.ResponseEditDeadline }
+
+// GetResponseNotifications returns FormInput.ResponseNotifications, and is useful for accessing the field via an interface.
+func (v *FormInput) GetResponseNotifications() *ResponseNotifications { return v.ResponseNotifications }
+
+// FormResponse includes the GraphQL fields of FormResponse requested by the fragment FormResponse.
+type FormResponse struct {
+	Id         string    `json:"id"`
+	FormId     string    `json:"formId"`
+	CreatedAt  string    `json:"createdAt"`
+	UpdatedAt  *string   `json:"updatedAt"`
+	Status     string    `json:"status"`
+	AssigneeId *string   `json:"assigneeId"`
+	Tags       []string  `json:"tags"`
+	EditToken  *string   `json:"editToken"`
+	Answers    []*Answer `json:"answers"`
+}
+
+// GetId returns FormResponse.Id, and is useful for accessing the field via an interface.
+func (v *FormResponse) GetId() string { return v.Id }
+
+// GetFormId returns FormResponse.FormId, and is useful for accessing the field via an interface.
+func (v *FormResponse) GetFormId() string { return v.FormId }
+
+// GetCreatedAt returns FormResponse.CreatedAt, and is useful for accessing the field via an interface.
+func (v *FormResponse) GetCreatedAt() string { return v.CreatedAt }
+
+// GetUpdatedAt returns FormResponse.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *FormResponse) GetUpdatedAt() *string { return v.UpdatedAt }
+
+// GetStatus returns FormResponse.Status, and is useful for accessing the field via an interface.
+func (v *FormResponse) GetStatus() string { return v.Status }
+
+// GetAssigneeId returns FormResponse.AssigneeId, and is useful for accessing the field via an interface.
+func (v *FormResponse) GetAssigneeId() *string { return v.AssigneeId }
+
+// GetTags returns FormResponse.Tags, and is useful for accessing the field via an interface.
+func (v *FormResponse) GetTags() []string { return v.Tags }
+
+// GetEditToken returns FormResponse.EditToken, and is useful for accessing the field via an interface.
+func (v *FormResponse) GetEditToken() *string { return v.EditToken }
+
+// GetAnswers returns FormResponse.Answers, and is useful for accessing the field via an interface.
+func (v *FormResponse) GetAnswers() []*Answer { return v.Answers }
+
+type FormResponseFilter struct {
+	Statuses   []string `json:"statuses,omitempty"`
+	AssigneeId *string  `json:"assigneeId,omitempty"`
+	Unassigned *bool    `json:"unassigned,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+}
+
+// GetStatuses returns FormResponseFilter.Statuses, and is useful for accessing the field via an interface.
+func (v *FormResponseFilter) GetStatuses() []string { return v.Statuses }
+
+// GetAssigneeId returns FormResponseFilter.AssigneeId, and is useful for accessing the field via an interface.
+func (v *FormResponseFilter) GetAssigneeId() *string { return v.AssigneeId }
+
+// GetUnassigned returns FormResponseFilter.Unassigned, and is useful for accessing the field via an interface.
+func (v *FormResponseFilter) GetUnassigned() *bool { return v.Unassigned }
+
+// GetTags returns FormResponseFilter.Tags, and is useful for accessing the field via an interface.
+func (v *FormResponseFilter) GetTags() []string { return v.Tags }
+
+type FormResponseInput struct {
+	FormId         string         `json:"formId"`
+	Answers        []*AnswerInput `json:"answers,omitempty"`
+	DraftToken     *string        `json:"draftToken,omitempty"`
+	IdempotencyKey *string        `json:"idempotencyKey,omitempty"`
+	SubmittedAt    *string        `json:"submittedAt,omitempty"`
+}
+
+// GetFormId returns FormResponseInput.FormId, and is useful for accessing the field via an interface.
+func (v *FormResponseInput) GetFormId() string { return v.FormId }
+
+// GetAnswers returns FormResponseInput.Answers, and is useful for accessing the field via an interface.
+func (v *FormResponseInput) GetAnswers() []*AnswerInput { return v.Answers }
+
+// GetDraftToken returns FormResponseInput.DraftToken, and is useful for accessing the field via an interface.
+func (v *FormResponseInput) GetDraftToken() *string { return v.DraftToken }
+
+// GetIdempotencyKey returns FormResponseInput.IdempotencyKey, and is useful for accessing the field via an interface.
+func (v *FormResponseInput) GetIdempotencyKey() *string { return v.IdempotencyKey }
+
+// GetSubmittedAt returns FormResponseInput.SubmittedAt, and is useful for accessing the field via an interface.
+func (v *FormResponseInput) GetSubmittedAt() *string { return v.SubmittedAt }
+
+type FormSort string
+
+const (
+	FormSortUpdatedAt    FormSort = "UPDATED_AT"
+	FormSortLastResponse FormSort = "LAST_RESPONSE"
+	FormSortTitle        FormSort = "TITLE"
+)
+
+var AllFormSort = []FormSort{
+	FormSortUpdatedAt,
+	FormSortLastResponse,
+	FormSortTitle,
+}
+
+type FormUpdateInput struct {
+	Title                 *string                `json:"title,omitempty"`
+	Description           *string                `json:"description,omitempty"`
+	Access                *FormAccess            `json:"access,omitempty"`
+	Questions             []*QuestionInput       `json:"questions,omitempty"`
+	ReviewStatuses        []string               `json:"reviewStatuses,omitempty"`
+	AllowResponseEditing  *bool                  `json:"allowResponseEditing,omitempty"`
+	ResponseEditDeadline  *string                `json:"responseEditDeadline,omitempty"`
+	ResponseNotifications *ResponseNotifications `json:"responseNotifications,omitempty"`
+	Version               int32                  `json:"version"`
+}
+
+// GetTitle returns FormUpdateInput.Title, and is useful for accessing the field via an interface.
+func (v *FormUpdateInput) GetTitle() *string { return v.Title }
+
+// GetDescription returns FormUpdateInput.Description, and is useful for accessing the field via an interface.
+func (v *FormUpdateInput) GetDescription() *string { return v.Description }
+
+// GetAccess returns FormUpdateInput.Access, and is useful for accessing the field via an interface.
+func (v *FormUpdateInput) GetAccess() *FormAccess { return v.Access }
+
+// GetQuestions returns FormUpdateInput.Questions, and is useful for accessing the field via an interface.
+func (v *FormUpdateInput) GetQuestions() []*QuestionInput { return v.Questions }
+
+// GetReviewStatuses returns FormUpdateInput.ReviewStatuses, and is useful for accessing the field via an interface.
+func (v *FormUpdateInput) GetReviewStatuses() []string { return v.ReviewStatuses }
+
+// GetAllowResponseEditing returns FormUpdateInput.AllowResponseEditing, and is useful for accessing the field via an interface.
+func (v *FormUpdateInput) GetAllowResponseEditing() *bool { return v.AllowResponseEditing }
+
+// GetResponseEditDeadline returns FormUpdateInput.ResponseEditDeadline, and is useful for accessing the field via an interface.
+func (v *FormUpdateInput) GetResponseEditDeadline() *string { return v.ResponseEditDeadline }
+
+// GetResponseNotifications returns FormUpdateInput.ResponseNotifications, and is useful for accessing the field via an interface.
+func (v *FormUpdateInput) GetResponseNotifications() *ResponseNotifications {
+	return v.ResponseNotifications
+}
+
+// GetVersion returns FormUpdateInput.Version, and is useful for accessing the field via an interface.
+func (v *FormUpdateInput) GetVersion() int32 { return v.Version }
+
+type NumberBucketsInput struct {
+	Min   *float64 `json:"min,omitempty"`
+	Max   *float64 `json:"max,omitempty"`
+	Count int32    `json:"count"`
+}
+
+// GetMin returns NumberBucketsInput.Min, and is useful for accessing the field via an interface.
+func (v *NumberBucketsInput) GetMin() *float64 { return v.Min }
+
+// GetMax returns NumberBucketsInput.Max, and is useful for accessing the field via an interface.
+func (v *NumberBucketsInput) GetMax() *float64 { return v.Max }
+
+// GetCount returns NumberBucketsInput.Count, and is useful for accessing the field via an interface.
+func (v *NumberBucketsInput) GetCount() int32 { return v.Count }
+
+// Option includes the GraphQL fields of Option requested by the fragment Option.
+type Option struct {
+	Id         string `json:"id"`
+	QuestionId string `json:"questionId"`
+	Text       string `json:"text"`
+	Order      int32  `json:"order"`
+}
+
+// GetId returns Option.Id, and is useful for accessing the field via an interface.
+func (v *Option) GetId() string { return v.Id }
+
+// GetQuestionId returns Option.QuestionId, and is useful for accessing the field via an interface.
+func (v *Option) GetQuestionId() string { return v.QuestionId }
+
+// GetText returns Option.Text, and is useful for accessing the field via an interface.
+func (v *Option) GetText() string { return v.Text }
+
+// GetOrder returns Option.Order, and is useful for accessing the field via an interface.
+func (v *Option) GetOrder() int32 { return v.Order }
+
+type OptionInput struct {
+	Text  string `json:"text"`
+	Order int32  `json:"order"`
+}
+
+// GetText returns OptionInput.Text, and is useful for accessing the field via an interface.
+func (v *OptionInput) GetText() string { return v.Text }
+
+// GetOrder returns OptionInput.Order, and is useful for accessing the field via an interface.
+func (v *OptionInput) GetOrder() int32 { return v.Order }
+
+type OptionUpdateInput struct {
+	Text    *string `json:"text,omitempty"`
+	Order   *int32  `json:"order,omitempty"`
+	Version int32   `json:"version"`
+}
+
+// GetText returns OptionUpdateInput.Text, and is useful for accessing the field via an interface.
+func (v *OptionUpdateInput) GetText() *string { return v.Text }
+
+// GetOrder returns OptionUpdateInput.Order, and is useful for accessing the field via an interface.
+func (v *OptionUpdateInput) GetOrder() *int32 { return v.Order }
+
+// GetVersion returns OptionUpdateInput.Version, and is useful for accessing the field via an interface.
+func (v *OptionUpdateInput) GetVersion() int32 { return v.Version }
+
+// Question includes the GraphQL fields of Question requested by the fragment Question.
+type Question struct {
+	Id              string       `json:"id"`
+	FormId          string       `json:"formId"`
+	Key             *string      `json:"key"`
+	Text            string       `json:"text"`
+	Type            QuestionType `json:"type"`
+	Required        bool         `json:"required"`
+	Order           int32        `json:"order"`
+	RespondentEmail bool         `json:"respondentEmail"`
+	Options         []*Option    `json:"options"`
+}
+
+// GetId returns Question.Id, and is useful for accessing the field via an interface.
+func (v *Question) GetId() string { return v.Id }
+
+// GetFormId returns Question.FormId, and is useful for accessing the field via an interface.
+func (v *Question) GetFormId() string { return v.FormId }
+
+// GetKey returns Question.Key, and is useful for accessing the field via an interface.
+func (v *Question) GetKey() *string { return v.Key }
+
+// GetText returns Question.Text, and is useful for accessing the field via an interface.
+func (v *Question) GetText() string { return v.Text }
+
+// GetType returns Question.Type, and is useful for accessing the field via an interface.
+func (v *Question) GetType() QuestionType { return v.Type }
+
+// GetRequired returns Question.Required, and is useful for accessing the field via an interface.
+func (v *Question) GetRequired() bool { return v.Required }
+
+// GetOrder returns Question.Order, and is useful for accessing the field via an interface.
+func (v *Question) GetOrder() int32 { return v.Order }
+
+// GetRespondentEmail returns Question.RespondentEmail, and is useful for accessing the field via an interface.
+func (v *Question) GetRespondentEmail() bool { return v.RespondentEmail }
+
+// GetOptions returns Question.Options, and is useful for accessing the field via an interface.
+func (v *Question) GetOptions() []*Option { return v.Options }
+
+type QuestionInput struct {
+	Key             *string        `json:"key,omitempty"`
+	Text            string         `json:"text"`
+	Type            QuestionType   `json:"type"`
+	Required        bool           `json:"required"`
+	Order           int32          `json:"order"`
+	RespondentEmail *bool          `json:"respondentEmail,omitempty"`
+	Options         []*OptionInput `json:"options,omitempty"`
+}
+
+// GetKey returns QuestionInput.Key, and is useful for accessing the field via an interface.
+func (v *QuestionInput) GetKey() *string { return v.Key }
+
+// GetText returns QuestionInput.Text, and is useful for accessing the field via an interface.
+func (v *QuestionInput) GetText() string { return v.Text }
+
+// GetType returns QuestionInput.Type, and is useful for accessing the field via an interface.
+func (v *QuestionInput) GetType() QuestionType { return v.Type }
+
+// GetRequired returns QuestionInput.Required, and is useful for accessing the field via an interface.
+func (v *QuestionInput) GetRequired() bool { return v.Required }
+
+// GetOrder returns QuestionInput.Order, and is useful for accessing the field via an interface.
+func (v *QuestionInput) GetOrder() int32 { return v.Order }
+
+// GetRespondentEmail returns QuestionInput.RespondentEmail, and is useful for accessing the field via an interface.
+func (v *QuestionInput) GetRespondentEmail() *bool { return v.RespondentEmail }
+
+// GetOptions returns QuestionInput.Options, and is useful for accessing the field via an interface.
+func (v *QuestionInput) GetOptions() []*OptionInput { return v.Options }
+
+type QuestionType string
+
+const (
+	QuestionTypeShortText      QuestionType = "SHORT_TEXT"
+	QuestionTypeParagraph      QuestionType = "PARAGRAPH"
+	QuestionTypeBoolean        QuestionType = "BOOLEAN"
+	QuestionTypeNumber         QuestionType = "NUMBER"
+	QuestionTypePhone          QuestionType = "PHONE"
+	QuestionTypeDate           QuestionType = "DATE"
+	QuestionTypeEmail          QuestionType = "EMAIL"
+	QuestionTypeSingleChoice   QuestionType = "SINGLE_CHOICE"
+	QuestionTypeMultipleChoice QuestionType = "MULTIPLE_CHOICE"
+)
+
+var AllQuestionType = []QuestionType{
+	QuestionTypeShortText,
+	QuestionTypeParagraph,
+	QuestionTypeBoolean,
+	QuestionTypeNumber,
+	QuestionTypePhone,
+	QuestionTypeDate,
+	QuestionTypeEmail,
+	QuestionTypeSingleChoice,
+	QuestionTypeMultipleChoice,
+}
+
+type QuestionUpdateInput struct {
+	Key      *string        `json:"key,omitempty"`
+	Text     *string        `json:"text,omitempty"`
+	Type     *QuestionType  `json:"type,omitempty"`
+	Required *bool          `json:"required,omitempty"`
+	Order    *int32         `json:"order,omitempty"`
+	Options  []*OptionInput `json:"options,omitempty"`
+	Version  int32          `json:"version"`
+}
+
+// GetKey returns QuestionUpdateInput.Key, and is useful for accessing the field via an interface.
+func (v *QuestionUpdateInput) GetKey() *string { return v.Key }
+
+// GetText returns QuestionUpdateInput.Text, and is useful for accessing the field via an interface.
+func (v *QuestionUpdateInput) GetText() *string { return v.Text }
+
+// GetType returns QuestionUpdateInput.Type, and is useful for accessing the field via an interface.
+func (v *QuestionUpdateInput) GetType() *QuestionType { return v.Type }
+
+// GetRequired returns QuestionUpdateInput.Required, and is useful for accessing the field via an interface.
+func (v *QuestionUpdateInput) GetRequired() *bool { return v.Required }
+
+// GetOrder returns QuestionUpdateInput.Order, and is useful for accessing the field via an interface.
+func (v *QuestionUpdateInput) GetOrder() *int32 { return v.Order }
+
+// GetOptions returns QuestionUpdateInput.Options, and is useful for accessing the field via an interface.
+func (v *QuestionUpdateInput) GetOptions() []*OptionInput { return v.Options }
+
+// GetVersion returns QuestionUpdateInput.Version, and is useful for accessing the field via an interface.
+func (v *QuestionUpdateInput) GetVersion() int32 { return v.Version }
+
+type ResponseNotifications string
+
+const (
+	ResponseNotificationsNone   ResponseNotifications = "NONE"
+	ResponseNotificationsEach   ResponseNotifications = "EACH"
+	ResponseNotificationsHourly ResponseNotifications = "HOURLY"
+	ResponseNotificationsDaily  ResponseNotifications = "DAILY"
+)
+
+var AllResponseNotifications = []ResponseNotifications{
+	ResponseNotificationsNone,
+	ResponseNotificationsEach,
+	ResponseNotificationsHourly,
+	ResponseNotificationsDaily,
+}
+
+// __applyFormInput is used internally by genqlient
+type __applyFormInput struct {
+	Document    string              `json:"document"`
+	Format      *FormDocumentFormat `json:"format"`
+	WorkspaceId *string             `json:"workspaceId"`
+}
+
+// GetDocument returns __applyFormInput.Document, and is useful for accessing the field via an interface.
+func (v *__applyFormInput) GetDocument() string { return v.Document }
+
+// GetFormat returns __applyFormInput.Format, and is useful for accessing the field via an interface.
+func (v *__applyFormInput) GetFormat() *FormDocumentFormat { return v.Format }
+
+// GetWorkspaceId returns __applyFormInput.WorkspaceId, and is useful for accessing the field via an interface.
+func (v *__applyFormInput) GetWorkspaceId() *string { return v.WorkspaceId }
+
+// __closeFormInput is used internally by genqlient
+type __closeFormInput struct {
+	Id string `json:"id"`
+}
+
+// GetId returns __closeFormInput.Id, and is useful for accessing the field via an interface.
+func (v *__closeFormInput) GetId() string { return v.Id }
+
+// __createFormInput is used internally by genqlient
+type __createFormInput struct {
+	Input *FormInput `json:"input,omitempty"`
+}
+
+// GetInput returns __createFormInput.Input, and is useful for accessing the field via an interface.
+func (v *__createFormInput) GetInput() *FormInput { return v.Input }
+
+// __crossTabInput is used internally by genqlient
+type __crossTabInput struct {
+	FormId           string                 `json:"formId"`
+	RowQuestionId    string                 `json:"rowQuestionId"`
+	ColumnQuestionId string                 `json:"columnQuestionId"`
+	Filters          []*CrossTabFilterInput `json:"filters,omitempty"`
+	RowBuckets       *NumberBucketsInput    `json:"rowBuckets,omitempty"`
+	ColumnBuckets    *NumberBucketsInput    `json:"columnBuckets,omitempty"`
+}
+
+// GetFormId returns __crossTabInput.FormId, and is useful for accessing the field via an interface.
+func (v *__crossTabInput) GetFormId() string { return v.FormId }
+
+// GetRowQuestionId returns __crossTabInput.RowQuestionId, and is useful for accessing the field via an interface.
+func (v *__crossTabInput) GetRowQuestionId() string { return v.RowQuestionId }
+
+// GetColumnQuestionId returns __crossTabInput.ColumnQuestionId, and is useful for accessing the field via an interface.
+func (v *__crossTabInput) GetColumnQuestionId() string { return v.ColumnQuestionId }
+
+// GetFilters returns __crossTabInput.Filters, and is useful for accessing the field via an interface.
+func (v *__crossTabInput) GetFilters() []*CrossTabFilterInput { return v.Filters }
+
+// GetRowBuckets returns __crossTabInput.RowBuckets, and is useful for accessing the field via an interface.
+func (v *__crossTabInput) GetRowBuckets() *NumberBucketsInput { return v.RowBuckets }
+
+// GetColumnBuckets returns __crossTabInput.ColumnBuckets, and is useful for accessing the field via an interface.
+func (v *__crossTabInput) GetColumnBuckets() *NumberBucketsInput { return v.ColumnBuckets }
+
+// __deleteFormInput is used internally by genqlient
+type __deleteFormInput struct {
+	Id string `json:"id"`
+}
+
+// GetId returns __deleteFormInput.Id, and is useful for accessing the field via an interface.
+func (v *__deleteFormInput) GetId() string { return v.Id }
+
+// __deleteOptionInput is used internally by genqlient
+type __deleteOptionInput struct {
+	Id      string `json:"id"`
+	Version int32  `json:"version"`
+}
+
+// GetId returns __deleteOptionInput.Id, and is useful for accessing the field via an interface.
+func (v *__deleteOptionInput) GetId() string { return v.Id }
+
+// GetVersion returns __deleteOptionInput.Version, and is useful for accessing the field via an interface.
+func (v *__deleteOptionInput) GetVersion() int32 { return v.Version }
+
+// __deleteQuestionInput is used internally by genqlient
+type __deleteQuestionInput struct {
+	Id      string `json:"id"`
+	Version int32  `json:"version"`
+}
+
+// GetId returns __deleteQuestionInput.Id, and is useful for accessing the field via an interface.
+func (v *__deleteQuestionInput) GetId() string { return v.Id }
+
+// GetVersion returns __deleteQuestionInput.Version, and is useful for accessing the field via an interface.
+func (v *__deleteQuestionInput) GetVersion() int32 { return v.Version }
+
+// __deleteResponseInput is used internally by genqlient
+type __deleteResponseInput struct {
+	Id string `json:"id"`
+}
+
+// GetId returns __deleteResponseInput.Id, and is useful for accessing the field via an interface.
+func (v *__deleteResponseInput) GetId() string { return v.Id }
+
+// __exportFormInput is used internally by genqlient
+type __exportFormInput struct {
+	Id     string             `json:"id"`
+	Format FormDocumentFormat `json:"format"`
+}
+
+// GetId returns __exportFormInput.Id, and is useful for accessing the field via an interface.
+func (v *__exportFormInput) GetId() string { return v.Id }
+
+// GetFormat returns __exportFormInput.Format, and is useful for accessing the field via an interface.
+func (v *__exportFormInput) GetFormat() FormDocumentFormat { return v.Format }
+
+// __getFormByKeyInput is used internally by genqlient
+type __getFormByKeyInput struct {
+	Key         string  `json:"key"`
+	WorkspaceId *string `json:"workspaceId"`
+}
+
+// GetKey returns __getFormByKeyInput.Key, and is useful for accessing the field via an interface.
+func (v *__getFormByKeyInput) GetKey() string { return v.Key }
+
+// GetWorkspaceId returns __getFormByKeyInput.WorkspaceId, and is useful for accessing the field via an interface.
+func (v *__getFormByKeyInput) GetWorkspaceId() *string { return v.WorkspaceId }
+
+// __getFormInput is used internally by genqlient
+type __getFormInput struct {
+	Id string `json:"id"`
+}
+
+// GetId returns __getFormInput.Id, and is useful for accessing the field via an interface.
+func (v *__getFormInput) GetId() string { return v.Id }
+
+// __getResponseInput is used internally by genqlient
+type __getResponseInput struct {
+	Id string `json:"id"`
+}
+
+// GetId returns __getResponseInput.Id, and is useful for accessing the field via an interface.
+func (v *__getResponseInput) GetId() string { return v.Id }
+
+// __listFormsInput is used internally by genqlient
+type __listFormsInput struct {
+	OwnerId     *string     `json:"ownerId"`
+	Access      *FormAccess `json:"access"`
+	WorkspaceId *string     `json:"workspaceId"`
+	FolderId    *string     `json:"folderId"`
+	Tag         *string     `json:"tag"`
+	Starred     *bool       `json:"starred"`
+	Sort        *FormSort   `json:"sort"`
+	Limit       int32       `json:"limit"`
+	Offset      int32       `json:"offset"`
+}
+
+// GetOwnerId returns __listFormsInput.OwnerId, and is useful for accessing the field via an interface.
+func (v *__listFormsInput) GetOwnerId() *string { return v.OwnerId }
+
+// GetAccess returns __listFormsInput.Access, and is useful for accessing the field via an interface.
+func (v *__listFormsInput) GetAccess() *FormAccess { return v.Access }
+
+// GetWorkspaceId returns __listFormsInput.WorkspaceId, and is useful for accessing the field via an interface.
+func (v *__listFormsInput) GetWorkspaceId() *string { return v.WorkspaceId }
+
+// GetFolderId returns __listFormsInput.FolderId, and is useful for accessing the field via an interface.
+func (v *__listFormsInput) GetFolderId() *string { return v.FolderId }
+
+// GetTag returns __listFormsInput.Tag, and is useful for accessing the field via an interface.
+func (v *__listFormsInput) GetTag() *string { return v.Tag }
+
+// GetStarred returns __listFormsInput.Starred, and is useful for accessing the field via an interface.
+func (v *__listFormsInput) GetStarred() *bool { return v.Starred }
+
+// GetSort returns __listFormsInput.Sort, and is useful for accessing the field via an interface.
+func (v *__listFormsInput) GetSort() *FormSort { return v.Sort }
+
+// GetLimit returns __listFormsInput.Limit, and is useful for accessing the field via an interface.
+func (v *__listFormsInput) GetLimit() int32 { return v.Limit }
+
+// GetOffset returns __listFormsInput.Offset, and is useful for accessing the field via an interface.
+func (v *__listFormsInput) GetOffset() int32 { return v.Offset }
+
+// __listResponsesInput is used internally by genqlient
+type __listResponsesInput struct {
+	FormId string              `json:"formId"`
+	Filter *FormResponseFilter `json:"filter,omitempty"`
+	Limit  int32               `json:"limit"`
+	Offset int32               `json:"offset"`
+}
+
+// GetFormId returns __listResponsesInput.FormId, and is useful for accessing the field via an interface.
+func (v *__listResponsesInput) GetFormId() string { return v.FormId }
+
+// GetFilter returns __listResponsesInput.Filter, and is useful for accessing the field via an interface.
+func (v *__listResponsesInput) GetFilter() *FormResponseFilter { return v.Filter }
+
+// GetLimit returns __listResponsesInput.Limit, and is useful for accessing the field via an interface.
+func (v *__listResponsesInput) GetLimit() int32 { return v.Limit }
+
+// GetOffset returns __listResponsesInput.Offset, and is useful for accessing the field via an interface.
+func (v *__listResponsesInput) GetOffset() int32 { return v.Offset }
+
+// __reopenFormInput is used internally by genqlient
+type __reopenFormInput struct {
+	Id string `json:"id"`
+}
+
+// GetId returns __reopenFormInput.Id, and is useful for accessing the field via an interface.
+func (v *__reopenFormInput) GetId() string { return v.Id }
+
+// __setResponseStatusInput is used internally by genqlient
+type __setResponseStatusInput struct {
+	Id     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// GetId returns __setResponseStatusInput.Id, and is useful for accessing the field via an interface.
+func (v *__setResponseStatusInput) GetId() string { return v.Id }
+
+// GetStatus returns __setResponseStatusInput.Status, and is useful for accessing the field via an interface.
+func (v *__setResponseStatusInput) GetStatus() string { return v.Status }
+
+// __submitResponseInput is used internally by genqlient
+type __submitResponseInput struct {
+	Input *FormResponseInput `json:"input,omitempty"`
+}
+
+// GetInput returns __submitResponseInput.Input, and is useful for accessing the field via an interface.
+func (v *__submitResponseInput) GetInput() *FormResponseInput { return v.Input }
+
+// __updateFormInput is used internally by genqlient
+type __updateFormInput struct {
+	Id    string           `json:"id"`
+	Input *FormUpdateInput `json:"input,omitempty"`
+}
+
+// GetId returns __updateFormInput.Id, and is useful for accessing the field via an interface.
+func (v *__updateFormInput) GetId() string { return v.Id }
+
+// GetInput returns __updateFormInput.Input, and is useful for accessing the field via an interface.
+func (v *__updateFormInput) GetInput() *FormUpdateInput { return v.Input }
+
+// __updateOptionInput is used internally by genqlient
+type __updateOptionInput struct {
+	Id    string             `json:"id"`
+	Input *OptionUpdateInput `json:"input,omitempty"`
+}
+
+// GetId returns __updateOptionInput.Id, and is useful for accessing the field via an interface.
+func (v *__updateOptionInput) GetId() string { return v.Id }
+
+// GetInput returns __updateOptionInput.Input, and is useful for accessing the field via an interface.
+func (v *__updateOptionInput) GetInput() *OptionUpdateInput { return v.Input }
+
+// __updateQuestionInput is used internally by genqlient
+type __updateQuestionInput struct {
+	Id    string               `json:"id"`
+	Input *QuestionUpdateInput `json:"input,omitempty"`
+}
+
+// GetId returns __updateQuestionInput.Id, and is useful for accessing the field via an interface.
+func (v *__updateQuestionInput) GetId() string { return v.Id }
+
+// GetInput returns __updateQuestionInput.Input, and is useful for accessing the field via an interface.
+func (v *__updateQuestionInput) GetInput() *QuestionUpdateInput { return v.Input }
+
+// applyFormResponse is returned by applyForm on success.
+type applyFormResponse struct {
+	ApplyForm *FormApply `json:"applyForm"`
+}
+
+// GetApplyForm returns applyFormResponse.ApplyForm, and is useful for accessing the field via an interface.
+func (v *applyFormResponse) GetApplyForm() *FormApply { return v.ApplyForm }
+
+// closeFormResponse is returned by closeForm on success.
+type closeFormResponse struct {
+	CloseForm *Form `json:"closeForm"`
+}
+
+// GetCloseForm returns closeFormResponse.CloseForm, and is useful for accessing the field via an interface.
+func (v *closeFormResponse) GetCloseForm() *Form { return v.CloseForm }
+
+// createFormResponse is returned by createForm on success.
+type createFormResponse struct {
+	CreateForm *Form `json:"createForm"`
+}
+
+// GetCreateForm returns createFormResponse.CreateForm, and is useful for accessing the field via an interface.
+func (v *createFormResponse) GetCreateForm() *Form { return v.CreateForm }
+
+// crossTabResponse is returned by crossTab on success.
+type crossTabResponse struct {
+	CrossTab *CrossTab `json:"crossTab"`
+}
+
+// GetCrossTab returns crossTabResponse.CrossTab, and is useful for accessing the field via an interface.
+func (v *crossTabResponse) GetCrossTab() *CrossTab { return v.CrossTab }
+
+// deleteFormResponse is returned by deleteForm on success.
+type deleteFormResponse struct {
+	DeleteForm bool `json:"deleteForm"`
+}
+
+// GetDeleteForm returns deleteFormResponse.DeleteForm, and is useful for accessing the field via an interface.
+func (v *deleteFormResponse) GetDeleteForm() bool { return v.DeleteForm }
+
+// deleteOptionResponse is returned by deleteOption on success.
+type deleteOptionResponse struct {
+	DeleteOption bool `json:"deleteOption"`
+}
+
+// GetDeleteOption returns deleteOptionResponse.DeleteOption, and is useful for accessing the field via an interface.
+func (v *deleteOptionResponse) GetDeleteOption() bool { return v.DeleteOption }
+
+// deleteQuestionResponse is returned by deleteQuestion on success.
+type deleteQuestionResponse struct {
+	DeleteQuestion bool `json:"deleteQuestion"`
+}
+
+// GetDeleteQuestion returns deleteQuestionResponse.DeleteQuestion, and is useful for accessing the field via an interface.
+func (v *deleteQuestionResponse) GetDeleteQuestion() bool { return v.DeleteQuestion }
+
+// deleteResponseResponse is returned by deleteResponse on success.
+type deleteResponseResponse struct {
+	DeleteResponse bool `json:"deleteResponse"`
+}
+
+// GetDeleteResponse returns deleteResponseResponse.DeleteResponse, and is useful for accessing the field via an interface.
+func (v *deleteResponseResponse) GetDeleteResponse() bool { return v.DeleteResponse }
+
+// exportFormResponse is returned by exportForm on success.
+type exportFormResponse struct {
+	ExportForm string `json:"exportForm"`
+}
+
+// GetExportForm returns exportFormResponse.ExportForm, and is useful for accessing the field via an interface.
+func (v *exportFormResponse) GetExportForm() string { return v.ExportForm }
+
+// getFormByKeyResponse is returned by getFormByKey on success.
+type getFormByKeyResponse struct {
+	FormByKey *Form `json:"formByKey"`
+}
+
+// GetFormByKey returns getFormByKeyResponse.FormByKey, and is useful for accessing the field via an interface.
+func (v *getFormByKeyResponse) GetFormByKey() *Form { return v.FormByKey }
+
+// getFormResponse is returned by getForm on success.
+type getFormResponse struct {
+	Form *Form `json:"form"`
+}
+
+// GetForm returns getFormResponse.Form, and is useful for accessing the field via an interface.
+func (v *getFormResponse) GetForm() *Form { return v.Form }
+
+// getResponseResponse is returned by getResponse on success.
+type getResponseResponse struct {
+	FormResponse *FormResponse `json:"formResponse"`
+}
+
+// GetFormResponse returns getResponseResponse.FormResponse, and is useful for accessing the field via an interface.
+func (v *getResponseResponse) GetFormResponse() *FormResponse { return v.FormResponse }
+
+// listFormsResponse is returned by listForms on success.
+type listFormsResponse struct {
+	Forms []*Form `json:"forms"`
+}
+
+// GetForms returns listFormsResponse.Forms, and is useful for accessing the field via an interface.
+func (v *listFormsResponse) GetForms() []*Form { return v.Forms }
+
+// listResponsesResponse is returned by listResponses on success.
+type listResponsesResponse struct {
+	FormResponses []*FormResponse `json:"formResponses"`
+}
+
+// GetFormResponses returns listResponsesResponse.FormResponses, and is useful for accessing the field via an interface.
+func (v *listResponsesResponse) GetFormResponses() []*FormResponse { return v.FormResponses }
+
+// reopenFormResponse is returned by reopenForm on success.
+type reopenFormResponse struct {
+	ReopenForm *Form `json:"reopenForm"`
+}
+
+// GetReopenForm returns reopenFormResponse.ReopenForm, and is useful for accessing the field via an interface.
+func (v *reopenFormResponse) GetReopenForm() *Form { return v.ReopenForm }
+
+// setResponseStatusResponse is returned by setResponseStatus on success.
+type setResponseStatusResponse struct {
+	SetResponseStatus *FormResponse `json:"setResponseStatus"`
+}
+
+// GetSetResponseStatus returns setResponseStatusResponse.SetResponseStatus, and is useful for accessing the field via an interface.
+func (v *setResponseStatusResponse) GetSetResponseStatus() *FormResponse { return v.SetResponseStatus }
+
+// submitResponseResponse is returned by submitResponse on success.
+type submitResponseResponse struct {
+	SubmitFormResponse *FormResponse `json:"submitFormResponse"`
+}
+
+// GetSubmitFormResponse returns submitResponseResponse.SubmitFormResponse, and is useful for accessing the field via an interface.
+func (v *submitResponseResponse) GetSubmitFormResponse() *FormResponse { return v.SubmitFormResponse }
+
+// updateFormResponse is returned by updateForm on success.
+type updateFormResponse struct {
+	UpdateForm *Form `json:"updateForm"`
+}
+
+// GetUpdateForm returns updateFormResponse.UpdateForm, and is useful for accessing the field via an interface.
+func (v *updateFormResponse) GetUpdateForm() *Form { return v.UpdateForm }
+
+// updateOptionResponse is returned by updateOption on success.
+type updateOptionResponse struct {
+	UpdateOption *Option `json:"updateOption"`
+}
+
+// GetUpdateOption returns updateOptionResponse.UpdateOption, and is useful for accessing the field via an interface.
+func (v *updateOptionResponse) GetUpdateOption() *Option { return v.UpdateOption }
+
+// updateQuestionResponse is returned by updateQuestion on success.
+type updateQuestionResponse struct {
+	UpdateQuestion *Question `json:"updateQuestion"`
+}
+
+// GetUpdateQuestion returns updateQuestionResponse.UpdateQuestion, and is useful for accessing the field via an interface.
+func (v *updateQuestionResponse) GetUpdateQuestion() *Question { return v.UpdateQuestion }
+
+// The mutation executed by applyForm.
+const applyForm_Operation = `
+mutation applyForm ($document: String!, $format: FormDocumentFormat, $workspaceId: ID) {
+	applyForm(document: $document, format: $format, workspaceId: $workspaceId) {
+		form {
+			... Form
+		}
+		action
+	}
+}
+fragment Form on Form {
+	id
+	ownerId
+	workspaceId
+	folderId
+	tags
+	isTemplate
+	externalKey
+	title
+	description
+	access
+	createdAt
+	updatedAt
+	allowResponseEditing
+	responseEditDeadline
+	closedAt
+	responseNotifications
+	responseStatuses
+	version
+	questions {
+		... Question
+	}
+}
+fragment Question on Question {
+	id
+	formId
+	key
+	text
+	type
+	required
+	order
+	respondentEmail
+	options {
+		... Option
+	}
+}
+fragment Option on Option {
+	id
+	questionId
+	text
+	order
+}
+`
+
+func applyForm(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	document string,
+	format *FormDocumentFormat,
+	workspaceId *string,
+) (data_ *applyFormResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "applyForm",
+		Query:  applyForm_Operation,
+		Variables: &__applyFormInput{
+			Document:    document,
+			Format:      format,
+			WorkspaceId: workspaceId,
+		},
+	}
+
+	data_ = &applyFormResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by closeForm.
+const closeForm_Operation = `
+mutation closeForm ($id: ID!) {
+	closeForm(id: $id) {
+		... Form
+	}
+}
+fragment Form on Form {
+	id
+	ownerId
+	workspaceId
+	folderId
+	tags
+	isTemplate
+	externalKey
+	title
+	description
+	access
+	createdAt
+	updatedAt
+	allowResponseEditing
+	responseEditDeadline
+	closedAt
+	responseNotifications
+	responseStatuses
+	version
+	questions {
+		... Question
+	}
+}
+fragment Question on Question {
+	id
+	formId
+	key
+	text
+	type
+	required
+	order
+	respondentEmail
+	options {
+		... Option
+	}
+}
+fragment Option on Option {
+	id
+	questionId
+	text
+	order
+}
+`
+
+func closeForm(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+) (data_ *closeFormResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "closeForm",
+		Query:  closeForm_Operation,
+		Variables: &__closeFormInput{
+			Id: id,
+		},
+	}
+
+	data_ = &closeFormResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by createForm.
+const createForm_Operation = `
+mutation createForm ($input: FormInput!) {
+	createForm(input: $input) {
+		... Form
+	}
+}
+fragment Form on Form {
+	id
+	ownerId
+	workspaceId
+	folderId
+	tags
+	isTemplate
+	externalKey
+	title
+	description
+	access
+	createdAt
+	updatedAt
+	allowResponseEditing
+	responseEditDeadline
+	closedAt
+	responseNotifications
+	responseStatuses
+	version
+	questions {
+		... Question
+	}
+}
+fragment Question on Question {
+	id
+	formId
+	key
+	text
+	type
+	required
+	order
+	respondentEmail
+	options {
+		... Option
+	}
+}
+fragment Option on Option {
+	id
+	questionId
+	text
+	order
+}
+`
+
+func createForm(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	input *FormInput,
+) (data_ *createFormResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "createForm",
+		Query:  createForm_Operation,
+		Variables: &__createFormInput{
+			Input: input,
+		},
+	}
+
+	data_ = &createFormResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by crossTab.
+const crossTab_Operation = `
+query crossTab ($formId: ID!, $rowQuestionId: ID!, $columnQuestionId: ID!, $filters: [CrossTabFilterInput!], $rowBuckets: NumberBucketsInput, $columnBuckets: NumberBucketsInput) {
+	crossTab(formId: $formId, rowQuestionId: $rowQuestionId, columnQuestionId: $columnQuestionId, filters: $filters, rowBuckets: $rowBuckets, columnBuckets: $columnBuckets) {
+		formId
+		rowQuestion {
+			... Question
+		}
+		columnQuestion {
+			... Question
+		}
+		rows {
+			key
+			label
+			total
+			percent
+		}
+		columns {
+			key
+			label
+			total
+			percent
+		}
+		cells {
+			rowKey
+			columnKey
+			count
+			expected
+			rowPercent
+			columnPercent
+			totalPercent
+		}
+		total
+		chiSquare
+		degreesOfFreedom
+		pValue
+	}
+}
+fragment Question on Question {
+	id
+	formId
+	key
+	text
+	type
+	required
+	order
+	respondentEmail
+	options {
+		... Option
+	}
+}
+fragment Option on Option {
+	id
+	questionId
+	text
+	order
+}
+`
+
+func crossTab(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	formId string,
+	rowQuestionId string,
+	columnQuestionId string,
+	filters []*CrossTabFilterInput,
+	rowBuckets *NumberBucketsInput,
+	columnBuckets *NumberBucketsInput,
+) (data_ *crossTabResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "crossTab",
+		Query:  crossTab_Operation,
+		Variables: &__crossTabInput{
+			FormId:           formId,
+			RowQuestionId:    rowQuestionId,
+			ColumnQuestionId: columnQuestionId,
+			Filters:          filters,
+			RowBuckets:       rowBuckets,
+			ColumnBuckets:    columnBuckets,
+		},
+	}
+
+	data_ = &crossTabResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by deleteForm.
+const deleteForm_Operation = `
+mutation deleteForm ($id: ID!) {
+	deleteForm(id: $id)
+}
+`
+
+func deleteForm(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+) (data_ *deleteFormResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "deleteForm",
+		Query:  deleteForm_Operation,
+		Variables: &__deleteFormInput{
+			Id: id,
+		},
+	}
+
+	data_ = &deleteFormResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by deleteOption.
+const deleteOption_Operation = `
+mutation deleteOption ($id: ID!, $version: Int!) {
+	deleteOption(id: $id, version: $version)
+}
+`
+
+func deleteOption(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+	version int32,
+) (data_ *deleteOptionResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "deleteOption",
+		Query:  deleteOption_Operation,
+		Variables: &__deleteOptionInput{
+			Id:      id,
+			Version: version,
+		},
+	}
+
+	data_ = &deleteOptionResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by deleteQuestion.
+const deleteQuestion_Operation = `
+mutation deleteQuestion ($id: ID!, $version: Int!) {
+	deleteQuestion(id: $id, version: $version)
+}
+`
+
+func deleteQuestion(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+	version int32,
+) (data_ *deleteQuestionResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "deleteQuestion",
+		Query:  deleteQuestion_Operation,
+		Variables: &__deleteQuestionInput{
+			Id:      id,
+			Version: version,
+		},
+	}
+
+	data_ = &deleteQuestionResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by deleteResponse.
+const deleteResponse_Operation = `
+mutation deleteResponse ($id: ID!) {
+	deleteResponse(id: $id)
+}
+`
+
+func deleteResponse(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+) (data_ *deleteResponseResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "deleteResponse",
+		Query:  deleteResponse_Operation,
+		Variables: &__deleteResponseInput{
+			Id: id,
+		},
+	}
+
+	data_ = &deleteResponseResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by exportForm.
+const exportForm_Operation = `
+query exportForm ($id: ID!, $format: FormDocumentFormat!) {
+	exportForm(id: $id, format: $format)
+}
+`
+
+func exportForm(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+	format FormDocumentFormat,
+) (data_ *exportFormResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "exportForm",
+		Query:  exportForm_Operation,
+		Variables: &__exportFormInput{
+			Id:     id,
+			Format: format,
+		},
+	}
+
+	data_ = &exportFormResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by getForm.
+const getForm_Operation = `
+query getForm ($id: ID!) {
+	form(id: $id) {
+		... Form
+	}
+}
+fragment Form on Form {
+	id
+	ownerId
+	workspaceId
+	folderId
+	tags
+	isTemplate
+	externalKey
+	title
+	description
+	access
+	createdAt
+	updatedAt
+	allowResponseEditing
+	responseEditDeadline
+	closedAt
+	responseNotifications
+	responseStatuses
+	version
+	questions {
+		... Question
+	}
+}
+fragment Question on Question {
+	id
+	formId
+	key
+	text
+	type
+	required
+	order
+	respondentEmail
+	options {
+		... Option
+	}
+}
+fragment Option on Option {
+	id
+	questionId
+	text
+	order
+}
+`
+
+func getForm(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+) (data_ *getFormResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "getForm",
+		Query:  getForm_Operation,
+		Variables: &__getFormInput{
+			Id: id,
+		},
+	}
+
+	data_ = &getFormResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by getFormByKey.
+const getFormByKey_Operation = `
+query getFormByKey ($key: String!, $workspaceId: ID) {
+	formByKey(key: $key, workspaceId: $workspaceId) {
+		... Form
+	}
+}
+fragment Form on Form {
+	id
+	ownerId
+	workspaceId
+	folderId
+	tags
+	isTemplate
+	externalKey
+	title
+	description
+	access
+	createdAt
+	updatedAt
+	allowResponseEditing
+	responseEditDeadline
+	closedAt
+	responseNotifications
+	responseStatuses
+	version
+	questions {
+		... Question
+	}
+}
+fragment Question on Question {
+	id
+	formId
+	key
+	text
+	type
+	required
+	order
+	respondentEmail
+	options {
+		... Option
+	}
+}
+fragment Option on Option {
+	id
+	questionId
+	text
+	order
+}
+`
+
+func getFormByKey(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	key string,
+	workspaceId *string,
+) (data_ *getFormByKeyResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "getFormByKey",
+		Query:  getFormByKey_Operation,
+		Variables: &__getFormByKeyInput{
+			Key:         key,
+			WorkspaceId: workspaceId,
+		},
+	}
+
+	data_ = &getFormByKeyResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by getResponse.
+const getResponse_Operation = `
+query getResponse ($id: ID!) {
+	formResponse(id: $id) {
+		... FormResponse
+	}
+}
+fragment FormResponse on FormResponse {
+	id
+	formId
+	createdAt
+	updatedAt
+	status
+	assigneeId
+	tags
+	editToken
+	answers {
+		... Answer
+	}
+}
+fragment Answer on Answer {
+	id
+	questionId
+	textValue
+	boolValue
+	numberValue
+	dateValue
+	selectedOptions {
+		... Option
+	}
+}
+fragment Option on Option {
+	id
+	questionId
+	text
+	order
+}
+`
+
+func getResponse(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+) (data_ *getResponseResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "getResponse",
+		Query:  getResponse_Operation,
+		Variables: &__getResponseInput{
+			Id: id,
+		},
+	}
+
+	data_ = &getResponseResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by listForms.
+const listForms_Operation = `
+query listForms ($ownerId: ID, $access: FormAccess, $workspaceId: ID, $folderId: ID, $tag: String, $starred: Boolean, $sort: FormSort, $limit: Int!, $offset: Int!) {
+	forms(ownerId: $ownerId, access: $access, workspaceId: $workspaceId, folderId: $folderId, tag: $tag, starred: $starred, sort: $sort, limit: $limit, offset: $offset) {
+		... Form
+	}
+}
+fragment Form on Form {
+	id
+	ownerId
+	workspaceId
+	folderId
+	tags
+	isTemplate
+	externalKey
+	title
+	description
+	access
+	createdAt
+	updatedAt
+	allowResponseEditing
+	responseEditDeadline
+	closedAt
+	responseNotifications
+	responseStatuses
+	version
+	questions {
+		... Question
+	}
+}
+fragment Question on Question {
+	id
+	formId
+	key
+	text
+	type
+	required
+	order
+	respondentEmail
+	options {
+		... Option
+	}
+}
+fragment Option on Option {
+	id
+	questionId
+	text
+	order
+}
+`
+
+func listForms(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	ownerId *string,
+	access *FormAccess,
+	workspaceId *string,
+	folderId *string,
+	tag *string,
+	starred *bool,
+	sort *FormSort,
+	limit int32,
+	offset int32,
+) (data_ *listFormsResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "listForms",
+		Query:  listForms_Operation,
+		Variables: &__listFormsInput{
+			OwnerId:     ownerId,
+			Access:      access,
+			WorkspaceId: workspaceId,
+			FolderId:    folderId,
+			Tag:         tag,
+			Starred:     starred,
+			Sort:        sort,
+			Limit:       limit,
+			Offset:      offset,
+		},
+	}
+
+	data_ = &listFormsResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by listResponses.
+const listResponses_Operation = `
+query listResponses ($formId: ID!, $filter: FormResponseFilter, $limit: Int!, $offset: Int!) {
+	formResponses(formId: $formId, filter: $filter, limit: $limit, offset: $offset) {
+		... FormResponse
+	}
+}
+fragment FormResponse on FormResponse {
+	id
+	formId
+	createdAt
+	updatedAt
+	status
+	assigneeId
+	tags
+	editToken
+	answers {
+		... Answer
+	}
+}
+fragment Answer on Answer {
+	id
+	questionId
+	textValue
+	boolValue
+	numberValue
+	dateValue
+	selectedOptions {
+		... Option
+	}
+}
+fragment Option on Option {
+	id
+	questionId
+	text
+	order
+}
+`
+
+func listResponses(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	formId string,
+	filter *FormResponseFilter,
+	limit int32,
+	offset int32,
+) (data_ *listResponsesResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "listResponses",
+		Query:  listResponses_Operation,
+		Variables: &__listResponsesInput{
+			FormId: formId,
+			Filter: filter,
+			Limit:  limit,
+			Offset: offset,
+		},
+	}
+
+	data_ = &listResponsesResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by reopenForm.
+const reopenForm_Operation = `
+mutation reopenForm ($id: ID!) {
+	reopenForm(id: $id) {
+		... Form
+	}
+}
+fragment Form on Form {
+	id
+	ownerId
+	workspaceId
+	folderId
+	tags
+	isTemplate
+	externalKey
+	title
+	description
+	access
+	createdAt
+	updatedAt
+	allowResponseEditing
+	responseEditDeadline
+	closedAt
+	responseNotifications
+	responseStatuses
+	version
+	questions {
+		... Question
+	}
+}
+fragment Question on Question {
+	id
+	formId
+	key
+	text
+	type
+	required
+	order
+	respondentEmail
+	options {
+		... Option
+	}
+}
+fragment Option on Option {
+	id
+	questionId
+	text
+	order
+}
+`
+
+func reopenForm(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+) (data_ *reopenFormResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "reopenForm",
+		Query:  reopenForm_Operation,
+		Variables: &__reopenFormInput{
+			Id: id,
+		},
+	}
+
+	data_ = &reopenFormResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by setResponseStatus.
+const setResponseStatus_Operation = `
+mutation setResponseStatus ($id: ID!, $status: String!) {
+	setResponseStatus(id: $id, status: $status) {
+		... FormResponse
+	}
+}
+fragment FormResponse on FormResponse {
+	id
+	formId
+	createdAt
+	updatedAt
+	status
+	assigneeId
+	tags
+	editToken
+	answers {
+		... Answer
+	}
+}
+fragment Answer on Answer {
+	id
+	questionId
+	textValue
+	boolValue
+	numberValue
+	dateValue
+	selectedOptions {
+		... Option
+	}
+}
+fragment Option on Option {
+	id
+	questionId
+	text
+	order
+}
+`
+
+func setResponseStatus(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+	status string,
+) (data_ *setResponseStatusResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "setResponseStatus",
+		Query:  setResponseStatus_Operation,
+		Variables: &__setResponseStatusInput{
+			Id:     id,
+			Status: status,
+		},
+	}
+
+	data_ = &setResponseStatusResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by submitResponse.
+const submitResponse_Operation = `
+mutation submitResponse ($input: FormResponseInput!) {
+	submitFormResponse(input: $input) {
+		... FormResponse
+	}
+}
+fragment FormResponse on FormResponse {
+	id
+	formId
+	createdAt
+	updatedAt
+	status
+	assigneeId
+	tags
+	editToken
+	answers {
+		... Answer
+	}
+}
+fragment Answer on Answer {
+	id
+	questionId
+	textValue
+	boolValue
+	numberValue
+	dateValue
+	selectedOptions {
+		... Option
+	}
+}
+fragment Option on Option {
+	id
+	questionId
+	text
+	order
+}
+`
+
+func submitResponse(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	input *FormResponseInput,
+) (data_ *submitResponseResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "submitResponse",
+		Query:  submitResponse_Operation,
+		Variables: &__submitResponseInput{
+			Input: input,
+		},
+	}
+
+	data_ = &submitResponseResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by updateForm.
+const updateForm_Operation = `
+mutation updateForm ($id: ID!, $input: FormUpdateInput!) {
+	updateForm(id: $id, input: $input) {
+		... Form
+	}
+}
+fragment Form on Form {
+	id
+	ownerId
+	workspaceId
+	folderId
+	tags
+	isTemplate
+	externalKey
+	title
+	description
+	access
+	createdAt
+	updatedAt
+	allowResponseEditing
+	responseEditDeadline
+	closedAt
+	responseNotifications
+	responseStatuses
+	version
+	questions {
+		... Question
+	}
+}
+fragment Question on Question {
+	id
+	formId
+	key
+	text
+	type
+	required
+	order
+	respondentEmail
+	options {
+		... Option
+	}
+}
+fragment Option on Option {
+	id
+	questionId
+	text
+	order
+}
+`
+
+func updateForm(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+	input *FormUpdateInput,
+) (data_ *updateFormResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "updateForm",
+		Query:  updateForm_Operation,
+		Variables: &__updateFormInput{
+			Id:    id,
+			Input: input,
+		},
+	}
+
+	data_ = &updateFormResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by updateOption.
+const updateOption_Operation = `
+mutation updateOption ($id: ID!, $input: OptionUpdateInput!) {
+	updateOption(id: $id, input: $input) {
+		... Option
+	}
+}
+fragment Option on Option {
+	id
+	questionId
+	text
+	order
+}
+`
+
+func updateOption(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+	input *OptionUpdateInput,
+) (data_ *updateOptionResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "updateOption",
+		Query:  updateOption_Operation,
+		Variables: &__updateOptionInput{
+			Id:    id,
+			Input: input,
+		},
+	}
+
+	data_ = &updateOptionResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by updateQuestion.
+const updateQuestion_Operation = `
+mutation updateQuestion ($id: ID!, $input: QuestionUpdateInput!) {
+	updateQuestion(id: $id, input: $input) {
+		... Question
+	}
+}
+fragment Question on Question {
+	id
+	formId
+	key
+	text
+	type
+	required
+	order
+	respondentEmail
+	options {
+		... Option
+	}
+}
+fragment Option on Option {
+	id
+	questionId
+	text
+	order
+}
+`
+
+func updateQuestion(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+	input *QuestionUpdateInput,
+) (data_ *updateQuestionResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "updateQuestion",
+		Query:  updateQuestion_Operation,
+		Variables: &__updateQuestionInput{
+			Id:    id,
+			Input: input,
+		},
+	}
+
+	data_ = &updateQuestionResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
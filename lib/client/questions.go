@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+// UpdateQuestion changes the fields set in the input. input.Version is the version of the
+// form the change is based on; the change fails with ErrVersionConflict if the form
+// changed since that version.
+func (c *Client) UpdateQuestion(ctx context.Context, id string, input QuestionUpdateInput) (*Question, error) {
+	var question *Question
+	err := c.mutate(ctx, func(ctx context.Context, g graphql.Client) error {
+		resp, err := updateQuestion(ctx, g, id, &input)
+		if err == nil {
+			question = resp.UpdateQuestion
+		}
+		return err
+	})
+	return question, err
+}
+
+// DeleteQuestion removes a question and its answers. version guards the change like in
+// UpdateQuestion.
+func (c *Client) DeleteQuestion(ctx context.Context, id string, version int32) error {
+	return c.mutate(ctx, func(ctx context.Context, g graphql.Client) error {
+		_, err := deleteQuestion(ctx, g, id, version)
+		return err
+	})
+}
+
+// UpdateOption changes the fields of a choice option set in the input.
+func (c *Client) UpdateOption(ctx context.Context, id string, input OptionUpdateInput) (*Option, error) {
+	var option *Option
+	err := c.mutate(ctx, func(ctx context.Context, g graphql.Client) error {
+		resp, err := updateOption(ctx, g, id, &input)
+		if err == nil {
+			option = resp.UpdateOption
+		}
+		return err
+	})
+	return option, err
+}
+
+// DeleteOption removes a choice option; version guards the change like in UpdateQuestion.
+func (c *Client) DeleteOption(ctx context.Context, id string, version int32) error {
+	return c.mutate(ctx, func(ctx context.Context, g graphql.Client) error {
+		_, err := deleteOption(ctx, g, id, version)
+		return err
+	})
+}
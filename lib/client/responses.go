@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"iter"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+// Responses lists the responses of a form, newest first, fetching them page by page as
+// the loop advances. filter may be nil. The iteration stops at the first error, which it
+// yields.
+func (c *Client) Responses(ctx context.Context, formID string, filter *FormResponseFilter) iter.Seq2[*FormResponse, error] {
+	return paginate(c.pageSize, func(limit, offset int32) ([]*FormResponse, error) {
+		var responses []*FormResponse
+		err := c.query(ctx, func(ctx context.Context, g graphql.Client) error {
+			resp, err := listResponses(ctx, g, formID, filter, limit, offset)
+			if err == nil {
+				responses = resp.FormResponses
+			}
+			return err
+		})
+		return responses, err
+	})
+}
+
+// Response returns a response with its answers.
+func (c *Client) Response(ctx context.Context, id string) (*FormResponse, error) {
+	var response *FormResponse
+	err := c.query(ctx, func(ctx context.Context, g graphql.Client) error {
+		resp, err := getResponse(ctx, g, id)
+		if err == nil {
+			response = resp.FormResponse
+		}
+		return err
+	})
+	return found(response, err)
+}
+
+// SubmitResponse stores a response. A submission with input.IdempotencyKey set is
+// retried like a query, since the server answers a repeat with the ID of the stored response;
+// without a key it is retried only when it certainly did not reach the server.
+func (c *Client) SubmitResponse(ctx context.Context, input FormResponseInput) (*FormResponse, error) {
+	run := c.mutate
+	if input.IdempotencyKey != nil {
+		run = c.query
+	}
+
+	var response *FormResponse
+	err := run(ctx, func(ctx context.Context, g graphql.Client) error {
+		resp, err := submitResponse(ctx, g, &input)
+		if err == nil {
+			response = resp.SubmitFormResponse
+		}
+		return err
+	})
+	return response, err
+}
+
+// DeleteResponse moves a response to the trash.
+func (c *Client) DeleteResponse(ctx context.Context, id string) error {
+	return c.mutate(ctx, func(ctx context.Context, g graphql.Client) error {
+		_, err := deleteResponse(ctx, g, id)
+		return err
+	})
+}
+
+// SetResponseStatus moves a response to one of the review statuses of its form.
+func (c *Client) SetResponseStatus(ctx context.Context, id, status string) (*FormResponse, error) {
+	var response *FormResponse
+	err := c.mutate(ctx, func(ctx context.Context, g graphql.Client) error {
+		resp, err := setResponseStatus(ctx, g, id, status)
+		if err == nil {
+			response = resp.SetResponseStatus
+		}
+		return err
+	})
+	return response, err
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// retryPolicy decides whether and when a failed request is sent again. The delay
+// doubles with every attempt, up to limit, with jitter so that many clients failing
+// together do not come back together; a Retry-After of the server takes precedence.
+type retryPolicy struct {
+	retries int
+	base    time.Duration
+	limit   time.Duration
+}
+
+func (p retryPolicy) next(attempt int, idempotent bool, err error) (time.Duration, bool) {
+	if attempt >= p.retries || !retryable(err, idempotent) {
+		return 0, false
+	}
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) && apiErr.retryAfter > 0 {
+		return min(apiErr.retryAfter, p.limit), true
+	}
+
+	delay := p.base << attempt
+	if delay <= 0 || delay > p.limit {
+		delay = p.limit
+	}
+	// Full jitter over the upper half keeps the delay growing
+	return delay/2 + rand.N(delay/2+1), true
+}
+
+// retryable reports whether err is temporary. Requests that may have reached the
+// server are repeated only when they are idempotent; 429 and 503 are answered before
+// the operation runs, and a refused connection never reached the server.
+func retryable(err error, idempotent bool) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			return true
+		case http.StatusBadGateway, http.StatusGatewayTimeout:
+			return idempotent
+		}
+		return false
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return idempotent
+}
+
+// retryAfterKey holds, in the context of a request, where the transport records the
+// Retry-After of the response.
+type retryAfterKey struct{}
+
+func withRetryAfter(ctx context.Context) (context.Context, *time.Duration) {
+	var retryAfter time.Duration
+	return context.WithValue(ctx, retryAfterKey{}, &retryAfter), &retryAfter
+}
+
+// recordRetryAfter keeps the Retry-After of a 429 or 503 response, in seconds or as a
+// date.
+func recordRetryAfter(req *http.Request, resp *http.Response) {
+	target, ok := req.Context().Value(retryAfterKey{}).(*time.Duration)
+	if !ok || (resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable) {
+		return
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		*target = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		*target = time.Until(date)
+	}
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelays(t *testing.T) {
+	p := retryPolicy{retries: 5, base: 100 * time.Millisecond, limit: time.Second}
+	throttled := &Error{StatusCode: http.StatusServiceUnavailable}
+
+	for attempt, ceiling := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second} {
+		for range 20 {
+			delay, retry := p.next(attempt, false, throttled)
+			if !retry || delay < ceiling/2 || delay > ceiling {
+				t.Fatalf("next(%d) = %v, %v, want a delay in [%v, %v]", attempt, delay, retry, ceiling/2, ceiling)
+			}
+		}
+	}
+	if _, retry := p.next(5, false, throttled); retry {
+		t.Error("retried past the limit of attempts")
+	}
+
+	asked := &Error{StatusCode: http.StatusTooManyRequests, retryAfter: 300 * time.Millisecond}
+	if delay, _ := p.next(0, false, asked); delay != 300*time.Millisecond {
+		t.Errorf("delay = %v, want the Retry-After", delay)
+	}
+	asked.retryAfter = time.Hour
+	if delay, _ := p.next(0, false, asked); delay != time.Second {
+		t.Errorf("delay = %v, want the Retry-After bounded by the limit", delay)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	reset := &net.OpError{Op: "read", Err: errors.New("connection reset")}
+
+	tests := []struct {
+		name          string
+		err           error
+		query, mutate bool
+	}{
+		{"429", &Error{StatusCode: http.StatusTooManyRequests}, true, true},
+		{"503", &Error{StatusCode: http.StatusServiceUnavailable}, true, true},
+		{"502", &Error{StatusCode: http.StatusBadGateway}, true, false},
+		{"504", &Error{StatusCode: http.StatusGatewayTimeout}, true, false},
+		{"500", &Error{StatusCode: http.StatusInternalServerError}, false, false},
+		{"operation error", &Error{StatusCode: http.StatusOK, Message: "access denied"}, false, false},
+		{"refused connection", refused, true, true},
+		{"reset connection", reset, true, false},
+		{"canceled", context.Canceled, false, false},
+		{"deadline", context.DeadlineExceeded, false, false},
+	}
+	for _, tt := range tests {
+		if got := retryable(tt.err, true); got != tt.query {
+			t.Errorf("%s: retryable(query) = %v, want %v", tt.name, got, tt.query)
+		}
+		if got := retryable(tt.err, false); got != tt.mutate {
+			t.Errorf("%s: retryable(mutation) = %v, want %v", tt.name, got, tt.mutate)
+		}
+	}
+}
+
+func TestRecordRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		header string
+		min    time.Duration
+		max    time.Duration
+	}{
+		{"seconds", http.StatusTooManyRequests, "3", 3 * time.Second, 3 * time.Second},
+		{"date", http.StatusServiceUnavailable, time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), 58 * time.Second, time.Minute},
+		{"garbage", http.StatusTooManyRequests, "soon", 0, 0},
+		{"other status", http.StatusBadGateway, "3", 0, 0},
+	}
+	for _, tt := range tests {
+		ctx, retryAfter := withRetryAfter(context.Background())
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com", nil)
+		resp := &http.Response{StatusCode: tt.status, Header: http.Header{"Retry-After": {tt.header}}}
+
+		recordRetryAfter(req, resp)
+		if *retryAfter < tt.min || *retryAfter > tt.max {
+			t.Errorf("%s: Retry-After = %v, want between %v and %v", tt.name, *retryAfter, tt.min, tt.max)
+		}
+	}
+}
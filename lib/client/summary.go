@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"math"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+// Summary aggregates the answers to the questions of a form.
+type Summary struct {
+	Form      *Form
+	Responses int
+	// Questions follow the order of the form
+	Questions []*QuestionSummary
+}
+
+// QuestionSummary aggregates the answers to one question. Only the fields of its type
+// are set.
+type QuestionSummary struct {
+	Question *Question
+	// Answered is the number of responses that answered the question
+	Answered int
+	// Options count the choices of SINGLE_CHOICE and MULTIPLE_CHOICE questions, in the
+	// order of the options
+	Options []*OptionCount
+	// Yes and No count the answers to BOOLEAN questions
+	Yes, No int
+	// Number describes the answers to NUMBER questions, nil when there are none
+	Number *NumberSummary
+}
+
+type OptionCount struct {
+	Option *Option
+	Count  int
+}
+
+type NumberSummary struct {
+	Min, Max, Mean float64
+}
+
+// Summarize reads every response of the form and aggregates its answers per question.
+// filter may be nil. For two questions at once use CrossTab, which the server computes.
+func (c *Client) Summarize(ctx context.Context, formID string, filter *FormResponseFilter) (*Summary, error) {
+	form, err := c.Form(ctx, formID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{Form: form, Questions: make([]*QuestionSummary, len(form.Questions))}
+	byQuestion := make(map[string]*QuestionSummary, len(form.Questions))
+	sums := make(map[string]float64)
+	for i, question := range form.Questions {
+		s := &QuestionSummary{Question: question}
+		for _, option := range question.Options {
+			s.Options = append(s.Options, &OptionCount{Option: option})
+		}
+		summary.Questions[i] = s
+		byQuestion[question.Id] = s
+	}
+
+	for response, err := range c.Responses(ctx, formID, filter) {
+		if err != nil {
+			return nil, err
+		}
+		summary.Responses++
+
+		for _, answer := range response.Answers {
+			s, ok := byQuestion[answer.QuestionId]
+			if !ok || !answered(answer) {
+				continue
+			}
+			s.Answered++
+
+			for _, selected := range answer.SelectedOptions {
+				for _, count := range s.Options {
+					if count.Option.Id == selected.Id {
+						count.Count++
+					}
+				}
+			}
+			if answer.BoolValue != nil {
+				if *answer.BoolValue {
+					s.Yes++
+				} else {
+					s.No++
+				}
+			}
+			if answer.NumberValue != nil {
+				value := *answer.NumberValue
+				if s.Number == nil {
+					s.Number = &NumberSummary{Min: value, Max: value}
+				}
+				s.Number.Min = math.Min(s.Number.Min, value)
+				s.Number.Max = math.Max(s.Number.Max, value)
+				sums[answer.QuestionId] += value
+			}
+		}
+	}
+
+	for _, s := range summary.Questions {
+		if s.Number != nil {
+			s.Number.Mean = sums[s.Question.Id] / float64(s.Answered)
+		}
+	}
+	return summary, nil
+}
+
+func answered(a *Answer) bool {
+	return (a.TextValue != nil && *a.TextValue != "") || a.BoolValue != nil ||
+		a.NumberValue != nil || a.DateValue != nil || len(a.SelectedOptions) > 0
+}
+
+// CrossTabOptions narrows a cross tabulation and splits number questions into buckets.
+type CrossTabOptions struct {
+	Filters       []*CrossTabFilterInput
+	RowBuckets    *NumberBucketsInput
+	ColumnBuckets *NumberBucketsInput
+}
+
+// CrossTab counts the responses by the answers to two questions, with the chi-square
+// test of their independence.
+func (c *Client) CrossTab(ctx context.Context, formID, rowQuestionID, columnQuestionID string, opts CrossTabOptions) (*CrossTab, error) {
+	var table *CrossTab
+	err := c.query(ctx, func(ctx context.Context, g graphql.Client) error {
+		resp, err := crossTab(ctx, g, formID, rowQuestionID, columnQuestionID,
+			opts.Filters, opts.RowBuckets, opts.ColumnBuckets)
+		if err == nil {
+			table = resp.CrossTab
+		}
+		return err
+	})
+	return table, err
+}
@@ -0,0 +1,163 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers of a webhook delivery. The signature is "sha256=" and the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the webhook secret.
+const (
+	SignatureHeader = "X-Formify-Signature"
+	TimestampHeader = "X-Formify-Timestamp"
+	EventHeader     = "X-Formify-Event"
+	DeliveryHeader  = "X-Formify-Delivery"
+)
+
+// DefaultWebhookTolerance is how old a delivery VerifyWebhook accepts by default. Every
+// attempt is signed anew, so retried deliveries are not older than this.
+const DefaultWebhookTolerance = 5 * time.Minute
+
+// maxWebhookBody bounds the body ReadWebhook reads
+const maxWebhookBody = 1 << 20
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrStaleWebhook means the timestamp of the delivery is outside the tolerance,
+	// as with a replayed request
+	ErrStaleWebhook = errors.New("webhook timestamp outside tolerance")
+)
+
+// Webhook events
+const (
+	WebhookResponseCreated = "response.created"
+	WebhookResponseUpdated = "response.updated"
+	WebhookFormClosed      = "form.closed"
+)
+
+// WebhookPayload is the body of a delivery.
+type WebhookPayload struct {
+	// ID identifies the event; it is the same for every webhook that receives it and for
+	// every attempt, so receivers can drop duplicates by it
+	ID        string          `json:"id"`
+	Event     string          `json:"event"`
+	FormID    string          `json:"formId"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// WebhookResponse is the data of response.created and response.updated.
+type WebhookResponse struct {
+	ResponseID  string          `json:"responseId"`
+	SubmittedAt time.Time       `json:"submittedAt"`
+	UpdatedAt   *time.Time      `json:"updatedAt,omitempty"`
+	Answers     []WebhookAnswer `json:"answers"`
+}
+
+type WebhookAnswer struct {
+	QuestionID  string     `json:"questionId"`
+	TextValue   *string    `json:"textValue,omitempty"`
+	BoolValue   *bool      `json:"boolValue,omitempty"`
+	NumberValue *float64   `json:"numberValue,omitempty"`
+	DateValue   *time.Time `json:"dateValue,omitempty"`
+	OptionIDs   []string   `json:"optionIds,omitempty"`
+}
+
+// WebhookForm is the data of form.closed.
+type WebhookForm struct {
+	Title    string    `json:"title"`
+	ClosedAt time.Time `json:"closedAt"`
+}
+
+// Response decodes the data of a response event.
+func (p *WebhookPayload) Response() (*WebhookResponse, error) {
+	if p.Event != WebhookResponseCreated && p.Event != WebhookResponseUpdated {
+		return nil, fmt.Errorf("%s is not a response event", p.Event)
+	}
+	var data WebhookResponse
+	if err := json.Unmarshal(p.Data, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// Form decodes the data of form.closed.
+func (p *WebhookPayload) Form() (*WebhookForm, error) {
+	if p.Event != WebhookFormClosed {
+		return nil, fmt.Errorf("%s is not a form event", p.Event)
+	}
+	var data WebhookForm
+	if err := json.Unmarshal(p.Data, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// VerifyWebhook checks the signature of a delivery against the secret of the webhook
+// and that it was signed at most tolerance ago; 0 means DefaultWebhookTolerance. body
+// must be the raw request body, as any change to it breaks the signature.
+func VerifyWebhook(secret string, header http.Header, body []byte, tolerance time.Duration) error {
+	if tolerance <= 0 {
+		tolerance = DefaultWebhookTolerance
+	}
+
+	timestamp, err := strconv.ParseInt(header.Get(TimestampHeader), 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	signature, ok := strings.CutPrefix(header.Get(SignatureHeader), "sha256=")
+	if !ok {
+		return ErrInvalidSignature
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+
+	age := time.Since(time.Unix(timestamp, 0))
+	if math.Abs(float64(age)) > float64(tolerance) {
+		return ErrStaleWebhook
+	}
+	return nil
+}
+
+// ReadWebhook reads the body of a delivery, verifies it like VerifyWebhook and decodes
+// the payload. It is meant for the handler of the receiver:
+//
+//	payload, err := client.ReadWebhook(r, secret, 0)
+//	if err != nil {
+//		http.Error(w, err.Error(), http.StatusUnauthorized)
+//		return
+//	}
+func ReadWebhook(r *http.Request, secret string, tolerance time.Duration) (*WebhookPayload, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyWebhook(secret, r.Header, body, tolerance); err != nil {
+		return nil, err
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
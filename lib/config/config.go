@@ -1,9 +1,12 @@
 package config
 
 import (
+	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -13,11 +16,61 @@ type Config struct {
 	RedisUrl    string `required:"true"  default:"redis://localhost:6379/0"    envconfig:"REDIS_URL"`
 	DatabaseUrl string `required:"true"                                        envconfig:"DATABASE_URL"`
 	SiteBaseUrl string `required:"true"  default:"https://localhost:8080" envconfig:"SITE_BASE_URL"`
-
-	GOOGLE_CLIENT_ID     string `required:"true" envconfig:"GOOGLE_CLIENT_ID"`
-	GOOGLE_CLIENT_SECRET string `required:"true" envconfig:"GOOGLE_CLIENT_SECRET"`
+	// Адреса или сети обратных прокси, которым доверяется X-Forwarded-For
+	TrustedProxies []string `envconfig:"TRUSTED_PROXIES"`
+
+	// Вход через Google и GitHub; провайдер без CLIENT_ID отключён
+	GOOGLE_CLIENT_ID     string `envconfig:"GOOGLE_CLIENT_ID"`
+	GOOGLE_CLIENT_SECRET string `envconfig:"GOOGLE_CLIENT_SECRET"`
+	GITHUB_CLIENT_ID     string `envconfig:"GITHUB_CLIENT_ID"`
+	GITHUB_CLIENT_SECRET string `envconfig:"GITHUB_CLIENT_SECRET"`
+	// Адрес GitHub Enterprise Server; пусто для github.com
+	GithubUrl string `envconfig:"GITHUB_URL"`
+	// Сколько ждать ответа провайдера входа
+	OAuthTimeout time.Duration `default:"10s" envconfig:"OAUTH_TIMEOUT"`
+
+	// Другие провайдеры OpenID Connect: имена через запятую. Настройки провайдера
+	// читаются из OIDC_<ИМЯ>_ISSUER, OIDC_<ИМЯ>_CLIENT_ID, OIDC_<ИМЯ>_CLIENT_SECRET и OIDC_<ИМЯ>_SCOPES
+	OidcProviderNames []string       `envconfig:"OIDC_PROVIDERS"`
+	OidcProviders     []OidcProvider `ignored:"true"`
+	// Куда вернуть пользователя после входа через провайдера
+	LoginRedirectUrl string `default:"https://localhost:3000" envconfig:"LOGIN_REDIRECT_URL"`
 
 	CYPHER_KEY string `required:"true" envconfig:"CYPHER_KEY"`
+
+	// Сколько удалённые ответы хранятся в корзине до окончательной очистки
+	ResponsePurgeDelay time.Duration `default:"720h" envconfig:"RESPONSE_PURGE_DELAY"`
+	// Сколько удалённые формы хранятся в корзине до окончательной очистки
+	FormPurgeDelay time.Duration `default:"720h" envconfig:"FORM_PURGE_DELAY"`
+	// Время жизни черновика ответа с момента последнего сохранения
+	DraftTTL time.Duration `default:"168h" envconfig:"DRAFT_TTL"`
+	// Сколько действует приглашение в форму
+	InvitationTTL time.Duration `default:"168h" envconfig:"INVITATION_TTL"`
+
+	// Сколько раз повторяется неудачная доставка вебхука и сколько ждать ответа получателя
+	WebhookMaxAttempts int           `default:"8"   envconfig:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookTimeout     time.Duration `default:"10s" envconfig:"WEBHOOK_TIMEOUT"`
+	// Разрешить вебхуки на локальные и внутренние адреса — только для разработки и тестов
+	AllowPrivateWebhooks bool `default:"false" envconfig:"ALLOW_PRIVATE_WEBHOOKS"`
+
+	// SMTP-сервер для писем; по умолчанию локальный MailHog
+	SmtpHost        string `default:"localhost"                          envconfig:"SMTP_HOST"`
+	SmtpPort        int    `default:"1025"                               envconfig:"SMTP_PORT"`
+	SmtpUsername    string `                                             envconfig:"SMTP_USERNAME"`
+	SmtpPassword    string `                                             envconfig:"SMTP_PASSWORD"`
+	MailFrom        string `default:"Formify <no-reply@formify.local>"   envconfig:"MAIL_FROM"`
+	MailMaxAttempts int    `default:"6"                                  envconfig:"MAIL_MAX_ATTEMPTS"`
+	// Сколько может длиться отправка одного письма
+	SmtpTimeout time.Duration `default:"30s" envconfig:"SMTP_TIMEOUT"`
+}
+
+// Провайдер OpenID Connect из OIDC_PROVIDERS
+type OidcProvider struct {
+	Name         string   `ignored:"true"`
+	Issuer       string   `required:"true" envconfig:"ISSUER"`
+	ClientID     string   `required:"true" envconfig:"CLIENT_ID"`
+	ClientSecret string   `envconfig:"CLIENT_SECRET"`
+	Scopes       []string `envconfig:"SCOPES"`
 }
 
 func (c *Config) GetGoogleCallbackUrl() string {
@@ -29,6 +82,35 @@ func (c *Config) GetGoogleCallbackUrl() string {
 	return u.JoinPath("/google/callback").String()
 }
 
+// GetOAuthCallbackUrl is where a provider sends the user back after login
+func (c *Config) GetOAuthCallbackUrl(provider string) string {
+	u, err := url.Parse(c.SiteBaseUrl)
+	if err != nil {
+		panic(err)
+	}
+
+	return u.JoinPath("/oauth", provider, "callback").String()
+}
+
+// loadOidcProviders reads the settings of every provider named in OIDC_PROVIDERS
+func (c *Config) loadOidcProviders() error {
+	c.OidcProviders = nil
+	for _, name := range c.OidcProviderNames {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		provider := OidcProvider{Name: name}
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		if err := envconfig.Process(prefix, &provider); err != nil {
+			return fmt.Errorf("oidc provider %s: %w", name, err)
+		}
+		c.OidcProviders = append(c.OidcProviders, provider)
+	}
+	return nil
+}
+
 func NewWithEnvPath(envPath string) (*Config, error) {
 	var newCfg Config
 	_ = godotenv.Load(envPath)
@@ -36,6 +118,9 @@ func NewWithEnvPath(envPath string) (*Config, error) {
 	if err := envconfig.Process("", &newCfg); err != nil {
 		return nil, err
 	}
+	if err := newCfg.loadOidcProviders(); err != nil {
+		return nil, err
+	}
 
 	return &newCfg, nil
 }
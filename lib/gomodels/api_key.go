@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Права API-ключа
+type ApiKeyScope string
+
+const (
+    ApiKeyScopeFormsRead      ApiKeyScope = "forms:read"
+    ApiKeyScopeFormsWrite     ApiKeyScope = "forms:write"
+    ApiKeyScopeResponsesRead  ApiKeyScope = "responses:read"
+    ApiKeyScopeResponsesWrite ApiKeyScope = "responses:write"
+)
+
+// API-ключ пользователя для интеграций. Хранится только SHA-256 ключа, сам ключ
+// показывается один раз при создании; Prefix — начало ключа, по нему ключ узнают в списке.
+// Отозванный ключ остаётся в таблице с RevokedAt.
+type ApiKey struct {
+    ID         string         `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+    UserID     string         `gorm:"column:user_id;type:uuid;not null;index" json:"userId"`
+    Name       string         `gorm:"column:name;type:varchar(255);not null" json:"name"`
+    Prefix     string         `gorm:"column:prefix;type:varchar(32);not null" json:"prefix"`
+    KeyHash    string         `gorm:"column:key_hash;type:varchar(64);not null;uniqueIndex" json:"-"`
+    Scopes     pq.StringArray `gorm:"column:scopes;type:text[]" json:"scopes"`
+    ExpiresAt  *time.Time     `gorm:"column:expires_at;type:timestamp" json:"expiresAt,omitempty"`
+    LastUsedAt *time.Time     `gorm:"column:last_used_at;type:timestamp" json:"lastUsedAt,omitempty"`
+    RevokedAt  *time.Time     `gorm:"column:revoked_at;type:timestamp" json:"revokedAt,omitempty"`
+    CreatedAt  time.Time      `gorm:"column:created_at;type:timestamp;default:current_timestamp" json:"createdAt"`
+}
+
+func (ApiKey) TableName() string {
+    return "api_keys"
+}
+
+// HasScope reports whether the key grants the scope
+func (k *ApiKey) HasScope(scope ApiKeyScope) bool {
+    return containsString(k.Scopes, string(scope))
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+)
+
+// Состояние письма в очереди отправки
+type EmailStatus string
+
+const (
+    EmailPending EmailStatus = "PENDING"
+    EmailSent    EmailStatus = "SENT"
+    EmailFailed  EmailStatus = "FAILED"
+)
+
+// Письмо в очереди: уже отрисованное по шаблону и ожидающее отправки по SMTP
+type EmailMessage struct {
+    ID            string      `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+    To            string      `gorm:"column:to;type:varchar(255);not null" json:"to"`
+    Template      string      `gorm:"column:template;type:varchar(64)" json:"template"`
+    Subject       string      `gorm:"column:subject;type:text" json:"subject"`
+    TextBody      string      `gorm:"column:text_body;type:text" json:"textBody"`
+    HTMLBody      string      `gorm:"column:html_body;type:text" json:"htmlBody"`
+    Status        EmailStatus `gorm:"column:status;type:varchar(16);default:'PENDING';index:idx_email_message_due,priority:1" json:"status"`
+    Attempts      int32       `gorm:"column:attempts;default:0" json:"attempts"`
+    NextAttemptAt time.Time   `gorm:"column:next_attempt_at;type:timestamp;index:idx_email_message_due,priority:2" json:"nextAttemptAt"`
+    Error         *string     `gorm:"column:error;type:text" json:"error,omitempty"`
+    CreatedAt     time.Time   `gorm:"column:created_at;type:timestamp;default:current_timestamp" json:"createdAt"`
+    SentAt        *time.Time  `gorm:"column:sent_at;type:timestamp" json:"sentAt,omitempty"`
+}
+
+func (EmailMessage) TableName() string {
+    return "email_messages"
+}
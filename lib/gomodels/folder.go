@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+)
+
+// Папка для форм. Принадлежит либо пользователю (личное пространство),
+// либо рабочему пространству; папки могут быть вложенными.
+type Folder struct {
+    ID          string    `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+    Name        string    `gorm:"column:name;type:varchar(255);not null" json:"name"`
+    ParentID    *string   `gorm:"column:parent_id;type:uuid;index" json:"parentId,omitempty"`
+    OwnerID     *string   `gorm:"column:owner_id;type:uuid;index" json:"ownerId,omitempty"`
+    WorkspaceID *string   `gorm:"column:workspace_id;type:uuid;index" json:"workspaceId,omitempty"`
+    CreatedAt   time.Time `gorm:"column:created_at;type:timestamp;default:current_timestamp" json:"createdAt"`
+    UpdatedAt   time.Time `gorm:"column:updated_at;type:timestamp;default:current_timestamp" json:"updatedAt"`
+}
+
+func (Folder) TableName() string {
+    return "folders"
+}
+
+// SameScope reports whether both folders belong to the same user or workspace
+func (f *Folder) SameScope(other *Folder) bool {
+    return equalStrings(f.OwnerID, other.OwnerID) && equalStrings(f.WorkspaceID, other.WorkspaceID)
+}
+
+// Holds reports whether a form may be put into the folder: workspace forms go to
+// folders of their workspace, personal forms to folders of their owner.
+func (f *Folder) Holds(form *Form) bool {
+    if form.WorkspaceID != nil {
+        return equalStrings(f.WorkspaceID, form.WorkspaceID)
+    }
+    return f.OwnerID != nil && *f.OwnerID == form.OwnerID
+}
+
+// Избранная (закреплённая) пользователем форма
+type FormStar struct {
+    UserID    string    `gorm:"column:user_id;type:uuid;primaryKey" json:"userId"`
+    FormID    string    `gorm:"column:form_id;type:uuid;primaryKey;index" json:"formId"`
+    CreatedAt time.Time `gorm:"column:created_at;type:timestamp;default:current_timestamp" json:"createdAt"`
+}
+
+func (FormStar) TableName() string {
+    return "form_stars"
+}
+
+func equalStrings(a, b *string) bool {
+    if a == nil || b == nil {
+        return a == b
+    }
+    return *a == *b
+}
@@ -2,6 +2,9 @@ package model
 
 import (
 	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
 )
 
 // Тип доступа к форме
@@ -13,6 +16,16 @@ const (
     FormAccessPublic    FormAccess = "PUBLIC"
 )
 
+// Как владелец узнаёт о новых ответах
+type ResponseNotifications string
+
+const (
+    ResponseNotificationsNone   ResponseNotifications = "NONE"
+    ResponseNotificationsEach   ResponseNotifications = "EACH"
+    ResponseNotificationsHourly ResponseNotifications = "HOURLY"
+    ResponseNotificationsDaily  ResponseNotifications = "DAILY"
+)
+
 // Типы вопросов
 type QuestionType string
 
@@ -29,14 +42,38 @@ const (
 )
 
 type Form struct {
-    ID          string      `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
-    OwnerID     string      `gorm:"column:owner_id;type:uuid;not null;index" json:"ownerId"`
-    Title       string      `gorm:"column:title;type:varchar(255)" json:"title"`
-    Description string      `gorm:"column:description;type:text" json:"description"`
-    Access      FormAccess  `gorm:"column:access;type:varchar(16);default:'private'" json:"access"`
-    CreatedAt   time.Time   `gorm:"column:createdAt;type:timestamp;default:current_timestamp" json:"createdAt"`
-    UpdatedAt   time.Time   `gorm:"column:updatedAt;type:timestamp;default:current_timestamp" json:"updatedAt"`
-    Questions   []Question  `gorm:"foreignKey:FormID" json:"questions"`
+    ID                    string                `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+    OwnerID               string                `gorm:"column:owner_id;type:uuid;not null;index;uniqueIndex:idx_form_owner_key,priority:1,where:\"workspaceId\" IS NULL" json:"ownerId"`
+    // Рабочее пространство формы; пусто для форм в личном пространстве владельца
+    WorkspaceID           *string               `gorm:"column:workspaceId;type:uuid;index;uniqueIndex:idx_form_workspace_key,priority:1,where:\"workspaceId\" IS NOT NULL" json:"workspaceId,omitempty"`
+    // Папка внутри пространства формы и произвольные метки для поиска
+    FolderID              *string               `gorm:"column:folderId;type:uuid;index" json:"folderId,omitempty"`
+    Tags                  pq.StringArray        `gorm:"column:tags;type:text[]" json:"tags"`
+    // Шаблон, из которого можно создавать новые формы
+    IsTemplate            bool                  `gorm:"column:isTemplate;default:false" json:"isTemplate"`
+    // Постоянный ключ формы в описаниях форм, которые хранятся в git (formify plan/apply);
+    // уникален среди форм рабочего пространства или личных форм владельца, включая корзину
+    ExternalKey           *string               `gorm:"column:externalKey;type:varchar(255);index;uniqueIndex:idx_form_workspace_key,priority:2;uniqueIndex:idx_form_owner_key,priority:2" json:"externalKey,omitempty"`
+    Title                 string                `gorm:"column:title;type:varchar(255)" json:"title"`
+    Description           string                `gorm:"column:description;type:text" json:"description"`
+    Access                FormAccess            `gorm:"column:access;type:varchar(16);default:'private'" json:"access"`
+    CreatedAt             time.Time             `gorm:"column:createdAt;type:timestamp;default:current_timestamp" json:"createdAt"`
+    UpdatedAt             time.Time             `gorm:"column:updatedAt;type:timestamp;default:current_timestamp" json:"updatedAt"`
+    // Удалённые формы лежат в корзине до окончательной очистки
+    DeletedAt             gorm.DeletedAt        `gorm:"column:deletedAt;index" json:"deletedAt,omitempty"`
+    // Дополнительные статусы проверки ответов, помимо встроенных
+    ReviewStatuses        pq.StringArray        `gorm:"column:reviewStatuses;type:text[]" json:"reviewStatuses"`
+    // Разрешено ли респондентам исправлять отправленные ответы и до какого момента
+    AllowResponseEditing  bool                  `gorm:"column:allowResponseEditing;default:false" json:"allowResponseEditing"`
+    ResponseEditDeadline  *time.Time            `gorm:"column:responseEditDeadline;type:timestamp" json:"responseEditDeadline,omitempty"`
+    // Закрытая форма не принимает новые ответы
+    ClosedAt              *time.Time            `gorm:"column:closedAt;type:timestamp" json:"closedAt,omitempty"`
+    // Уведомления владельцу о новых ответах и время последней сводки
+    ResponseNotifications ResponseNotifications `gorm:"column:responseNotifications;type:varchar(16);default:'NONE'" json:"responseNotifications"`
+    LastDigestAt          *time.Time            `gorm:"column:lastDigestAt;type:timestamp" json:"lastDigestAt,omitempty"`
+    // Растёт при каждом изменении формы, её вопросов или вариантов
+    Version               int64                 `gorm:"column:version;not null;default:1" json:"version"`
+    Questions             []Question            `gorm:"foreignKey:FormID" json:"questions"`
 }
 
 func (Form) TableName() string {
@@ -45,13 +82,17 @@ func (Form) TableName() string {
 
 // Вопрос
 type Question struct {
-    ID        string       `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
-    FormID    string       `gorm:"column:form_id;type:uuid;not null;index" json:"formId"`
-    Text      string       `gorm:"column:text;type:text" json:"text"`
-    Type      QuestionType `gorm:"column:type;type:varchar(32)" json:"type"`
-    Required  bool         `gorm:"column:required;default:false" json:"required"`
-    Order     int32         `gorm:"column:order" json:"order"`
-    Options   []*Option     `gorm:"foreignKey:QuestionID" json:"options,omitempty"` // для single/multiple choice
+    ID              string       `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+    FormID          string       `gorm:"column:form_id;type:uuid;not null;index;uniqueIndex:idx_question_key" json:"formId"`
+    // Постоянный ключ вопроса для REST API и JSON Schema формы, уникален в пределах формы
+    Key             *string      `gorm:"column:key;type:varchar(64);uniqueIndex:idx_question_key" json:"key,omitempty"`
+    Text            string       `gorm:"column:text;type:text" json:"text"`
+    Type            QuestionType `gorm:"column:type;type:varchar(32)" json:"type"`
+    Required        bool         `gorm:"column:required;default:false" json:"required"`
+    Order           int32        `gorm:"column:order" json:"order"`
+    // На адрес из ответа на этот вопрос (типа EMAIL) респондент получает копию своих ответов
+    RespondentEmail bool         `gorm:"column:respondent_email;default:false" json:"respondentEmail"`
+    Options         []*Option    `gorm:"foreignKey:QuestionID" json:"options,omitempty"` // для single/multiple choice
 }
 
 func (Question) TableName() string {
@@ -68,4 +109,25 @@ type Option struct {
 
 func (Option) TableName() string {
     return "options"
-}
\ No newline at end of file
+}
+
+// Statuses returns every review status a response of the form may take
+func (f *Form) Statuses() []string {
+    statuses := make([]string, 0, len(DefaultResponseStatuses)+len(f.ReviewStatuses))
+    statuses = append(statuses, DefaultResponseStatuses...)
+    for _, s := range f.ReviewStatuses {
+        if !containsString(statuses, s) {
+            statuses = append(statuses, s)
+        }
+    }
+    return statuses
+}
+
+func containsString(list []string, value string) bool {
+    for _, v := range list {
+        if v == value {
+            return true
+        }
+    }
+    return false
+}
@@ -2,13 +2,43 @@ package model
 
 import (
 	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// Встроенные статусы проверки ответа. Форма может добавить к ним свои.
+const (
+    ResponseStatusNew      = "NEW"
+    ResponseStatusInReview = "IN_REVIEW"
+    ResponseStatusApproved = "APPROVED"
+    ResponseStatusRejected = "REJECTED"
 )
 
+var DefaultResponseStatuses = []string{
+    ResponseStatusNew,
+    ResponseStatusInReview,
+    ResponseStatusApproved,
+    ResponseStatusRejected,
+}
+
 type FormResponse struct {
-    ID        string    `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
-    FormID    string    `gorm:"column:form_id;type:uuid;not null;index" json:"formId"`
-    CreatedAt time.Time `gorm:"column:created_at;type:timestamp;default:current_timestamp" json:"createdAt"`
-    Answers   []Answer  `gorm:"foreignKey:ResponseID" json:"answers"`
+    ID             string         `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+    FormID         string         `gorm:"column:form_id;type:uuid;not null;index;uniqueIndex:idx_form_response_submitter_idempotency" json:"formId"`
+    CreatedAt      time.Time      `gorm:"column:created_at;type:timestamp;default:current_timestamp" json:"createdAt"`
+    // Удалённые ответы лежат в корзине до окончательной очистки
+    DeletedAt      gorm.DeletedAt `gorm:"column:deleted_at;index" json:"deletedAt,omitempty"`
+    Status         string         `gorm:"column:status;type:varchar(64);default:'NEW';index" json:"status"`
+    AssigneeID     *string        `gorm:"column:assignee_id;type:uuid;index" json:"assigneeId,omitempty"`
+    Tags           pq.StringArray `gorm:"column:tags;type:text[]" json:"tags"`
+    // Хэш секретного токена, по которому респондент может изменить свой ответ
+    EditTokenHash  *string        `gorm:"column:edit_token_hash;type:varchar(64);uniqueIndex" json:"-"`
+    UpdatedAt      *time.Time     `gorm:"column:updated_at;type:timestamp" json:"updatedAt,omitempty"`
+    // Хэш отправителя, в пределах которого действует ключ идемпотентности; у анонимных отправок он общий
+    IdempotencyScope *string      `gorm:"column:idempotency_scope;type:varchar(64);uniqueIndex:idx_form_response_submitter_idempotency" json:"-"`
+    // Ключ клиента, по которому повторная отправка того же ответа не создаёт дубликат
+    IdempotencyKey *string        `gorm:"column:idempotency_key;type:varchar(255);uniqueIndex:idx_form_response_submitter_idempotency" json:"idempotencyKey,omitempty"`
+    Answers        []Answer       `gorm:"foreignKey:ResponseID" json:"answers"`
 }
 
 func (FormResponse) TableName() string {
@@ -39,4 +69,32 @@ type AnswerOption struct {
 
 func (AnswerOption) TableName() string {
     return "answer_options"
-}
\ No newline at end of file
+}
+
+// Предыдущий набор ответов, сохранённый перед редактированием респондентом
+type ResponseRevision struct {
+    ID         string    `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+    ResponseID string    `gorm:"column:response_id;type:uuid;not null;index" json:"responseId"`
+    Revision   int32     `gorm:"column:revision" json:"revision"`
+    Answers    string    `gorm:"column:answers;type:jsonb" json:"answers"` // []Answer в JSON
+    CreatedAt  time.Time `gorm:"column:created_at;type:timestamp;default:current_timestamp" json:"createdAt"`
+}
+
+func (ResponseRevision) TableName() string {
+    return "response_revisions"
+}
+
+// Черновик ответа: частично заполненная форма, которую можно продолжить по токену
+type ResponseDraft struct {
+    ID        string    `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+    FormID    string    `gorm:"column:form_id;type:uuid;not null;index" json:"formId"`
+    TokenHash string    `gorm:"column:token_hash;type:varchar(64);not null;uniqueIndex" json:"-"`
+    Answers   string    `gorm:"column:answers;type:jsonb" json:"answers"` // ответы в том виде, в котором их прислал респондент
+    CreatedAt time.Time `gorm:"column:created_at;type:timestamp;default:current_timestamp" json:"createdAt"`
+    UpdatedAt time.Time `gorm:"column:updated_at;type:timestamp;default:current_timestamp" json:"updatedAt"`
+    ExpiresAt time.Time `gorm:"column:expires_at;type:timestamp;not null;index" json:"expiresAt"`
+}
+
+func (ResponseDraft) TableName() string {
+    return "response_drafts"
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+)
+
+// Роль пользователя в форме
+type FormRole string
+
+const (
+    FormRoleOwner   FormRole = "OWNER"
+    FormRoleEditor  FormRole = "EDITOR"
+    FormRoleAnalyst FormRole = "ANALYST"
+    FormRoleViewer  FormRole = "VIEWER"
+)
+
+// Состояние приглашения в форму
+type FormInvitationStatus string
+
+const (
+    FormInvitationPending  FormInvitationStatus = "PENDING"
+    FormInvitationAccepted FormInvitationStatus = "ACCEPTED"
+    FormInvitationDeclined FormInvitationStatus = "DECLINED"
+    FormInvitationRevoked  FormInvitationStatus = "REVOKED"
+)
+
+// Участник формы. Владелец хранится в forms.owner_id, здесь только остальные роли.
+type FormMember struct {
+    ID        string    `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+    FormID    string    `gorm:"column:form_id;type:uuid;not null;uniqueIndex:idx_form_member,priority:1" json:"formId"`
+    UserID    string    `gorm:"column:user_id;type:uuid;not null;uniqueIndex:idx_form_member,priority:2;index" json:"userId"`
+    Role      FormRole  `gorm:"column:role;type:varchar(16);not null" json:"role"`
+    CreatedAt time.Time `gorm:"column:created_at;type:timestamp;default:current_timestamp" json:"createdAt"`
+    User      *Users    `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+func (FormMember) TableName() string {
+    return "form_members"
+}
+
+// Приглашение в форму по email. Принять его можно по ссылке из письма, отклонить — с тем же адресом.
+type FormInvitation struct {
+    ID          string               `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+    FormID      string               `gorm:"column:form_id;type:uuid;not null;index" json:"formId"`
+    Email       string               `gorm:"column:email;type:varchar(255);not null;index" json:"email"`
+    Role        FormRole             `gorm:"column:role;type:varchar(16);not null" json:"role"`
+    InvitedByID string               `gorm:"column:invited_by_id;type:uuid;not null" json:"invitedById"`
+    Status      FormInvitationStatus `gorm:"column:status;type:varchar(16);default:'PENDING'" json:"status"`
+    ExpiresAt   time.Time            `gorm:"column:expires_at;type:timestamp" json:"expiresAt"`
+    CreatedAt   time.Time            `gorm:"column:created_at;type:timestamp;default:current_timestamp" json:"createdAt"`
+    RespondedAt *time.Time           `gorm:"column:responded_at;type:timestamp" json:"respondedAt,omitempty"`
+    // Хеш токена из письма с приглашением; принять приглашение можно только с этим токеном
+    TokenHash   *string              `gorm:"column:token_hash;type:varchar(64);uniqueIndex" json:"-"`
+    Form        *Form                `gorm:"foreignKey:FormID" json:"form,omitempty"`
+    InvitedBy   *Users               `gorm:"foreignKey:InvitedByID" json:"invitedBy,omitempty"`
+}
+
+func (FormInvitation) TableName() string {
+    return "form_invitations"
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// Внешний способ входа пользователя: аккаунт у провайдера (google, github, любой OpenID Connect).
+// У одного пользователя может быть несколько способов входа; аккаунт провайдера
+// привязан не больше чем к одному пользователю.
+type Identity struct {
+    ID       string `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+    UserID   string `gorm:"column:user_id;type:uuid;not null;index" json:"userId"`
+    Provider string `gorm:"column:provider;type:varchar(64);not null;uniqueIndex:idx_identity_provider_subject" json:"provider"`
+    // Идентификатор пользователя у провайдера (sub)
+    Subject string `gorm:"column:subject;type:varchar(255);not null;uniqueIndex:idx_identity_provider_subject" json:"subject"`
+    // Email, который сообщил провайдер при привязке
+    Email     string    `gorm:"column:email;type:varchar(255)" json:"email"`
+    CreatedAt time.Time `gorm:"column:created_at;type:timestamp;default:current_timestamp" json:"createdAt"`
+}
+
+func (Identity) TableName() string {
+    return "identities"
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+)
+
+// Действия, которые попадают в журнал ответа
+type ResponseActivityAction string
+
+const (
+    ResponseActivityStatusChanged   ResponseActivityAction = "STATUS_CHANGED"
+    ResponseActivityAssigneeChanged ResponseActivityAction = "ASSIGNEE_CHANGED"
+    ResponseActivityTagsChanged     ResponseActivityAction = "TAGS_CHANGED"
+    ResponseActivityNoteAdded       ResponseActivityAction = "NOTE_ADDED"
+    ResponseActivityNoteDeleted     ResponseActivityAction = "NOTE_DELETED"
+)
+
+// Внутренняя заметка к ответу. ParentID указывает на заметку, на которую отвечают.
+type ResponseNote struct {
+    ID         string    `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+    ResponseID string    `gorm:"column:response_id;type:uuid;not null;index" json:"responseId"`
+    ParentID   *string   `gorm:"column:parent_id;type:uuid;index" json:"parentId,omitempty"`
+    AuthorID   string    `gorm:"column:author_id;type:uuid;not null" json:"authorId"`
+    Author     Users     `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+    Body       string    `gorm:"column:body;type:text" json:"body"`
+    CreatedAt  time.Time `gorm:"column:created_at;type:timestamp;default:current_timestamp" json:"createdAt"`
+}
+
+func (ResponseNote) TableName() string {
+    return "response_notes"
+}
+
+// Запись журнала: кто, что и когда изменил в ответе
+type ResponseActivity struct {
+    ID         string                 `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+    ResponseID string                 `gorm:"column:response_id;type:uuid;not null;index" json:"responseId"`
+    ActorID    string                 `gorm:"column:actor_id;type:uuid;not null" json:"actorId"`
+    Actor      Users                  `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+    Action     ResponseActivityAction `gorm:"column:action;type:varchar(32)" json:"action"`
+    OldValue   *string                `gorm:"column:old_value;type:text" json:"oldValue,omitempty"`
+    NewValue   *string                `gorm:"column:new_value;type:text" json:"newValue,omitempty"`
+    CreatedAt  time.Time              `gorm:"column:created_at;type:timestamp;default:current_timestamp" json:"createdAt"`
+}
+
+func (ResponseActivity) TableName() string {
+    return "response_activities"
+}
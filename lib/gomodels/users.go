@@ -17,6 +17,10 @@ type Users struct {
 	PasswordHash string    `gorm:"column:passwordHash" json:"-"`
 	CreatedAt    time.Time `gorm:"column:createdAt;type:timestamp;default:current_timestamp" json:"createdAt"`
 	UpdatedAt    time.Time `gorm:"column:updatedAt;type:timestamp;default:current_timestamp" json:"updatedAt"`
+
+	// Пространство, выбранное пользователем; пусто для личного.
+	// Forms содержит только формы личного пространства.
+	CurrentWorkspaceID *string `gorm:"column:currentWorkspaceId;type:uuid" json:"currentWorkspaceId,omitempty"`
 }
 
 func (Users) TableName() string {
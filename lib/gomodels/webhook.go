@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// События, на которые можно подписать вебхук
+type WebhookEvent string
+
+const (
+    WebhookEventResponseCreated WebhookEvent = "response.created"
+    WebhookEventResponseUpdated WebhookEvent = "response.updated"
+    WebhookEventFormClosed      WebhookEvent = "form.closed"
+)
+
+// Состояние доставки вебхука
+type WebhookDeliveryStatus string
+
+const (
+    WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"
+    WebhookDeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+    WebhookDeliveryFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+// Подписка формы на события. Secret хранится зашифрованным, им подписывается тело запроса.
+type Webhook struct {
+    ID        string         `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+    FormID    string         `gorm:"column:form_id;type:uuid;not null;index" json:"formId"`
+    URL       string         `gorm:"column:url;type:text;not null" json:"url"`
+    Events    pq.StringArray `gorm:"column:events;type:text[]" json:"events"`
+    Secret    string         `gorm:"column:secret;type:text;not null" json:"-"`
+    Enabled   bool           `gorm:"column:enabled;default:true" json:"enabled"`
+    CreatedAt time.Time      `gorm:"column:created_at;type:timestamp;default:current_timestamp" json:"createdAt"`
+}
+
+func (Webhook) TableName() string {
+    return "webhooks"
+}
+
+// Subscribed reports whether the webhook listens to the event
+func (w *Webhook) Subscribed(event WebhookEvent) bool {
+    return containsString(w.Events, string(event))
+}
+
+// Попытка доставить событие: запрос, ответ получателя и расписание повторов
+type WebhookDelivery struct {
+    ID             string                `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+    WebhookID      string                `gorm:"column:webhook_id;type:uuid;not null;index" json:"webhookId"`
+    Event          WebhookEvent          `gorm:"column:event;type:varchar(64);not null" json:"event"`
+    RequestBody    string                `gorm:"column:request_body;type:text" json:"requestBody"`
+    Status         WebhookDeliveryStatus `gorm:"column:status;type:varchar(16);default:'PENDING';index:idx_webhook_delivery_due,priority:1" json:"status"`
+    Attempts       int32                 `gorm:"column:attempts;default:0" json:"attempts"`
+    NextAttemptAt  time.Time             `gorm:"column:next_attempt_at;type:timestamp;index:idx_webhook_delivery_due,priority:2" json:"nextAttemptAt"`
+    ResponseStatus *int32                `gorm:"column:response_status" json:"responseStatus,omitempty"`
+    ResponseBody   *string               `gorm:"column:response_body;type:text" json:"responseBody,omitempty"`
+    Error          *string               `gorm:"column:error;type:text" json:"error,omitempty"`
+    CreatedAt      time.Time             `gorm:"column:created_at;type:timestamp;default:current_timestamp" json:"createdAt"`
+    DeliveredAt    *time.Time            `gorm:"column:delivered_at;type:timestamp" json:"deliveredAt,omitempty"`
+}
+
+func (WebhookDelivery) TableName() string {
+    return "webhook_deliveries"
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+)
+
+// Роль пользователя в рабочем пространстве
+type WorkspaceRole string
+
+const (
+    // Администратор управляет пространством и всеми его формами
+    WorkspaceRoleAdmin  WorkspaceRole = "ADMIN"
+    // Участник создаёт формы в пространстве и видит остальные его формы
+    WorkspaceRoleMember WorkspaceRole = "MEMBER"
+)
+
+// Рабочее пространство команды. Формы пространства не пропадают, когда уходит их автор.
+type Workspace struct {
+    ID          string            `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+    Name        string            `gorm:"column:name;type:varchar(255);not null" json:"name"`
+    CreatedByID string            `gorm:"column:created_by_id;type:uuid;not null" json:"createdById"`
+    CreatedAt   time.Time         `gorm:"column:created_at;type:timestamp;default:current_timestamp" json:"createdAt"`
+    UpdatedAt   time.Time         `gorm:"column:updated_at;type:timestamp;default:current_timestamp" json:"updatedAt"`
+    Members     []WorkspaceMember `gorm:"foreignKey:WorkspaceID" json:"members,omitempty"`
+}
+
+func (Workspace) TableName() string {
+    return "workspaces"
+}
+
+// Участник рабочего пространства
+type WorkspaceMember struct {
+    ID          string        `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+    WorkspaceID string        `gorm:"column:workspace_id;type:uuid;not null;uniqueIndex:idx_workspace_member,priority:1" json:"workspaceId"`
+    UserID      string        `gorm:"column:user_id;type:uuid;not null;uniqueIndex:idx_workspace_member,priority:2;index" json:"userId"`
+    Role        WorkspaceRole `gorm:"column:role;type:varchar(16);not null" json:"role"`
+    CreatedAt   time.Time     `gorm:"column:created_at;type:timestamp;default:current_timestamp" json:"createdAt"`
+    User        *Users        `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+func (WorkspaceMember) TableName() string {
+    return "workspace_members"
+}
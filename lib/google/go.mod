@@ -1,3 +0,0 @@
-module github.com/TrySquadDF/formify/lib/google
-
-go 1.24.0
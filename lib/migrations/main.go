@@ -20,9 +20,41 @@ func main() {
 		log.Fatal("failed to connect database:", err)
 	}
 
+	// Form keys became unique: later forms with a taken key lose it
+	if db.Migrator().HasColumn(&model.Form{}, "externalKey") {
+		if err := db.Exec(`UPDATE forms SET "externalKey" = NULL WHERE id IN (
+			SELECT id FROM (
+				SELECT id, row_number() OVER (
+					PARTITION BY COALESCE("workspaceId"::text, 'owner:' || owner_id::text), "externalKey"
+					ORDER BY "createdAt", id
+				) AS n
+				FROM forms WHERE "externalKey" IS NOT NULL
+			) duplicates WHERE n > 1
+		)`).Error; err != nil {
+			log.Fatal("failed to clear duplicate form keys:", err)
+		}
+	}
+
+	// Idempotency keys became scoped per submitter; the index is rebuilt under a new name
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_form_response_idempotency`).Error; err != nil {
+		log.Fatal("failed to drop the idempotency index:", err)
+	}
+
 	if err := db.AutoMigrate(&model.Users{}, &model.Tokens{},
 		&model.Form{}, &model.Question{}, &model.Option{}, &model.FormResponse{},
-		&model.Answer{}, &model.AnswerOption{}); err != nil {
+		&model.Answer{}, &model.AnswerOption{}, &model.ResponseRevision{}, &model.ResponseDraft{},
+		&model.ResponseNote{}, &model.ResponseActivity{},
+		&model.Webhook{}, &model.WebhookDelivery{}, &model.EmailMessage{},
+		&model.FormMember{}, &model.FormInvitation{}, &model.Workspace{}, &model.WorkspaceMember{},
+		&model.Folder{}, &model.FormStar{}, &model.ApiKey{},
+		&model.Identity{}); err != nil {
 		log.Fatal("failed to migrate:", err)
 	}
+
+	// Google logins were kept on users before identities existed
+	if err := db.Exec(`INSERT INTO identities (user_id, provider, subject, email)
+		SELECT id, 'google', "googleId", email FROM users WHERE "googleId" <> ''
+		ON CONFLICT (provider, subject) DO NOTHING`).Error; err != nil {
+		log.Fatal("failed to migrate google identities:", err)
+	}
 }
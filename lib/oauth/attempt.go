@@ -0,0 +1,89 @@
+package oauth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/gob"
+	"errors"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// AttemptSessionKey keeps the Attempt in the session
+	AttemptSessionKey = "oauth.attempt"
+	// AttemptTTL is how long the user has to come back from the provider
+	AttemptTTL = 10 * time.Minute
+)
+
+var (
+	ErrNoAttempt      = errors.New("no login in progress")
+	ErrStateMismatch  = errors.New("login state does not match")
+	ErrAttemptExpired = errors.New("login has expired")
+	ErrNonceMismatch  = errors.New("id token nonce does not match")
+)
+
+// Attempt is a login in progress. It is stored in the session of the user before the
+// redirect to the provider and checked by the callback, which ties the callback to the
+// browser that started the login.
+type Attempt struct {
+	Provider string
+	// State is echoed by the provider in the callback
+	State string
+	// Nonce is echoed by OpenID Connect providers in the ID token
+	Nonce string
+	// Verifier is the PKCE code verifier; its challenge goes with the redirect
+	Verifier string
+	// LinkUserID is set when a signed-in user links the provider to their account
+	// instead of signing in
+	LinkUserID string
+	CreatedAt  time.Time
+}
+
+func init() {
+	// Sessions keep values as gob
+	gob.Register(Attempt{})
+}
+
+func NewAttempt(provider string) (Attempt, error) {
+	state, err := randomString()
+	if err != nil {
+		return Attempt{}, err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return Attempt{}, err
+	}
+
+	return Attempt{
+		Provider:  provider,
+		State:     state,
+		Nonce:     nonce,
+		Verifier:  oauth2.GenerateVerifier(),
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Check verifies that the callback of the provider answers this attempt
+func (a Attempt) Check(provider, state string) error {
+	if a.State == "" {
+		return ErrNoAttempt
+	}
+	if a.Provider != provider || subtle.ConstantTimeCompare([]byte(a.State), []byte(state)) != 1 {
+		return ErrStateMismatch
+	}
+	if time.Since(a.CreatedAt) > AttemptTTL {
+		return ErrAttemptExpired
+	}
+	return nil
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
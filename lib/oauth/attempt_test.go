@@ -0,0 +1,49 @@
+package oauth
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAttemptCheck(t *testing.T) {
+	attempt, err := NewAttempt(GitHub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired := attempt
+	expired.CreatedAt = time.Now().Add(-AttemptTTL - time.Second)
+
+	tests := []struct {
+		name     string
+		attempt  Attempt
+		provider string
+		state    string
+		want     error
+	}{
+		{name: "matching", attempt: attempt, provider: GitHub, state: attempt.State},
+		{name: "no attempt", attempt: Attempt{}, provider: GitHub, state: "", want: ErrNoAttempt},
+		{name: "empty state", attempt: attempt, provider: GitHub, state: "", want: ErrStateMismatch},
+		{name: "another state", attempt: attempt, provider: GitHub, state: attempt.Nonce, want: ErrStateMismatch},
+		{name: "another provider", attempt: attempt, provider: Google, state: attempt.State, want: ErrStateMismatch},
+		{name: "expired", attempt: expired, provider: GitHub, state: attempt.State, want: ErrAttemptExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.attempt.Check(tt.provider, tt.state); !errors.Is(err, tt.want) {
+				t.Errorf("Check() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAttemptIsRandom(t *testing.T) {
+	a, _ := NewAttempt(Google)
+	b, _ := NewAttempt(Google)
+	if a.State == b.State || a.Nonce == b.Nonce || a.Verifier == b.Verifier {
+		t.Error("two attempts share a secret")
+	}
+	if a.State == a.Nonce {
+		t.Error("state and nonce are the same")
+	}
+}
@@ -0,0 +1,147 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/github"
+)
+
+const gitHubAPIURL = "https://api.github.com"
+
+type GitHubConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Endpoint and APIURL point to github.com when empty; set them for GitHub
+	// Enterprise or a test server
+	Endpoint oauth2.Endpoint
+	APIURL   string
+	// HTTPClient talks to GitHub; http.DefaultClient when nil
+	HTTPClient *http.Client
+}
+
+// GitHubProvider logs users in with GitHub, which speaks OAuth 2.0 but not OpenID
+// Connect: the user is read from the REST API instead of an ID token.
+type GitHubProvider struct {
+	oauth  *oauth2.Config
+	apiURL string
+	client *http.Client
+}
+
+type gitHubUser struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type gitHubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// GitHubEnterprise returns the endpoints of a GitHub Enterprise Server at baseURL
+func GitHubEnterprise(baseURL string) (oauth2.Endpoint, string) {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return oauth2.Endpoint{
+		AuthURL:  baseURL + "/login/oauth/authorize",
+		TokenURL: baseURL + "/login/oauth/access_token",
+	}, baseURL + "/api/v3"
+}
+
+func NewGitHubProvider(cfg GitHubConfig) *GitHubProvider {
+	endpoint := cfg.Endpoint
+	if endpoint.AuthURL == "" {
+		endpoint = github.Endpoint
+	}
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = gitHubAPIURL
+	}
+
+	return &GitHubProvider{
+		oauth: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     endpoint,
+			Scopes:       []string{"read:user", "user:email"},
+		},
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+		client: httpClient(cfg.HTTPClient),
+	}
+}
+
+func (p *GitHubProvider) Name() string {
+	return GitHub
+}
+
+func (p *GitHubProvider) AuthCodeURL(_ context.Context, attempt Attempt) (string, error) {
+	return p.oauth.AuthCodeURL(attempt.State, oauth2.S256ChallengeOption(attempt.Verifier)), nil
+}
+
+func (p *GitHubProvider) Exchange(ctx context.Context, attempt Attempt, code string) (*UserInfo, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.client)
+
+	token, err := p.oauth.Exchange(ctx, code, oauth2.VerifierOption(attempt.Verifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchange code: %w", err)
+	}
+	client := p.oauth.Client(ctx, token)
+
+	var user gitHubUser
+	if err := p.get(ctx, client, "/user", &user); err != nil {
+		return nil, err
+	}
+	var emails []gitHubEmail
+	if err := p.get(ctx, client, "/user/emails", &emails); err != nil {
+		return nil, err
+	}
+
+	info := &UserInfo{
+		Subject: strconv.FormatInt(user.ID, 10),
+		Name:    user.Name,
+		Picture: user.AvatarURL,
+	}
+	if info.Name == "" {
+		info.Name = user.Login
+	}
+	for _, e := range emails {
+		if e.Primary {
+			info.Email = e.Email
+			info.EmailVerified = e.Verified
+			break
+		}
+	}
+	if info.Email == "" {
+		return nil, ErrEmailRequired
+	}
+
+	return info, nil
+}
+
+func (p *GitHubProvider) get(ctx context.Context, client *http.Client, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("github %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("github %s: %s", path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
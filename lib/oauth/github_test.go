@@ -0,0 +1,127 @@
+package oauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestGitHub serves the token endpoint and REST API of a GitHub Enterprise Server
+// whose user has the emails
+func newTestGitHub(t *testing.T, attempt Attempt, emails []gitHubEmail) *GitHubProvider {
+	sum := sha256.Sum256([]byte(attempt.Verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		verifier := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if r.FormValue("code") != "code" || base64.RawURLEncoding.EncodeToString(verifier[:]) != challenge {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]string{"error": "bad_verification_code"})
+			return
+		}
+		writeJSON(w, map[string]any{"access_token": "access", "token_type": "bearer"})
+	})
+	authorized := func(next func(w http.ResponseWriter)) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer access" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			next(w)
+		}
+	}
+	mux.HandleFunc("/api/v3/user", authorized(func(w http.ResponseWriter) {
+		writeJSON(w, gitHubUser{ID: 42, Login: "ann", AvatarURL: "https://avatars.example.com/42"})
+	}))
+	mux.HandleFunc("/api/v3/user/emails", authorized(func(w http.ResponseWriter) {
+		writeJSON(w, emails)
+	}))
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	endpoint, apiURL := GitHubEnterprise(server.URL + "/")
+	return NewGitHubProvider(GitHubConfig{
+		ClientID:   "formify",
+		Endpoint:   endpoint,
+		APIURL:     apiURL,
+		HTTPClient: server.Client(),
+	})
+}
+
+func TestGitHubExchange(t *testing.T) {
+	tests := []struct {
+		name   string
+		emails []gitHubEmail
+		want   *UserInfo
+		err    error
+	}{
+		{
+			name: "verified primary",
+			emails: []gitHubEmail{
+				{Email: "old@example.com", Verified: true},
+				{Email: "ann@example.com", Primary: true, Verified: true},
+			},
+			want: &UserInfo{Subject: "42", Email: "ann@example.com", EmailVerified: true, Name: "ann", Picture: "https://avatars.example.com/42"},
+		},
+		{
+			// A verified secondary email does not stand in for the primary one
+			name: "unverified primary",
+			emails: []gitHubEmail{
+				{Email: "ann@example.com", Primary: true},
+				{Email: "other@example.com", Verified: true},
+			},
+			want: &UserInfo{Subject: "42", Email: "ann@example.com", Name: "ann", Picture: "https://avatars.example.com/42"},
+		},
+		{
+			name:   "no primary",
+			emails: []gitHubEmail{{Email: "ann@example.com", Verified: true}},
+			err:    ErrEmailRequired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempt, _ := NewAttempt(GitHub)
+			provider := newTestGitHub(t, attempt, tt.emails)
+
+			info, err := provider.Exchange(context.Background(), attempt, "code")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Exchange() error = %v, want %v", err, tt.err)
+			}
+			if tt.want != nil && *info != *tt.want {
+				t.Errorf("Exchange() = %+v, want %+v", *info, *tt.want)
+			}
+		})
+	}
+}
+
+func TestGitHubExchangeChecksVerifier(t *testing.T) {
+	attempt, _ := NewAttempt(GitHub)
+	provider := newTestGitHub(t, attempt, []gitHubEmail{{Email: "ann@example.com", Primary: true, Verified: true}})
+
+	other, _ := NewAttempt(GitHub)
+	attempt.Verifier = other.Verifier
+	if _, err := provider.Exchange(context.Background(), attempt, "code"); err == nil {
+		t.Error("Exchange() with another verifier succeeded")
+	}
+}
+
+func TestGitHubAuthCodeURL(t *testing.T) {
+	attempt, _ := NewAttempt(GitHub)
+	provider := newTestGitHub(t, attempt, nil)
+
+	redirect, err := provider.AuthCodeURL(context.Background(), attempt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := mustParseURL(t, redirect).Query()
+	if query.Get("state") != attempt.State || query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		t.Errorf("AuthCodeURL() = %s, want the state and an S256 challenge", redirect)
+	}
+}
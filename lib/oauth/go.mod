@@ -0,0 +1,18 @@
+module github.com/TrySquadDF/formify/lib/oauth
+
+go 1.24.0
+
+replace github.com/TrySquadDF/formify/lib/config => ../config
+
+require (
+	github.com/TrySquadDF/formify/lib/config v0.0.0-00010101000000-000000000000
+	github.com/coreos/go-oidc/v3 v3.14.1
+	github.com/go-jose/go-jose/v4 v4.0.5
+	golang.org/x/oauth2 v0.29.0
+)
+
+require (
+	github.com/joho/godotenv v1.5.1 // indirect
+	github.com/kelseyhightower/envconfig v1.4.0 // indirect
+	golang.org/x/crypto v0.36.0 // indirect
+)
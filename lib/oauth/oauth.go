@@ -0,0 +1,135 @@
+// Package oauth signs users in through external providers: any OpenID Connect issuer,
+// Google among them, and GitHub. Providers are looked up by name in a Registry; a login
+// is an Attempt kept in the session between the redirect and the callback, so that the
+// callback can check its state, PKCE verifier and nonce.
+package oauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/TrySquadDF/formify/lib/config"
+)
+
+const (
+	Google = "google"
+	GitHub = "github"
+
+	googleIssuer = "https://accounts.google.com"
+)
+
+var (
+	ErrUnknownProvider = errors.New("unknown login provider")
+	ErrEmailRequired   = errors.New("login provider did not return an email")
+)
+
+// UserInfo is what a provider tells about the user who logged in
+type UserInfo struct {
+	// Subject identifies the user at the provider and never changes
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+}
+
+// Provider is an external login.
+type Provider interface {
+	// Name is used in URLs and to tell providers apart
+	Name() string
+	// AuthCodeURL is where the user is sent to log in
+	AuthCodeURL(ctx context.Context, attempt Attempt) (string, error)
+	// Exchange trades the code of the callback for the user, checking it against the attempt
+	Exchange(ctx context.Context, attempt Attempt, code string) (*UserInfo, error)
+}
+
+type Registry struct {
+	providers map[string]Provider
+}
+
+func NewRegistry(providers ...Provider) *Registry {
+	r := &Registry{providers: make(map[string]Provider, len(providers))}
+	for _, p := range providers {
+		r.Register(p)
+	}
+	return r
+}
+
+// NewFx builds the registry of the providers enabled in the config. OpenID Connect
+// providers discover their endpoints on first use, so an issuer that is down does not
+// keep the server from starting.
+func NewFx(cfg config.Config) *Registry {
+	r := NewRegistry()
+	client := &http.Client{Timeout: cfg.OAuthTimeout}
+
+	if cfg.GOOGLE_CLIENT_ID != "" {
+		r.Register(NewOIDCProvider(OIDCConfig{
+			Name:         Google,
+			Issuer:       googleIssuer,
+			ClientID:     cfg.GOOGLE_CLIENT_ID,
+			ClientSecret: cfg.GOOGLE_CLIENT_SECRET,
+			// Google clients are registered with the old callback
+			RedirectURL: cfg.GetGoogleCallbackUrl(),
+			HTTPClient:  client,
+		}))
+	}
+
+	if cfg.GITHUB_CLIENT_ID != "" {
+		gitHub := GitHubConfig{
+			ClientID:     cfg.GITHUB_CLIENT_ID,
+			ClientSecret: cfg.GITHUB_CLIENT_SECRET,
+			RedirectURL:  cfg.GetOAuthCallbackUrl(GitHub),
+			HTTPClient:   client,
+		}
+		if cfg.GithubUrl != "" {
+			gitHub.Endpoint, gitHub.APIURL = GitHubEnterprise(cfg.GithubUrl)
+		}
+		r.Register(NewGitHubProvider(gitHub))
+	}
+
+	for _, p := range cfg.OidcProviders {
+		r.Register(NewOIDCProvider(OIDCConfig{
+			Name:         p.Name,
+			Issuer:       p.Issuer,
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			RedirectURL:  cfg.GetOAuthCallbackUrl(p.Name),
+			Scopes:       p.Scopes,
+			HTTPClient:   client,
+		}))
+	}
+
+	return r
+}
+
+// Register adds a provider, replacing one of the same name
+func (r *Registry) Register(p Provider) {
+	r.providers[p.Name()] = p
+}
+
+func (r *Registry) Get(name string) (Provider, error) {
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	return p, nil
+}
+
+// Names lists the registered providers in alphabetical order
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func httpClient(c *http.Client) *http.Client {
+	if c == nil {
+		return http.DefaultClient
+	}
+	return c
+}
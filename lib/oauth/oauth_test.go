@@ -0,0 +1,46 @@
+package oauth
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/TrySquadDF/formify/lib/config"
+)
+
+func TestNewFx(t *testing.T) {
+	cfg := config.Config{
+		SiteBaseUrl:      "https://formify.test",
+		GOOGLE_CLIENT_ID: "google",
+		GITHUB_CLIENT_ID: "github",
+		GithubUrl:        "https://github.example.com/",
+		OAuthTimeout:     5 * time.Second,
+		OidcProviders:    []config.OidcProvider{{Name: "okta", Issuer: "https://okta.example.com", ClientID: "okta"}},
+	}
+
+	r := NewFx(cfg)
+	if names := r.Names(); !slices.Equal(names, []string{GitHub, Google, "okta"}) {
+		t.Fatalf("Names() = %v", names)
+	}
+
+	p, _ := r.Get(GitHub)
+	gitHub := p.(*GitHubProvider)
+	if gitHub.oauth.Endpoint.TokenURL != "https://github.example.com/login/oauth/access_token" ||
+		gitHub.apiURL != "https://github.example.com/api/v3" {
+		t.Errorf("GitHub Enterprise endpoints = %+v, %s", gitHub.oauth.Endpoint, gitHub.apiURL)
+	}
+	if gitHub.client.Timeout != cfg.OAuthTimeout {
+		t.Errorf("GitHub client timeout = %v, want %v", gitHub.client.Timeout, cfg.OAuthTimeout)
+	}
+
+	for _, name := range []string{Google, "okta"} {
+		p, _ := r.Get(name)
+		oidc := p.(*OIDCProvider)
+		if oidc.config.HTTPClient == nil || oidc.config.HTTPClient.Timeout != cfg.OAuthTimeout {
+			t.Errorf("%s client has no timeout", name)
+		}
+	}
+	if _, err := r.Get("unknown"); err != ErrUnknownProvider {
+		t.Errorf("Get(unknown) error = %v, want ErrUnknownProvider", err)
+	}
+}
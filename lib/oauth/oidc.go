@@ -0,0 +1,167 @@
+package oauth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+var ErrNoIDToken = errors.New("token response has no id_token")
+
+type OIDCConfig struct {
+	Name string
+	// Issuer is the URL the discovery document is read from, and must match the
+	// issuer it declares
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Scopes are asked for besides openid; email and profile when empty
+	Scopes []string
+	// HTTPClient talks to the issuer; http.DefaultClient when nil
+	HTTPClient *http.Client
+}
+
+// OIDCProvider logs users in with any OpenID Connect issuer. The endpoints and signing
+// keys of the issuer come from its discovery document, read on first use.
+type OIDCProvider struct {
+	config OIDCConfig
+
+	mu       sync.Mutex
+	oauth    *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+	userInfo func(ctx context.Context, token *oauth2.Token) (*oidc.UserInfo, error)
+}
+
+func NewOIDCProvider(cfg OIDCConfig) *OIDCProvider {
+	return &OIDCProvider{config: cfg}
+}
+
+func (p *OIDCProvider) Name() string {
+	return p.config.Name
+}
+
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, attempt Attempt) (string, error) {
+	if err := p.discover(ctx); err != nil {
+		return "", err
+	}
+
+	return p.oauth.AuthCodeURL(attempt.State,
+		oidc.Nonce(attempt.Nonce),
+		oauth2.S256ChallengeOption(attempt.Verifier),
+	), nil
+}
+
+func (p *OIDCProvider) Exchange(ctx context.Context, attempt Attempt, code string) (*UserInfo, error) {
+	if err := p.discover(ctx); err != nil {
+		return nil, err
+	}
+	ctx = p.clientContext(ctx)
+
+	token, err := p.oauth.Exchange(ctx, code, oauth2.VerifierOption(attempt.Verifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchange code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, ErrNoIDToken
+	}
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("verify id token: %w", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(attempt.Nonce)) != 1 {
+		return nil, ErrNonceMismatch
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified *bool  `json:"email_verified"`
+		Name          string `json:"name"`
+		Picture       string `json:"picture"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("read id token claims: %w", err)
+	}
+
+	info := &UserInfo{
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified != nil && *claims.EmailVerified,
+		Name:          claims.Name,
+		Picture:       claims.Picture,
+	}
+
+	// Some issuers keep the email out of the ID token
+	if info.Email == "" && p.userInfo != nil {
+		extra, err := p.userInfo(ctx, token)
+		if err != nil {
+			return nil, fmt.Errorf("get user info: %w", err)
+		}
+		// The userinfo endpoint may not speak for another subject
+		if extra.Subject == info.Subject {
+			info.Email = extra.Email
+			info.EmailVerified = extra.EmailVerified
+		}
+	}
+	if info.Email == "" {
+		return nil, ErrEmailRequired
+	}
+
+	return info, nil
+}
+
+// discover reads the discovery document of the issuer once. A failure is not kept, so
+// the next login tries again.
+func (p *OIDCProvider) discover(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.oauth != nil {
+		return nil
+	}
+
+	provider, err := oidc.NewProvider(p.clientContext(ctx), p.config.Issuer)
+	if err != nil {
+		return fmt.Errorf("discover %s: %w", p.config.Name, err)
+	}
+
+	scopes := []string{oidc.ScopeOpenID}
+	for _, scope := range p.config.Scopes {
+		if scope != oidc.ScopeOpenID {
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 1 {
+		scopes = append(scopes, "email", "profile")
+	}
+
+	p.verifier = provider.Verifier(&oidc.Config{ClientID: p.config.ClientID})
+	if provider.UserInfoEndpoint() != "" {
+		p.userInfo = func(ctx context.Context, token *oauth2.Token) (*oidc.UserInfo, error) {
+			return provider.UserInfo(ctx, oauth2.StaticTokenSource(token))
+		}
+	}
+	p.oauth = &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       scopes,
+	}
+	return nil
+}
+
+// clientContext makes both oidc and oauth2 use the configured HTTP client
+func (p *OIDCProvider) clientContext(ctx context.Context) context.Context {
+	client := httpClient(p.config.HTTPClient)
+	ctx = oidc.ClientContext(ctx, client)
+	return context.WithValue(ctx, oauth2.HTTPClient, client)
+}
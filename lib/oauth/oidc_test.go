@@ -0,0 +1,303 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+const testClientID = "formify"
+
+// testIssuer is an OpenID Connect issuer serving discovery, its signing keys and a
+// token endpoint that checks the PKCE verifier of the code it issued
+type testIssuer struct {
+	*httptest.Server
+	t   *testing.T
+	key *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]issuedCode
+}
+
+type issuedCode struct {
+	challenge string
+	claims    map[string]any
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer := &testIssuer{t: t, key: key, codes: map[string]issuedCode{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{
+			"issuer":                                issuer.URL,
+			"authorization_endpoint":                issuer.URL + "/authorize",
+			"token_endpoint":                        issuer.URL + "/token",
+			"jwks_uri":                              issuer.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "test", Algorithm: string(jose.RS256), Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/token", issuer.token)
+	issuer.Server = httptest.NewServer(mux)
+	t.Cleanup(issuer.Close)
+
+	return issuer
+}
+
+func (i *testIssuer) provider() *OIDCProvider {
+	return NewOIDCProvider(OIDCConfig{
+		Name:        "test",
+		Issuer:      i.URL,
+		ClientID:    testClientID,
+		RedirectURL: "https://formify.test/oauth/test/callback",
+		HTTPClient:  i.Client(),
+	})
+}
+
+// authorize plays the user logging in at the issuer: it returns a code for the
+// challenge of the redirect, whose ID token carries the claims and the nonce of
+// the redirect unless the claims set one
+func (i *testIssuer) authorize(redirect string, claims map[string]any) string {
+	u, err := url.Parse(redirect)
+	if err != nil {
+		i.t.Fatal(err)
+	}
+	query := u.Query()
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		i.t.Fatalf("redirect %s has no S256 code challenge", redirect)
+	}
+
+	all := map[string]any{
+		"iss":   i.URL,
+		"aud":   testClientID,
+		"sub":   "user-1",
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"nonce": query.Get("nonce"),
+	}
+	for k, v := range claims {
+		all[k] = v
+	}
+
+	code := randomCode(i.t)
+	i.mu.Lock()
+	i.codes[code] = issuedCode{challenge: query.Get("code_challenge"), claims: all}
+	i.mu.Unlock()
+	return code
+}
+
+func (i *testIssuer) token(w http.ResponseWriter, r *http.Request) {
+	i.mu.Lock()
+	issued, ok := i.codes[r.FormValue("code")]
+	delete(i.codes, r.FormValue("code"))
+	i.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != issued.challenge {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		writeJSON(w, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: i.key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "test"),
+	)
+	if err != nil {
+		i.t.Fatal(err)
+	}
+	idToken, err := jwt.Signed(signer).Claims(issued.claims).Serialize()
+	if err != nil {
+		i.t.Fatal(err)
+	}
+
+	writeJSON(w, map[string]any{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func TestOIDCExchange(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := issuer.provider()
+	ctx := context.Background()
+
+	attempt, err := NewAttempt("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	redirect, err := provider.AuthCodeURL(ctx, attempt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state := mustParseURL(t, redirect).Query().Get("state"); state != attempt.State {
+		t.Errorf("redirect state = %q, want %q", state, attempt.State)
+	}
+
+	code := issuer.authorize(redirect, map[string]any{"email": "ann@example.com", "email_verified": true, "name": "Ann"})
+	info, err := provider.Exchange(ctx, attempt, code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := UserInfo{Subject: "user-1", Email: "ann@example.com", EmailVerified: true, Name: "Ann"}
+	if *info != want {
+		t.Errorf("Exchange() = %+v, want %+v", *info, want)
+	}
+}
+
+func TestOIDCExchangeUnverifiedEmail(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := issuer.provider()
+	ctx := context.Background()
+
+	attempt, _ := NewAttempt("test")
+	redirect, err := provider.AuthCodeURL(ctx, attempt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An issuer that says nothing about the email does not vouch for it
+	code := issuer.authorize(redirect, map[string]any{"email": "ann@example.com"})
+	info, err := provider.Exchange(ctx, attempt, code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.EmailVerified {
+		t.Error("Exchange() reports an email without email_verified as verified")
+	}
+}
+
+func TestOIDCExchangeRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		// change alters what the callback presents compared to the login that was started
+		change func(attempt *Attempt, claims map[string]any)
+		want   error
+	}{
+		{
+			name: "another verifier",
+			change: func(attempt *Attempt, _ map[string]any) {
+				other, _ := NewAttempt("test")
+				attempt.Verifier = other.Verifier
+			},
+		},
+		{
+			name:   "another nonce",
+			change: func(_ *Attempt, claims map[string]any) { claims["nonce"] = "replayed" },
+			want:   ErrNonceMismatch,
+		},
+		{
+			name:   "another audience",
+			change: func(_ *Attempt, claims map[string]any) { claims["aud"] = "someone-else" },
+		},
+		{
+			name:   "another issuer",
+			change: func(_ *Attempt, claims map[string]any) { claims["iss"] = "https://evil.example.com" },
+		},
+		{
+			name:   "expired id token",
+			change: func(_ *Attempt, claims map[string]any) { claims["exp"] = time.Now().Add(-time.Hour).Unix() },
+		},
+		{
+			name: "no email",
+			change: func(_ *Attempt, claims map[string]any) {
+				delete(claims, "email")
+			},
+			want: ErrEmailRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := newTestIssuer(t)
+			provider := issuer.provider()
+			ctx := context.Background()
+
+			attempt, _ := NewAttempt("test")
+			redirect, err := provider.AuthCodeURL(ctx, attempt)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			claims := map[string]any{"email": "ann@example.com", "email_verified": true}
+			tt.change(&attempt, claims)
+			code := issuer.authorize(redirect, claims)
+
+			_, err = provider.Exchange(ctx, attempt, code)
+			if err == nil {
+				t.Fatal("Exchange() succeeded")
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Exchange() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestOIDCCodeIsUsedOnce(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := issuer.provider()
+	ctx := context.Background()
+
+	attempt, _ := NewAttempt("test")
+	redirect, err := provider.AuthCodeURL(ctx, attempt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := issuer.authorize(redirect, map[string]any{"email": "ann@example.com"})
+
+	if _, err := provider.Exchange(ctx, attempt, code); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := provider.Exchange(ctx, attempt, code); err == nil {
+		t.Error("second Exchange() with the same code succeeded")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func mustParseURL(t *testing.T, raw string) *url.URL {
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func randomCode(t *testing.T) string {
+	code, err := randomString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
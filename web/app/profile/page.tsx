@@ -17,10 +17,13 @@ export default function Profile() {
     const forms = useMemo(()=>  data?.me?.forms || [], [data]);
 
     const handleUpdateAccess = async (formId: string, newAccess: "PUBLIC" | "PRIVATE") => {
+        const form = forms.find((f) => f.id === formId);
+        if (!form) return;
         try {
             await updateFormAccess(
                 formId,
-                newAccess as FormAccess
+                newAccess as FormAccess,
+                form.version
             );
             
             await refetch();
@@ -11,6 +11,7 @@ const getMyForms = gql`
                     access
                     createdAt
                     updatedAt
+                    version
                 }
             }
         }
@@ -2,12 +2,13 @@ import { gql, useMutation } from '@apollo/client';
 import { FormAccess } from '@/src/gql/graphql';
 
 const UPDATE_FORM_ACCESS = gql`
-  mutation UpdateFormAccess($id: ID!, $access: FormAccess!) {
-    updateForm(id: $id, input: { access: $access }) {
+  mutation UpdateFormAccess($id: ID!, $access: FormAccess!, $version: Int!) {
+    updateForm(id: $id, input: { access: $access, version: $version }) {
       id
       title
       access
       updatedAt
+      version
     }
   }
 `;
@@ -16,6 +17,8 @@ const UPDATE_FORM_ACCESS = gql`
 type UpdateFormAccessVariables = {
   id: string;
   access: FormAccess;
+  // Версия формы, которую видел пользователь
+  version: number;
 };
 
 type UpdateFormAccessResponse = {
@@ -24,6 +27,7 @@ type UpdateFormAccessResponse = {
     title: string;
     access: FormAccess;
     updatedAt: string;
+    version: number;
   };
 };
 
@@ -33,12 +37,13 @@ export const useUpdateFormAccess = () => {
     UpdateFormAccessVariables
   >(UPDATE_FORM_ACCESS);
 
-  const updateFormAccess = async (formId: string, newAccess: FormAccess) => {
+  const updateFormAccess = async (formId: string, newAccess: FormAccess, version: number) => {
     try {
       const response = await mutate({
         variables: {
           id: formId,
           access: newAccess,
+          version,
         },
       });
       return response.data?.updateForm;
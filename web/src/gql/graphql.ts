@@ -47,6 +47,7 @@ export type Form = {
   questions?: Maybe<Array<Question>>;
   title: Scalars['String']['output'];
   updatedAt: Scalars['String']['output'];
+  version: Scalars['Int']['output'];
 };
 
 export enum FormAccess {
@@ -81,6 +82,7 @@ export type FormUpdateInput = {
   description?: InputMaybe<Scalars['String']['input']>;
   questions?: InputMaybe<Array<QuestionInput>>;
   title?: InputMaybe<Scalars['String']['input']>;
+  version: Scalars['Int']['input'];
 };
 
 export type Mutation = {